@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+)
+
+// Deposit contains handler functions of the CLI commands related to deposit data.
+type Deposit struct {
+	printer printer.Printer
+}
+
+// New is the constructor of Deposit handler.
+func New(printer printer.Printer) *Deposit {
+	return &Deposit{
+		printer: printer,
+	}
+}
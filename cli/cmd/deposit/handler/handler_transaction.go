@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/deposit/flag"
+	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
+)
+
+// Transaction builds an unsigned deposit transaction for every deposit of a deposit data file and prints them.
+// Deposits are verified first, the command fails if any deposit is invalid.
+func (h *Deposit) Transaction(cmd *cobra.Command, _ []string) error {
+	// Get network flag.
+	network, err := rootcmd.GetNetworkFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the network flag value")
+	}
+
+	// Get file flag.
+	file, err := flag.GetFileFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the file flag value")
+	}
+
+	// Get nonce flag.
+	nonce, err := rootcmd.GetNonceFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the nonce flag value")
+	}
+
+	// Get max fee per gas flag.
+	maxFeePerGas, err := rootcmd.GetMaxFeePerGasFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the max fee per gas flag value")
+	}
+
+	// Get max priority fee per gas flag.
+	maxPriorityFeePerGas, err := rootcmd.GetMaxPriorityFeePerGasFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the max priority fee per gas flag value")
+	}
+
+	byts, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return errors.Wrap(err, "failed to read deposit data file")
+	}
+	deposits, err := eth1deposit.ParseDepositDataJSON(byts)
+	if err != nil {
+		return err
+	}
+
+	report, err := eth1deposit.VerifyDepositData(deposits, network)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify deposit data")
+	}
+	for _, verification := range report {
+		if !verification.Valid {
+			return errors.Errorf("deposit %d is invalid: %s", verification.Index, strings.Join(verification.Errors, ", "))
+		}
+	}
+
+	var transactions []map[string]interface{}
+	for i, deposit := range deposits {
+		depositData, err := deposit.DepositData()
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse deposit %d", i)
+		}
+		tx, err := eth1deposit.DepositTransaction(depositData, network)
+		if err != nil {
+			return errors.Wrapf(err, "failed to build the transaction of deposit %d", i)
+		}
+		tx.Nonce = nonce + uint64(i)
+		tx.MaxFeePerGas = maxFeePerGas
+		tx.MaxPriorityFeePerGas = maxPriorityFeePerGas
+
+		unsigned, err := tx.MarshalBinary()
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode the transaction of deposit %d", i)
+		}
+		signingHash, err := tx.SigningHash()
+		if err != nil {
+			return errors.Wrapf(err, "failed to hash the transaction of deposit %d", i)
+		}
+
+		transactions = append(transactions, map[string]interface{}{
+			"pubkey":              deposit.PubKey,
+			"transaction":         tx,
+			"unsignedTransaction": "0x" + hex.EncodeToString(unsigned),
+			"signingHash":         "0x" + hex.EncodeToString(signingHash[:]),
+		})
+	}
+
+	err = h.printer.JSON(transactions)
+	if err != nil {
+		return errors.Wrap(err, "failed to print deposit transactions JSON")
+	}
+	return nil
+}
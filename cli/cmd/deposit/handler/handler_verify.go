@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/deposit/flag"
+	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
+)
+
+// Verify verifies the deposits of a deposit data file and prints a report per deposit.
+// It fails if any deposit is invalid.
+func (h *Deposit) Verify(cmd *cobra.Command, _ []string) error {
+	// Get network flag.
+	network, err := rootcmd.GetNetworkFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the network flag value")
+	}
+
+	// Get file flag.
+	file, err := flag.GetFileFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the file flag value")
+	}
+
+	byts, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return errors.Wrap(err, "failed to read deposit data file")
+	}
+	deposits, err := eth1deposit.ParseDepositDataJSON(byts)
+	if err != nil {
+		return err
+	}
+
+	report, err := eth1deposit.VerifyDepositData(deposits, network)
+	if err != nil {
+		return errors.Wrap(err, "failed to verify deposit data")
+	}
+
+	err = h.printer.JSON(report)
+	if err != nil {
+		return errors.Wrap(err, "failed to print deposit data report JSON")
+	}
+
+	invalid := 0
+	for _, verification := range report {
+		if !verification.Valid {
+			invalid++
+		}
+	}
+	if invalid > 0 {
+		return errors.Errorf("%d of %d deposits failed the verification", invalid, len(report))
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package deposit
+
+import (
+	"github.com/spf13/cobra"
+
+	keyvaultcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+)
+
+// Command represents the deposit related command.
+var Command = &cobra.Command{
+	Use:   "deposit",
+	Short: "Manage deposit data",
+}
+
+func init() {
+	keyvaultcmd.RootCmd.AddCommand(Command)
+}
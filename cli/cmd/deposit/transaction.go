@@ -0,0 +1,31 @@
+package deposit
+
+import (
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/deposit/flag"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/deposit/handler"
+)
+
+// transactionCmd represents the deposit transaction command.
+var transactionCmd = &cobra.Command{
+	Use:   "transaction",
+	Short: "Builds unsigned deposit transactions.",
+	Long:  `This command builds an unsigned EIP-1559 deposit contract transaction for every deposit of a staking-deposit-cli deposit_data file, to be signed offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler := handler.New(rootcmd.ResultPrinter)
+		return handler.Transaction(cmd, args)
+	},
+}
+
+func init() {
+	// Define flags for the command.
+	rootcmd.AddNetworkFlag(transactionCmd)
+	flag.AddFileFlag(transactionCmd)
+	rootcmd.AddNonceFlag(transactionCmd)
+	rootcmd.AddMaxFeePerGasFlag(transactionCmd)
+	rootcmd.AddMaxPriorityFeePerGasFlag(transactionCmd)
+
+	Command.AddCommand(transactionCmd)
+}
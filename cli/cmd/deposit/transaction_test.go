@@ -0,0 +1,87 @@
+package deposit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
+)
+
+func TestDepositTransaction(t *testing.T) {
+	t.Run("Successfully build deposit transactions", func(t *testing.T) {
+		file := writeDepositDataFile(t, func(deposits []*eth1deposit.DepositDataJSON) {})
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"deposit",
+			"transaction",
+			"--file=" + file,
+			"--network=holesky",
+			"--nonce=5",
+			"--max-fee-per-gas=30",
+			"--max-priority-fee-per-gas=1.5",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var transactions []map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &transactions))
+		require.Len(t, transactions, 2)
+		for i, expectedNonce := range []string{"0x5", "0x6"} {
+			tx := transactions[i]["transaction"].(map[string]interface{})
+			require.Equal(t, "0x4268", tx["chainId"])
+			require.Equal(t, expectedNonce, tx["nonce"])
+			require.Equal(t, "0x6fc23ac00", tx["maxFeePerGas"])
+			require.Equal(t, "0x59682f00", tx["maxPriorityFeePerGas"])
+			require.Equal(t, "0x4242424242424242424242424242424242424242", tx["to"])
+			require.Equal(t, "0x1bc16d674ec800000", tx["value"])
+			require.True(t, strings.HasPrefix(tx["input"].(string), "0x22895118"))
+			require.True(t, strings.HasPrefix(transactions[i]["unsignedTransaction"].(string), "0x02f901"))
+			require.Len(t, transactions[i]["signingHash"], 66)
+		}
+	})
+
+	t.Run("Fail build transactions of invalid deposits", func(t *testing.T) {
+		file := writeDepositDataFile(t, func(deposits []*eth1deposit.DepositDataJSON) {
+			deposits[0].Amount = 31000000000
+		})
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"deposit",
+			"transaction",
+			"--file=" + file,
+			"--network=holesky",
+			"--nonce=0",
+			"--max-fee-per-gas=30",
+			"--max-priority-fee-per-gas=1",
+		})
+		err := cmd.RootCmd.Execute()
+		require.Error(t, err)
+		require.True(t, strings.HasPrefix(err.Error(), "deposit 0 is invalid: deposit_message_root"), err.Error())
+	})
+
+	t.Run("Fail build transactions with invalid fees", func(t *testing.T) {
+		file := writeDepositDataFile(t, func(deposits []*eth1deposit.DepositDataJSON) {})
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"deposit",
+			"transaction",
+			"--file=" + file,
+			"--network=holesky",
+			"--nonce=0",
+			"--max-fee-per-gas=0.0000000001",
+			"--max-priority-fee-per-gas=1",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "failed to retrieve the max fee per gas flag value: invalid Gwei amount 0.0000000001")
+	})
+}
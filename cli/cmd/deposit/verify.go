@@ -0,0 +1,28 @@
+package deposit
+
+import (
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/deposit/flag"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/deposit/handler"
+)
+
+// verifyCmd represents the verify deposit command.
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifies a deposit data file.",
+	Long:  `This command verifies the roots, signatures and withdrawal credentials of a staking-deposit-cli deposit_data file and prints a report per deposit.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler := handler.New(rootcmd.ResultPrinter)
+		return handler.Verify(cmd, args)
+	},
+}
+
+func init() {
+	// Define flags for the command.
+	rootcmd.AddNetworkFlag(verifyCmd)
+	flag.AddFileFlag(verifyCmd)
+
+	Command.AddCommand(verifyCmd)
+}
@@ -0,0 +1,115 @@
+package deposit_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
+)
+
+func writeDepositDataFile(t *testing.T, modify func(deposits []*eth1deposit.DepositDataJSON)) string {
+	require.NoError(t, core.InitBLS())
+	var deposits []*eth1deposit.DepositDataJSON
+	for _, privKey := range []string{
+		"175db1c5411459893301c3f2ebe740e5da07db8f17c2df4fa0be6d31a48a4f79",
+		"3a2c8e5a3a4f0a1e9a25e2fe7e0e4f0b5c4c1b5e4a0c1e2f3a4b5c6d7e8f9a0b",
+	} {
+		privKeyBytes, err := hex.DecodeString(privKey)
+		require.NoError(t, err)
+		key, err := core.NewHDKeyFromPrivateKey(privKeyBytes, "")
+		require.NoError(t, err)
+		depositData, _, err := eth1deposit.DepositData(key, key.PublicKey().Serialize(), core.HoleskyNetwork, eth1deposit.MaxEffectiveBalanceInGwei)
+		require.NoError(t, err)
+		deposit, err := eth1deposit.NewDepositDataJSON(depositData, core.HoleskyNetwork)
+		require.NoError(t, err)
+		deposits = append(deposits, deposit)
+	}
+	modify(deposits)
+
+	byts, err := json.Marshal(deposits)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "deposit_data-1700000000.json")
+	require.NoError(t, os.WriteFile(file, byts, 0600))
+	return file
+}
+
+func TestDepositVerify(t *testing.T) {
+	t.Run("Successfully verify deposit data", func(t *testing.T) {
+		file := writeDepositDataFile(t, func(deposits []*eth1deposit.DepositDataJSON) {})
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"deposit",
+			"verify",
+			"--file=" + file,
+			"--network=holesky",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var report []*eth1deposit.DepositVerification
+		require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+		require.Len(t, report, 2)
+		for _, verification := range report {
+			require.True(t, verification.Valid)
+			require.Empty(t, verification.Errors)
+			require.Empty(t, verification.Warnings)
+		}
+	})
+
+	t.Run("Fail verify deposit data of another network", func(t *testing.T) {
+		file := writeDepositDataFile(t, func(deposits []*eth1deposit.DepositDataJSON) {})
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"deposit",
+			"verify",
+			"--file=" + file,
+			"--network=mainnet",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "2 of 2 deposits failed the verification")
+
+		var report []*eth1deposit.DepositVerification
+		require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+		require.Len(t, report, 2)
+		require.False(t, report[0].Valid)
+		require.Contains(t, report[0].Errors, "network_name holesky doesn't match mainnet")
+		require.Contains(t, report[0].Errors, "signature doesn't match the pubkey and the genesis fork domain")
+	})
+
+	t.Run("Fail verify tampered deposit data with duplicate pubkeys", func(t *testing.T) {
+		file := writeDepositDataFile(t, func(deposits []*eth1deposit.DepositDataJSON) {
+			tampered := *deposits[0]
+			tampered.WithdrawalCredentials = "01" + tampered.WithdrawalCredentials[2:]
+			deposits[1] = &tampered
+		})
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"deposit",
+			"verify",
+			"--file=" + file,
+			"--network=holesky",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "1 of 2 deposits failed the verification")
+
+		var report []*eth1deposit.DepositVerification
+		require.NoError(t, json.Unmarshal(output.Bytes(), &report))
+		require.Len(t, report, 2)
+		require.True(t, report[0].Valid)
+		require.False(t, report[1].Valid)
+		require.Equal(t, []string{"duplicate pubkey, also deposited by entry 0"}, report[1].Warnings)
+		require.Contains(t, report[1].Errors, "execution withdrawal credentials must have 11 zero bytes after the prefix")
+	})
+}
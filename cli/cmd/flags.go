@@ -87,6 +87,11 @@ func AddIndexFlag(c *cobra.Command) {
 	cliflag.AddPersistentIntFlag(c, indexFlag, 0, "public key index", true)
 }
 
+// AddOptionalIndexFlag adds the index flag to the command, 0 if it's not set
+func AddOptionalIndexFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, indexFlag, 0, "public key index", false)
+}
+
 // GetIndexFlagValue gets the index flag from the command
 func GetIndexFlagValue(c *cobra.Command) (int, error) {
 	return c.Flags().GetInt(indexFlag)
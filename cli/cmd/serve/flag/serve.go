@@ -0,0 +1,100 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/util/cliflag"
+)
+
+// Flag names.
+const (
+	listenAddressFlag   = "listen-address"
+	storageFlag         = "storage"
+	dbPathFlag          = "db-path"
+	passwordFileFlag    = "password-file"
+	interchangeFileFlag = "interchange-file"
+
+	keymanagerListenAddressFlag = "keymanager-listen-address"
+	keymanagerTokenFlag         = "keymanager-token"
+	keymanagerTokenFileFlag     = "keymanager-token-file"
+)
+
+// AddListenAddressFlag adds the listen address flag to the command
+func AddListenAddressFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, listenAddressFlag, ":9000", "address the server listens on", false)
+}
+
+// GetListenAddressFlagValue gets the listen address flag from the command
+func GetListenAddressFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(listenAddressFlag)
+}
+
+// AddStorageFlag adds the storage flag to the command
+func AddStorageFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, storageFlag, "", "key-vault storage, slashing data is kept in memory only", false)
+}
+
+// GetStorageFlagValue gets the storage flag from the command
+func GetStorageFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(storageFlag)
+}
+
+// AddDBPathFlag adds the db path flag to the command
+func AddDBPathFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, dbPathFlag, "", "path of a bolt key-vault database", false)
+}
+
+// GetDBPathFlagValue gets the db path flag from the command
+func GetDBPathFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(dbPathFlag)
+}
+
+// AddPasswordFileFlag adds the password file flag to the command
+func AddPasswordFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, passwordFileFlag, "", "file holding the password of encrypted storage or db-path keys", false)
+}
+
+// GetPasswordFileFlagValue gets the password file flag from the command
+func GetPasswordFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(passwordFileFlag)
+}
+
+// AddInterchangeFileFlag adds the interchange file flag to the command
+func AddInterchangeFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, interchangeFileFlag, "", "EIP-3076 slashing protection file imported before serving", false)
+}
+
+// GetInterchangeFileFlagValue gets the interchange file flag from the command
+func GetInterchangeFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(interchangeFileFlag)
+}
+
+// AddKeymanagerListenAddressFlag adds the keymanager listen address flag to the command
+func AddKeymanagerListenAddressFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, keymanagerListenAddressFlag, "", "address the keymanager API listens on, disabled if empty", false)
+}
+
+// GetKeymanagerListenAddressFlagValue gets the keymanager listen address flag from the command
+func GetKeymanagerListenAddressFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(keymanagerListenAddressFlag)
+}
+
+// AddKeymanagerTokenFlag adds the keymanager token flag to the command
+func AddKeymanagerTokenFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, keymanagerTokenFlag, "", "bearer token required by the keymanager API", false)
+}
+
+// GetKeymanagerTokenFlagValue gets the keymanager token flag from the command
+func GetKeymanagerTokenFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(keymanagerTokenFlag)
+}
+
+// AddKeymanagerTokenFileFlag adds the keymanager token file flag to the command
+func AddKeymanagerTokenFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, keymanagerTokenFileFlag, "", "file of the keymanager API token, generated if missing, used without keymanager-token", false)
+}
+
+// GetKeymanagerTokenFileFlagValue gets the keymanager token file flag from the command
+func GetKeymanagerTokenFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(keymanagerTokenFileFlag)
+}
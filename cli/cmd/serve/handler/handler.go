@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// Serve contains handler functions of the CLI commands related to the remote signer server.
+type Serve struct {
+	printer printer.Printer
+	network core.Network
+}
+
+// New is the constructor of Serve handler.
+func New(printer printer.Printer, network core.Network) *Serve {
+	return &Serve{
+		printer: printer,
+		network: network,
+	}
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/serve/flag"
+	accountflag "github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	encryptor2 "github.com/ssvlabs/eth2-key-manager/encryptor"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+	"github.com/ssvlabs/eth2-key-manager/server/keymanager"
+	"github.com/ssvlabs/eth2-key-manager/server/web3signer"
+	"github.com/ssvlabs/eth2-key-manager/signer"
+	slashingprotection "github.com/ssvlabs/eth2-key-manager/slashing_protection"
+	"github.com/ssvlabs/eth2-key-manager/slashing_protection/interchange"
+	"github.com/ssvlabs/eth2-key-manager/stores/bolt"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+)
+
+// store is the storage the server signs from
+type store interface {
+	core.Storage
+	core.SlashingStore
+}
+
+// Serve starts a Web3Signer compatible remote signing server.
+func (h *Serve) Serve(cmd *cobra.Command, _ []string) error {
+	err := core.InitBLS()
+	if err != nil {
+		return errors.Wrap(err, "failed to init BLS")
+	}
+
+	listenAddress, err := flag.GetListenAddressFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the listen address flag value")
+	}
+
+	keymanagerAddress, err := flag.GetKeymanagerListenAddressFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the keymanager listen address flag value")
+	}
+	var keymanagerToken string
+	if len(keymanagerAddress) > 0 {
+		if keymanagerToken, err = h.keymanagerToken(cmd); err != nil {
+			return err
+		}
+	}
+
+	s, closeStore, err := h.openStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	interchangeFile, err := flag.GetInterchangeFileFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the interchange file flag value")
+	}
+	if len(interchangeFile) > 0 {
+		byts, err := os.ReadFile(interchangeFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read interchange file")
+		}
+		if err := interchange.Import(byts, s, h.network); err != nil {
+			return errors.Wrap(err, "failed to import interchange file")
+		}
+	}
+
+	options := &eth2keymanager.KeyVaultOptions{}
+	options.SetStorage(s)
+	vault, err := eth2keymanager.OpenKeyVault(options)
+	if err != nil {
+		return errors.Wrap(err, "failed to open key vault")
+	}
+	wallet, err := vault.Wallet()
+	if err != nil {
+		return errors.Wrap(err, "failed to open wallet")
+	}
+
+	// both servers share the wallet so keys managed through the keymanager API are signable right away
+	errs := make(chan error, 2)
+	if len(keymanagerAddress) > 0 {
+		keymanagerServer, err := keymanager.New(vault, wallet, s, keymanagerToken)
+		if err != nil {
+			return errors.Wrap(err, "failed to create keymanager API server")
+		}
+		h.printer.Text(fmt.Sprintf("keymanager API listening on %s", keymanagerAddress))
+		go func() {
+			errs <- http.ListenAndServe(keymanagerAddress, keymanagerServer)
+		}()
+	}
+
+	simpleSigner := signer.NewSimpleSigner(wallet, slashingprotection.NewNormalProtection(s), h.network)
+	server := web3signer.New(wallet, simpleSigner, h.network)
+
+	h.printer.Text(fmt.Sprintf("listening on %s", listenAddress))
+	go func() {
+		errs <- http.ListenAndServe(listenAddress, server)
+	}()
+	return <-errs
+}
+
+// keymanagerToken returns the keymanager API token of the flag, otherwise the one of the token file.
+// A missing token file is created with a random token, readable by the owner only.
+func (h *Serve) keymanagerToken(cmd *cobra.Command) (string, error) {
+	token, err := flag.GetKeymanagerTokenFlagValue(cmd)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve the keymanager token flag value")
+	}
+	if len(token) > 0 {
+		return token, nil
+	}
+
+	tokenFile, err := flag.GetKeymanagerTokenFileFlagValue(cmd)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to retrieve the keymanager token file flag value")
+	}
+	if len(tokenFile) == 0 {
+		return "", errors.New("either keymanager-token or keymanager-token-file is required by the keymanager API")
+	}
+
+	byts, err := os.ReadFile(tokenFile)
+	switch {
+	case err == nil:
+		token = strings.TrimSpace(string(byts))
+		if len(token) == 0 {
+			return "", errors.Errorf("keymanager token file %s is empty", tokenFile)
+		}
+		return token, nil
+	case !os.IsNotExist(err):
+		return "", errors.Wrap(err, "failed to read keymanager token file")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "failed to generate keymanager token")
+	}
+	token = hex.EncodeToString(secret)
+	if err := os.WriteFile(tokenFile, []byte(token), 0600); err != nil {
+		return "", errors.Wrap(err, "failed to write keymanager token file")
+	}
+	h.printer.Text(fmt.Sprintf("keymanager API token written to %s", tokenFile))
+	return token, nil
+}
+
+// openStore opens the storage or bolt database of the flags, keys are decrypted with the password of the password file.
+// The returned function closes the underlying storage.
+func (h *Serve) openStore(cmd *cobra.Command) (store, func(), error) {
+	storageFlagValue, err := flag.GetStorageFlagValue(cmd)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the storage flag value")
+	}
+	dbPath, err := flag.GetDBPathFlagValue(cmd)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the db path flag value")
+	}
+	passwordFile, err := flag.GetPasswordFileFlagValue(cmd)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the password file flag value")
+	}
+
+	var encryptor encryptor2.Encryptor
+	var password string
+	if len(passwordFile) > 0 {
+		if password, err = accountflag.ReadPasswordFile(passwordFile); err != nil {
+			return nil, nil, err
+		}
+		encryptor = keystorev4.New()
+	}
+
+	switch {
+	case len(storageFlagValue) > 0 && len(dbPath) > 0:
+		return nil, nil, errors.New("only one of storage or db-path can be set")
+	case len(dbPath) > 0:
+		ret, err := bolt.NewBoltStoreWithEncryptor(dbPath, h.network, encryptor, []byte(password))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to open bolt storage")
+		}
+		return ret, func() { _ = ret.Close() }, nil
+	case len(storageFlagValue) > 0:
+		storageBytes, err := hex.DecodeString(storageFlagValue)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to HEX decode storage")
+		}
+		ret := &inmemory.InMemStore{}
+		if err := ret.UnmarshalJSON(storageBytes); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to JSON un-marshal storage")
+		}
+		if encryptor != nil {
+			ret.SetEncryptor(encryptor, []byte(password))
+		}
+		return ret, func() {}, nil
+	default:
+		return nil, nil, errors.New("either storage or db-path is required")
+	}
+}
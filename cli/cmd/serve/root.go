@@ -0,0 +1,42 @@
+package serve
+
+import (
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/serve/flag"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/serve/handler"
+)
+
+// Command represents the remote signer server command.
+var Command = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a Web3Signer compatible remote signer.",
+	Long: `This command serves the wallet accounts through the Web3Signer remote signing API.
+Sign requests are checked against the slashing protection data of the storage.
+Keys can be imported and deleted at runtime through the optional keymanager API.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		network, err := rootcmd.GetNetworkFlagValue(cmd)
+		if err != nil {
+			return err
+		}
+
+		handler := handler.New(rootcmd.ResultPrinter, network)
+		return handler.Serve(cmd, args)
+	},
+}
+
+func init() {
+	// Define flags for the command.
+	rootcmd.AddNetworkFlag(Command)
+	flag.AddListenAddressFlag(Command)
+	flag.AddStorageFlag(Command)
+	flag.AddDBPathFlag(Command)
+	flag.AddPasswordFileFlag(Command)
+	flag.AddInterchangeFileFlag(Command)
+	flag.AddKeymanagerListenAddressFlag(Command)
+	flag.AddKeymanagerTokenFlag(Command)
+	flag.AddKeymanagerTokenFileFlag(Command)
+
+	rootcmd.RootCmd.AddCommand(Command)
+}
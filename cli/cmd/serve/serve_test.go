@@ -0,0 +1,168 @@
+package serve_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+	"github.com/ssvlabs/eth2-key-manager/stores/bolt"
+)
+
+func TestServe(t *testing.T) {
+	t.Run("Missing storage", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualError(t, err, "either storage or db-path is required")
+	})
+
+	t.Run("Both storage and db path", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+			"--storage=7b7d",
+			"--db-path=" + t.TempDir() + "/keyvault.db",
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualError(t, err, "only one of storage or db-path can be set")
+	})
+
+	t.Run("Fail to HEX decode storage", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+			"--storage=zz",
+			"--db-path=",
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualError(t, err, "failed to HEX decode storage: encoding/hex: invalid byte: U+007A 'z'")
+	})
+
+	t.Run("Keymanager API without token", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+			"--storage=",
+			"--db-path=",
+			"--keymanager-listen-address=:0",
+			"--keymanager-token=",
+			"--keymanager-token-file=",
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualError(t, err, "either keymanager-token or keymanager-token-file is required by the keymanager API")
+	})
+
+	t.Run("Keymanager API token file is generated", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "api-token.txt")
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+			"--storage=",
+			"--db-path=",
+			"--keymanager-listen-address=:0",
+			"--keymanager-token=",
+			"--keymanager-token-file=" + tokenFile,
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualError(t, err, "either storage or db-path is required")
+
+		info, err := os.Stat(tokenFile)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		token, err := os.ReadFile(tokenFile)
+		require.NoError(t, err)
+		require.Len(t, token, 64)
+		require.Contains(t, output.String(), tokenFile)
+
+		// the token of an existing file is kept
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+			"--storage=",
+			"--db-path=",
+			"--keymanager-listen-address=:0",
+			"--keymanager-token=",
+			"--keymanager-token-file=" + tokenFile,
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "either storage or db-path is required")
+		byts, err := os.ReadFile(tokenFile)
+		require.NoError(t, err)
+		require.Equal(t, token, byts)
+
+		// reset the keymanager flags of the shared command
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+			"--storage=",
+			"--db-path=",
+			"--keymanager-listen-address=",
+			"--keymanager-token-file=",
+		})
+		require.Error(t, cmd.RootCmd.Execute())
+	})
+
+	t.Run("Encrypted db path", func(t *testing.T) {
+		dir := t.TempDir()
+		dbPath := filepath.Join(dir, "keyvault.db")
+		passwordFile := filepath.Join(dir, "password.txt")
+		require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+		store, err := bolt.NewBoltStoreWithEncryptor(dbPath, core.MainNetwork, keystorev4.New(), []byte("password"))
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+			"--storage=",
+			"--db-path=" + dbPath,
+			"--password-file=" + filepath.Join(dir, "missing.txt"),
+		})
+		require.ErrorContains(t, cmd.RootCmd.Execute(), "failed to read password file")
+
+		// the database is closed when serving fails
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+			"--storage=",
+			"--db-path=" + dbPath,
+			"--password-file=" + passwordFile,
+			"--interchange-file=" + filepath.Join(dir, "missing.json"),
+		})
+		require.ErrorContains(t, cmd.RootCmd.Execute(), "failed to read interchange file")
+		store, err = bolt.NewBoltStore(dbPath, core.MainNetwork)
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		// reset the flags of the shared command
+		cmd.RootCmd.SetArgs([]string{
+			"serve",
+			"--network=mainnet",
+			"--storage=",
+			"--db-path=",
+			"--password-file=",
+			"--interchange-file=",
+		})
+		require.Error(t, cmd.RootCmd.Execute())
+	})
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/util/cliflag"
+)
+
+// Flag names.
+const (
+	nonceFlag                = "nonce"
+	maxFeePerGasFlag         = "max-fee-per-gas"
+	maxPriorityFeePerGasFlag = "max-priority-fee-per-gas"
+)
+
+// AddNonceFlag adds the nonce flag to the command
+func AddNonceFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, nonceFlag, 0, "nonce of the first transaction, following transactions increment it", false)
+}
+
+// AddRequestNonceFlag adds the nonce flag of commands building a single transaction to the command
+func AddRequestNonceFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, nonceFlag, 0, "nonce of the transaction, the next one of the sending address", false)
+}
+
+// GetNonceFlagValue gets the nonce flag from the command
+func GetNonceFlagValue(c *cobra.Command) (uint64, error) {
+	nonce, err := c.Flags().GetInt(nonceFlag)
+	if err != nil {
+		return 0, err
+	}
+	if nonce < 0 {
+		return 0, errors.Errorf("invalid nonce %d", nonce)
+	}
+	return uint64(nonce), nil
+}
+
+// AddMaxFeePerGasFlag adds the max fee per gas flag to the command
+func AddMaxFeePerGasFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, maxFeePerGasFlag, "", "max fee per gas in Gwei", true)
+}
+
+// GetMaxFeePerGasFlagValue gets the max fee per gas flag from the command, in wei
+func GetMaxFeePerGasFlagValue(c *cobra.Command) (*big.Int, error) {
+	value, err := c.Flags().GetString(maxFeePerGasFlag)
+	if err != nil {
+		return nil, err
+	}
+	return parseGwei(value)
+}
+
+// AddMaxPriorityFeePerGasFlag adds the max priority fee per gas flag to the command
+func AddMaxPriorityFeePerGasFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, maxPriorityFeePerGasFlag, "", "max priority fee per gas in Gwei", true)
+}
+
+// GetMaxPriorityFeePerGasFlagValue gets the max priority fee per gas flag from the command, in wei
+func GetMaxPriorityFeePerGasFlagValue(c *cobra.Command) (*big.Int, error) {
+	value, err := c.Flags().GetString(maxPriorityFeePerGasFlag)
+	if err != nil {
+		return nil, err
+	}
+	return parseGwei(value)
+}
+
+// parseGwei parses a decimal Gwei amount into wei
+func parseGwei(value string) (*big.Int, error) {
+	whole, fraction, _ := strings.Cut(value, ".")
+	if len(whole) == 0 || len(fraction) > 9 {
+		return nil, errors.Errorf("invalid Gwei amount %s", value)
+	}
+	ret, ok := new(big.Int).SetString(whole+fraction+strings.Repeat("0", 9-len(fraction)), 10)
+	if !ok || ret.Sign() < 0 {
+		return nil, errors.Errorf("invalid Gwei amount %s", value)
+	}
+	return ret, nil
+}
@@ -0,0 +1,37 @@
+package account
+
+import (
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/handler"
+)
+
+// consolidationRequestCmd represents the consolidation request account command.
+var consolidationRequestCmd = &cobra.Command{
+	Use:   "consolidation-request",
+	Short: "Builds an unsigned consolidation request transaction",
+	Long:  `This command builds the unsigned EIP-7251 consolidation request transaction of an account of EIP-2335 keystores, a storage or a bolt database into a target validator, to be signed offline by the withdrawal address.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler := handler.New(rootcmd.ResultPrinter)
+		return handler.ConsolidationRequest(cmd, args)
+	},
+}
+
+func init() {
+	// Define flags for the command.
+	rootcmd.AddNetworkFlag(consolidationRequestCmd)
+	rootcmd.AddRequestNonceFlag(consolidationRequestCmd)
+	rootcmd.AddMaxFeePerGasFlag(consolidationRequestCmd)
+	rootcmd.AddMaxPriorityFeePerGasFlag(consolidationRequestCmd)
+	flag.AddVaultFlags(consolidationRequestCmd)
+	flag.AddRequestValidatorPublicKeyFlag(consolidationRequestCmd)
+	flag.AddRequestWithdrawalCredentialsFlag(consolidationRequestCmd)
+	flag.AddTargetPublicKeyFlag(consolidationRequestCmd)
+	flag.AddTargetWithdrawalCredentialsFlag(consolidationRequestCmd)
+	flag.AddFeeFlag(consolidationRequestCmd)
+	flag.AddAllowForeignTargetFlag(consolidationRequestCmd)
+
+	Command.AddCommand(consolidationRequestCmd)
+}
@@ -29,6 +29,9 @@ func init() {
 	flag.AddValidatorPublicKeysFlag(credentialsCmd)
 	flag.AddWithdrawalCredentialsFlag(credentialsCmd)
 	flag.AddToExecutionAddressFlag(credentialsCmd)
+	flag.AddCredentialsFileFlag(credentialsCmd)
+	flag.AddIndexRangeFlag(credentialsCmd)
+	flag.AddCredentialsOutputDirFlag(credentialsCmd)
 
 	Command.AddCommand(credentialsCmd)
 }
@@ -2,8 +2,12 @@ package account_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ssvlabs/eth2-key-manager/cli/cmd"
@@ -214,4 +218,90 @@ func TestAccountCredentials(t *testing.T) {
 		require.Error(t, err)
 		require.EqualError(t, err, "derived withdrawal credentials: 0x00d9cdf17e3a79317a4e5cd18580b1d10b1df360bbca5c5f8ac5b79b45c29d15, does not match with the provided one: 0x00d9cdf17e3a79317a4e5cd18580b1d10b1df360bbca5c5f8ac5b79b45c29d14")
 	})
+	// the following tests sign the changes of a file, whose flag is kept by the command
+	seed := "847d135b3aecac8ae77c3fdfd46dc5849ad3b5bacd30a1b9082b6ff53c77357e923b12fcdc3d02728fd35c3685de1fe1e9c052c48f0d83566b1b2287cf0e54c3"
+	jsonFile := filepath.Join(t.TempDir(), "validators.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`[
+		{"validator_index": "273407", "pubkey": "0xa1a593775967bf88bb6c14ac109c12e52dc836fa139bd1ba6ca873d65fe91bb7a0fc79c7b2a7315482a81f31e6b1018a", "withdrawal_credentials": "0x00202f88c6116d27f5de06eeda3b801d3a4eeab8eb09f879848445fffc8948a5", "to_execution_address": "0x3e6935b8250Cf9A777862871649E5594bE08779e"},
+		{"validator_index": 273230, "pubkey": "0xb2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c", "withdrawal_credentials": "0x00d9cdf17e3a79317a4e5cd18580b1d10b1df360bbca5c5f8ac5b79b45c29d15", "to_execution_address": "0x3e6935b8250Cf9A777862871649E5594bE08779e"}
+	]`), 0600))
+	csvFile := filepath.Join(t.TempDir(), "validators.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte(`to_execution_address,validator_index,pubkey,withdrawal_credentials
+0x3e6935b8250Cf9A777862871649E5594bE08779e,273407,0xa1a593775967bf88bb6c14ac109c12e52dc836fa139bd1ba6ca873d65fe91bb7a0fc79c7b2a7315482a81f31e6b1018a,0x00202f88c6116d27f5de06eeda3b801d3a4eeab8eb09f879848445fffc8948a5
+0x3e6935b8250Cf9A777862871649E5594bE08779e,273230,0xb2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c,0x00d9cdf17e3a79317a4e5cd18580b1d10b1df360bbca5c5f8ac5b79b45c29d15
+`), 0600))
+
+	// the changes signed with the comma separated flags, in the order of the files
+	var expected []*capella.SignedBLSToExecutionChange
+	{
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"credentials",
+			"--seed=" + seed,
+			"--index=1",
+			"--accumulate=true",
+			"--validator-indices=273230,273407",
+			"--validator-public-keys=0xb2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c,0xa1a593775967bf88bb6c14ac109c12e52dc836fa139bd1ba6ca873d65fe91bb7a0fc79c7b2a7315482a81f31e6b1018a",
+			"--withdrawal-credentials=0x00d9cdf17e3a79317a4e5cd18580b1d10b1df360bbca5c5f8ac5b79b45c29d15,0x00202f88c6116d27f5de06eeda3b801d3a4eeab8eb09f879848445fffc8948a5",
+			"--to-execution-address=0x3e6935b8250Cf9A777862871649E5594bE08779e,0x3e6935b8250Cf9A777862871649E5594bE08779e",
+			"--network=prater",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+		require.NoError(t, json.Unmarshal(output.Bytes(), &expected))
+		require.Len(t, expected, 2)
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+
+	for _, file := range []string{jsonFile, csvFile} {
+		t.Run("Successfully sign credentials changes of "+filepath.Ext(file)+" file", func(t *testing.T) {
+			outputDir := t.TempDir()
+			var output bytes.Buffer
+			cmd.ResultPrinter = printer.New(&output)
+			cmd.RootCmd.SetArgs([]string{
+				"wallet",
+				"account",
+				"credentials",
+				"--seed=" + seed,
+				"--index=0",
+				"--file=" + file,
+				"--output-dir=" + outputDir,
+				"--network=prater",
+			})
+			require.NoError(t, cmd.RootCmd.Execute())
+
+			var result map[string]interface{}
+			require.NoError(t, json.Unmarshal(output.Bytes(), &result))
+			require.EqualValues(t, 2, result["changes"])
+			require.Equal(t, outputDir, filepath.Dir(result["file"].(string)))
+			require.Regexp(t, `^bls_to_execution_changes-\d+\.json$`, filepath.Base(result["file"].(string)))
+
+			byts, err := os.ReadFile(result["file"].(string))
+			require.NoError(t, err)
+			var changes []*capella.SignedBLSToExecutionChange
+			require.NoError(t, json.Unmarshal(byts, &changes))
+			require.Equal(t, expected, changes)
+		})
+	}
+
+	t.Run("Withdrawal credentials out of the index range", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"credentials",
+			"--seed=" + seed,
+			"--index=0",
+			"--index-range=1",
+			"--file=" + jsonFile,
+			"--output-dir=" + t.TempDir(),
+			"--network=prater",
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualValues(t, "", output.String())
+		require.EqualError(t, err, "withdrawal credentials 0x00202f88c6116d27f5de06eeda3b801d3a4eeab8eb09f879848445fffc8948a5 of validator 273407 don't match a withdrawal key of indices 0 to 0")
+	})
 }
@@ -12,7 +12,7 @@ import (
 var depositDataCmd = &cobra.Command{
 	Use:   "deposit-data",
 	Short: "Returns an account deposit-data.",
-	Long:  `This command returns an account deposit-data using public key and storage.`,
+	Long:  `This command returns an account deposit-data using public key and storage, with BLS, execution address or compounding withdrawal credentials. With an output dir, it writes a staking-deposit-cli deposit_data file of the accounts from the index on.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		handler := handler.New(rootcmd.ResultPrinter)
 		return handler.DepositData(cmd, args)
@@ -25,6 +25,11 @@ func init() {
 	rootcmd.AddSeedFlag(depositDataCmd)
 	rootcmd.AddIndexFlag(depositDataCmd)
 	flag.AddPublicKeyFlag(depositDataCmd)
+	flag.AddWithdrawalAddressFlag(depositDataCmd)
+	flag.AddCompoundingFlag(depositDataCmd)
+	flag.AddAmountFlag(depositDataCmd)
+	flag.AddDepositDataOutputDirFlag(depositDataCmd)
+	flag.AddCountFlag(depositDataCmd)
 
 	Command.AddCommand(depositDataCmd)
 }
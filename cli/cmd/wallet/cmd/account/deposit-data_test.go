@@ -2,6 +2,9 @@ package account_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -62,4 +65,107 @@ func TestAccountDepositData(t *testing.T) {
 		require.Error(t, err)
 		require.EqualError(t, err, "failed to get account by public key: account not found")
 	})
+	t.Run("Successfully retrieve compounding deposit-data", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"deposit-data",
+			"--seed=0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff",
+			"--index=0",
+			"--publickey=95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf",
+			"--network=prater",
+			"--withdrawal-address=0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
+			"--compounding",
+			"--amount=2048",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var depositData map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &depositData))
+		require.Equal(t, "020000000000000000000000d8da6bf26964af9d7eed9e03e53415d37aa96045", depositData["withdrawalCredentials"])
+		require.Equal(t, "2048000000000", depositData["amount"])
+	})
+
+	t.Run("Fail retrieve compounding deposit-data without withdrawal address", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"deposit-data",
+			"--seed=0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff",
+			"--index=0",
+			"--publickey=95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf",
+			"--network=prater",
+			"--withdrawal-address=",
+			"--compounding",
+			"--amount=32",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "failed to get deposit data: compounding withdrawal credentials require a withdrawal address")
+	})
+
+	t.Run("Fail retrieve deposit-data above the amount limit", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"deposit-data",
+			"--seed=0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff",
+			"--index=0",
+			"--publickey=95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf",
+			"--network=prater",
+			"--withdrawal-address=0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
+			"--compounding=false",
+			"--amount=32.5",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "failed to get deposit data: deposit amount 32500000000 Gwei exceeds the maximum of 32000000000 Gwei for 0x01 withdrawal credentials")
+	})
+
+	t.Run("Successfully write deposit_data file", func(t *testing.T) {
+		outputDir := t.TempDir()
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"deposit-data",
+			"--seed=0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff",
+			"--index=0",
+			"--count=3",
+			"--publickey=",
+			"--network=prater",
+			"--withdrawal-address=",
+			"--compounding=false",
+			"--amount=32",
+			"--output-dir=" + outputDir,
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &result))
+		require.EqualValues(t, 3, result["deposits"])
+		file := result["file"].(string)
+		require.Regexp(t, `^deposit_data-\d+\.json$`, filepath.Base(file))
+		require.Equal(t, outputDir, filepath.Dir(file))
+
+		byts, err := os.ReadFile(file)
+		require.NoError(t, err)
+		var deposits []map[string]interface{}
+		require.NoError(t, json.Unmarshal(byts, &deposits))
+		require.Len(t, deposits, 3)
+		require.Equal(t, "95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf", deposits[0]["pubkey"])
+		for _, deposit := range deposits {
+			require.EqualValues(t, 32000000000, deposit["amount"])
+			require.Equal(t, "00001020", deposit["fork_version"])
+			require.Equal(t, "goerli", deposit["network_name"])
+			require.NotEmpty(t, deposit["deposit_message_root"])
+			require.NotEmpty(t, deposit["deposit_data_root"])
+			require.NotEmpty(t, deposit["deposit_cli_version"])
+		}
+		require.NotEqual(t, deposits[0]["pubkey"], deposits[1]["pubkey"])
+		require.NotEqual(t, deposits[1]["pubkey"], deposits[2]["pubkey"])
+	})
 }
@@ -0,0 +1,30 @@
+package account
+
+import (
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/handler"
+)
+
+// exportCmd represents the export keystores command.
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports wallet accounts as EIP-2335 keystores.",
+	Long:  `This command writes an EIP-2335 keystore file for every wallet account, which can be imported by Lighthouse, Prysm and Teku.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler := handler.New(rootcmd.ResultPrinter)
+		return handler.Export(cmd, args)
+	},
+}
+
+func init() {
+	// Define flags for the command.
+	flag.AddStorageFlag(exportCmd)
+	flag.AddPasswordFileFlag(exportCmd)
+	flag.AddOutputDirFlag(exportCmd)
+	flag.AddKDFFlag(exportCmd)
+
+	Command.AddCommand(exportCmd)
+}
@@ -0,0 +1,109 @@
+package account_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+)
+
+func TestAccountExport(t *testing.T) {
+	// flags of the other commands stay set on the root command, the storage is built directly
+	require.NoError(t, core.InitBLS())
+	store := inmemory.NewInMemStore(core.MainNetwork)
+	options := &eth2keymanager.KeyVaultOptions{}
+	options.SetStorage(store)
+	vault, err := eth2keymanager.NewKeyVault(options)
+	require.NoError(t, err)
+	wallet, err := vault.Wallet()
+	require.NoError(t, err)
+	seed, err := hex.DecodeString("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := wallet.CreateValidatorAccount(seed, &i)
+		require.NoError(t, err)
+	}
+	byts, err := store.MarshalJSON()
+	require.NoError(t, err)
+	storage := hex.EncodeToString(byts)
+
+	passwordFile := filepath.Join(t.TempDir(), "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password"), 0600))
+
+	t.Run("Successfully export accounts", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "validator_keys")
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"export",
+			"--storage=" + storage,
+			"--password-file=" + passwordFile,
+			"--output-dir=" + dir,
+			"--kdf=pbkdf2",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var files []map[string]string
+		require.NoError(t, json.Unmarshal(output.Bytes(), &files))
+		require.Len(t, files, 2)
+		for _, file := range files {
+			require.True(t, strings.HasPrefix(filepath.Base(file["file"]), "keystore-m_12381_3600_"))
+			byts, err := os.ReadFile(file["file"])
+			require.NoError(t, err)
+			ks, err := keystorev4.ParseKeystore(byts)
+			require.NoError(t, err)
+			require.Equal(t, file["id"], ks.UUID.String())
+			require.Equal(t, file["validationPubKey"], ks.PubKey)
+			require.Equal(t, "pbkdf2", ks.Crypto["kdf"].(map[string]interface{})["function"])
+		}
+
+		// exported keystores can be imported back
+		output.Reset()
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"import",
+			"--keystore-dir=" + dir,
+			"--password-file=" + passwordFile,
+			"--response-type=object",
+			"--wallet-type=HD",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+		var results []map[string]string
+		require.NoError(t, json.Unmarshal(output.Bytes(), &results))
+		require.Len(t, results, 2)
+		for _, result := range results {
+			require.Empty(t, result["error"])
+		}
+	})
+
+	t.Run("Unknown KDF", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"export",
+			"--storage=" + storage,
+			"--password-file=" + passwordFile,
+			"--output-dir=" + t.TempDir(),
+			"--kdf=argon2",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "failed to retrieve the KDF flag value: unknown KDF argon2")
+	})
+}
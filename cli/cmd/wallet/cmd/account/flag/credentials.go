@@ -1,7 +1,13 @@
 package flag
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
@@ -20,11 +26,13 @@ const (
 	validatorPublicKeys   = "validator-public-keys"
 	withdrawalCredentials = "withdrawal-credentials"
 	toExecutionAddress    = "to-execution-address"
+	credentialsFileFlag   = "file"
+	indexRangeFlag        = "index-range"
 )
 
 // AddValidatorIndicesFlag adds the validator indices flag to the command
 func AddValidatorIndicesFlag(c *cobra.Command) {
-	cliflag.AddPersistentStringFlag(c, validatorIndices, "", "comma separate string of validator indices", true)
+	cliflag.AddPersistentStringFlag(c, validatorIndices, "", "comma separate string of validator indices, required without a file", false)
 }
 
 // GetValidatorIndicesFlagValue gets the validator indices flag from the command
@@ -38,7 +46,7 @@ func GetValidatorIndicesFlagValue(c *cobra.Command) ([]uint64, error) {
 
 // AddValidatorPublicKeysFlag adds the validator public keys flag to the command
 func AddValidatorPublicKeysFlag(c *cobra.Command) {
-	cliflag.AddPersistentStringFlag(c, validatorPublicKeys, "", "comma separate string of validator public keys", true)
+	cliflag.AddPersistentStringFlag(c, validatorPublicKeys, "", "comma separate string of validator public keys, required without a file", false)
 }
 
 // GetValidatorPublicKeysFlagValue gets the validator public keys flag from the command
@@ -68,7 +76,7 @@ func GetValidatorPublicKeysFlagValue(c *cobra.Command) ([]phase0.BLSPubKey, erro
 
 // AddWithdrawalCredentialsFlag adds withdrawal credentials to the command
 func AddWithdrawalCredentialsFlag(c *cobra.Command) {
-	cliflag.AddPersistentStringFlag(c, withdrawalCredentials, "", "comma separate string of withdrawal credentials", true)
+	cliflag.AddPersistentStringFlag(c, withdrawalCredentials, "", "comma separate string of withdrawal credentials, required without a file", false)
 }
 
 // GetWithdrawalCredentialsFlagValue returns the value of withdrawal credentials
@@ -99,7 +107,7 @@ func GetWithdrawalCredentialsFlagValue(c *cobra.Command) ([][]byte, error) {
 
 // AddToExecutionAddressFlag adds the validator execution withdrawal address flag to the command
 func AddToExecutionAddressFlag(c *cobra.Command) {
-	cliflag.AddPersistentStringFlag(c, toExecutionAddress, "", "comma separate string of to execution addresses", true)
+	cliflag.AddPersistentStringFlag(c, toExecutionAddress, "", "comma separate string of to execution addresses, required without a file", false)
 }
 
 // GetToExecutionAddressFlagValue gets the validator withdrawal address flag from the command
@@ -191,3 +199,156 @@ func GetValidatorInfoFlagValue(c *cobra.Command) ([]*core.ValidatorInfo, error)
 
 	return validatorInfoList, nil
 }
+
+// AddCredentialsFileFlag adds the credentials file flag to the command
+func AddCredentialsFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, credentialsFileFlag, "", "CSV or JSON file of validator_index, pubkey, withdrawal_credentials and to_execution_address entries", false)
+}
+
+// GetCredentialsFileFlagValue gets the credentials file flag from the command
+func GetCredentialsFileFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(credentialsFileFlag)
+}
+
+// AddIndexRangeFlag adds the index range flag to the command
+func AddIndexRangeFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, indexRangeFlag, 100, "number of seed indices, from the index on, searched for the withdrawal keys of a file", false)
+}
+
+// GetIndexRangeFlagValue gets the index range flag from the command
+func GetIndexRangeFlagValue(c *cobra.Command) (int, error) {
+	indexRange, err := c.Flags().GetInt(indexRangeFlag)
+	if err != nil {
+		return 0, err
+	}
+	if indexRange < 1 {
+		return 0, errors.Errorf("invalid index range %d", indexRange)
+	}
+	return indexRange, nil
+}
+
+// AddCredentialsOutputDirFlag adds the output dir flag of BLS to execution changes signed from a file to the command
+func AddCredentialsOutputDirFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, outputDirFlag, "", "directory to write the bls_to_execution_changes file to, required with a file", false)
+}
+
+// CheckCredentialsFlagsSet returns an error if a validator flag required without a file isn't set
+func CheckCredentialsFlagsSet(c *cobra.Command) error {
+	for _, name := range []string{validatorIndices, validatorPublicKeys, withdrawalCredentials, toExecutionAddress} {
+		if !c.Flags().Changed(name) {
+			return errors.Errorf("%s flag is required without a file", name)
+		}
+	}
+	return nil
+}
+
+// credentialsEntry is an entry of a credentials file, named as the validator fields of the beacon API
+type credentialsEntry struct {
+	ValidatorIndex        json.Number `json:"validator_index"`
+	Pubkey                string      `json:"pubkey"`
+	WithdrawalCredentials string      `json:"withdrawal_credentials"`
+	ToExecutionAddress    string      `json:"to_execution_address"`
+}
+
+// ReadCredentialsFile reads the validator info of the given JSON array or CSV file, the CSV file has a header line of the JSON field names
+func ReadCredentialsFile(file string) ([]*core.ValidatorInfo, error) {
+	byts, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read credentials file")
+	}
+
+	var entries []*credentialsEntry
+	if bytes.HasPrefix(bytes.TrimSpace(byts), []byte("[")) {
+		if err := json.Unmarshal(byts, &entries); err != nil {
+			return nil, errors.Wrap(err, "failed to JSON un-marshal credentials file")
+		}
+	} else if entries, err = readCredentialsCSV(byts); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("credentials file has no entries")
+	}
+
+	validatorInfoList := make([]*core.ValidatorInfo, len(entries))
+	for i, entry := range entries {
+		if validatorInfoList[i], err = entry.validatorInfo(); err != nil {
+			return nil, errors.Wrapf(err, "invalid entry %d", i)
+		}
+	}
+	return validatorInfoList, nil
+}
+
+// readCredentialsCSV reads the entries of a CSV credentials file, its columns are found by the header line
+func readCredentialsCSV(byts []byte) ([]*credentialsEntry, error) {
+	records, err := csv.NewReader(bytes.NewReader(byts)).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CSV credentials file")
+	}
+	if len(records) == 0 {
+		return nil, errors.New("credentials file has no entries")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"validator_index", "pubkey", "withdrawal_credentials", "to_execution_address"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.Errorf("CSV credentials file has no %s column", name)
+		}
+	}
+
+	entries := make([]*credentialsEntry, 0, len(records)-1)
+	for _, record := range records[1:] {
+		entries = append(entries, &credentialsEntry{
+			ValidatorIndex:        json.Number(strings.TrimSpace(record[columns["validator_index"]])),
+			Pubkey:                strings.TrimSpace(record[columns["pubkey"]]),
+			WithdrawalCredentials: strings.TrimSpace(record[columns["withdrawal_credentials"]]),
+			ToExecutionAddress:    strings.TrimSpace(record[columns["to_execution_address"]]),
+		})
+	}
+	return entries, nil
+}
+
+// validatorInfo returns the validator info of the entry
+func (e *credentialsEntry) validatorInfo() (*core.ValidatorInfo, error) {
+	index, err := strconv.ParseUint(e.ValidatorIndex.String(), 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid validator index supplied")
+	}
+
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(e.Pubkey, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid validator public key supplied")
+	}
+	if len(pubKey) != phase0.PublicKeyLength {
+		return nil, errors.New("invalid length for validator public key")
+	}
+
+	withdrawalCreds, err := hex.DecodeString(strings.TrimPrefix(e.WithdrawalCredentials, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid withdrawal credentials supplied")
+	}
+	if len(withdrawalCreds) != 32 {
+		return nil, errors.New("invalid length for withdrawal credentials")
+	}
+	if withdrawalCreds[0] != byte(0) {
+		return nil, errors.New("non-BLS withdrawal credentials supplied")
+	}
+
+	toExecutionAddress, err := hex.DecodeString(strings.TrimPrefix(e.ToExecutionAddress, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid to execution address supplied")
+	}
+	if len(toExecutionAddress) != bellatrix.ExecutionAddressLength {
+		return nil, errors.New("invalid length for to execution address")
+	}
+
+	validatorInfo := &core.ValidatorInfo{
+		Index:                 phase0.ValidatorIndex(index),
+		WithdrawalCredentials: withdrawalCreds,
+	}
+	copy(validatorInfo.Pubkey[:], pubKey)
+	copy(validatorInfo.ToExecutionAddress[:], toExecutionAddress)
+	return validatorInfo, nil
+}
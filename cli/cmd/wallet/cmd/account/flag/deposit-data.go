@@ -1,6 +1,13 @@
 package flag
 
 import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/ssvlabs/eth2-key-manager/cli/util/cliflag"
@@ -8,15 +15,111 @@ import (
 
 // Flag names.
 const (
-	publicKeyFlag = "publickey"
+	publicKeyFlag         = "publickey"
+	withdrawalAddressFlag = "withdrawal-address"
+	compoundingFlag       = "compounding"
+	amountFlag            = "amount"
+	countFlag             = "count"
 )
 
 // AddPublicKeyFlag adds the public key flag to the command
 func AddPublicKeyFlag(c *cobra.Command) {
-	cliflag.AddPersistentStringFlag(c, publicKeyFlag, "", "public key", true)
+	cliflag.AddPersistentStringFlag(c, publicKeyFlag, "", "public key, required unless deposit data files are written", false)
 }
 
 // GetPublicKeyFlagValue gets the public key flag from the command
 func GetPublicKeyFlagValue(c *cobra.Command) (string, error) {
 	return c.Flags().GetString(publicKeyFlag)
 }
+
+// AddDepositDataOutputDirFlag adds the optional output dir flag to the command
+func AddDepositDataOutputDirFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, outputDirFlag, "", "directory to write a staking-deposit-cli deposit_data file to, the deposit data is printed if not set", false)
+}
+
+// AddCountFlag adds the count flag to the command
+func AddCountFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, countFlag, 1, "number of accounts, from the index on, to write deposit data of", false)
+}
+
+// GetCountFlagValue gets the count flag from the command
+func GetCountFlagValue(c *cobra.Command) (int, error) {
+	count, err := c.Flags().GetInt(countFlag)
+	if err != nil {
+		return 0, err
+	}
+	if count < 1 {
+		return 0, errors.Errorf("invalid count %d", count)
+	}
+	return count, nil
+}
+
+// AddWithdrawalAddressFlag adds the withdrawal address flag to the command
+func AddWithdrawalAddressFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, withdrawalAddressFlag, "", "execution withdrawal address (0x01 credentials), BLS credentials if not set", false)
+}
+
+// GetWithdrawalAddressFlagValue gets the withdrawal address flag from the command, nil if it's not set
+func GetWithdrawalAddressFlagValue(c *cobra.Command) (*bellatrix.ExecutionAddress, error) {
+	value, err := c.Flags().GetString(withdrawalAddressFlag)
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	byts, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid withdrawal address supplied")
+	}
+	if len(byts) != bellatrix.ExecutionAddressLength {
+		return nil, errors.New("invalid length for withdrawal address")
+	}
+	var ret bellatrix.ExecutionAddress
+	copy(ret[:], byts)
+	return &ret, nil
+}
+
+// AddCompoundingFlag adds the compounding flag to the command
+func AddCompoundingFlag(c *cobra.Command) {
+	cliflag.AddPersistentBoolFlag(c, compoundingFlag, false, "compounding withdrawal credentials (0x02), requires a withdrawal address", false)
+}
+
+// GetCompoundingFlagValue gets the compounding flag from the command
+func GetCompoundingFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(compoundingFlag)
+}
+
+// AddAmountFlag adds the amount flag to the command
+func AddAmountFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, amountFlag, "32", "deposit amount in ETH, up to 32 ETH or 2048 ETH for compounding credentials", false)
+}
+
+// GetAmountFlagValue gets the amount flag from the command, in Gwei
+func GetAmountFlagValue(c *cobra.Command) (phase0.Gwei, error) {
+	value, err := c.Flags().GetString(amountFlag)
+	if err != nil {
+		return 0, err
+	}
+	return parseETHAmount(value)
+}
+
+// parseETHAmount parses a decimal ETH amount into Gwei
+func parseETHAmount(value string) (phase0.Gwei, error) {
+	whole, fraction, _ := strings.Cut(value, ".")
+	if len(fraction) > 9 {
+		return 0, errors.Errorf("invalid amount %s, at most 9 decimals are allowed", value)
+	}
+	fraction += strings.Repeat("0", 9-len(fraction))
+
+	eth, err := strconv.ParseUint(whole, 10, 32)
+	if err != nil {
+		return 0, errors.Errorf("invalid amount %s", value)
+	}
+	gwei, err := strconv.ParseUint(fraction, 10, 32)
+	if err != nil {
+		return 0, errors.Errorf("invalid amount %s", value)
+	}
+	return phase0.Gwei(eth*1000000000 + gwei), nil
+}
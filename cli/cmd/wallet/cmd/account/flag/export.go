@@ -0,0 +1,41 @@
+package flag
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/util/cliflag"
+)
+
+// Flag names.
+const (
+	outputDirFlag = "output-dir"
+	kdfFlag       = "kdf"
+)
+
+// AddOutputDirFlag adds the output dir flag to the command
+func AddOutputDirFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, outputDirFlag, "", "directory to write the files to", true)
+}
+
+// GetOutputDirFlagValue gets the output dir flag from the command
+func GetOutputDirFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(outputDirFlag)
+}
+
+// AddKDFFlag adds the KDF flag to the command
+func AddKDFFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, kdfFlag, "scrypt", "keystore key derivation function, scrypt or pbkdf2", false)
+}
+
+// GetKDFFlagValue gets the KDF flag from the command
+func GetKDFFlagValue(c *cobra.Command) (string, error) {
+	kdf, err := c.Flags().GetString(kdfFlag)
+	if err != nil {
+		return "", err
+	}
+	if kdf != "scrypt" && kdf != "pbkdf2" {
+		return "", errors.Errorf("unknown KDF %s", kdf)
+	}
+	return kdf, nil
+}
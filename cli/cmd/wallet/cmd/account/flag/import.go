@@ -0,0 +1,72 @@
+package flag
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/util/cliflag"
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// Flag names.
+const (
+	keystoreDirFlag  = "keystore-dir"
+	passwordFileFlag = "password-file"
+	walletTypeFlag   = "wallet-type"
+)
+
+// AddKeystoreDirFlag adds the keystore dir flag to the command
+func AddKeystoreDirFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, keystoreDirFlag, "", "directory of the EIP-2335 keystore files", true)
+}
+
+// GetKeystoreDirFlagValue gets the keystore dir flag from the command
+func GetKeystoreDirFlagValue(c *cobra.Command) (string, error) {
+	return c.Flags().GetString(keystoreDirFlag)
+}
+
+// AddPasswordFileFlag adds the password file flag to the command
+func AddPasswordFileFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, passwordFileFlag, "", "file holding the keystores password", true)
+}
+
+// GetPasswordFileFlagValue reads the password of the password file flag, without its trailing new line
+func GetPasswordFileFlagValue(c *cobra.Command) (string, error) {
+	file, err := c.Flags().GetString(passwordFileFlag)
+	if err != nil {
+		return "", err
+	}
+	return ReadPasswordFile(file)
+}
+
+// ReadPasswordFile reads the password of the given file, without its trailing new line
+func ReadPasswordFile(file string) (string, error) {
+	byts, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read password file")
+	}
+	return strings.TrimRight(string(byts), "\r\n"), nil
+}
+
+// AddWalletTypeFlag adds the wallet type flag to the command
+func AddWalletTypeFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, walletTypeFlag, core.NDWallet, "wallet type, HD or ND", false)
+}
+
+// GetWalletTypeFlagValue gets the wallet type flag from the command
+func GetWalletTypeFlagValue(c *cobra.Command) (core.WalletType, error) {
+	walletType, err := c.Flags().GetString(walletTypeFlag)
+	if err != nil {
+		return "", err
+	}
+	switch walletType {
+	case core.HDWallet, core.NDWallet:
+		return walletType, nil
+	default:
+		return "", errors.Errorf("unknown wallet type %s", walletType)
+	}
+}
@@ -0,0 +1,71 @@
+package flag
+
+import (
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/util/cliflag"
+)
+
+// Flag names.
+const (
+	feeRecipientFlag = "fee-recipient"
+	gasLimitFlag     = "gas-limit"
+	timestampFlag    = "timestamp"
+)
+
+// AddFeeRecipientFlag adds the fee recipient flag to the command
+func AddFeeRecipientFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, feeRecipientFlag, "", "fee recipient execution address of the registrations", true)
+}
+
+// GetFeeRecipientFlagValue gets the fee recipient flag from the command
+func GetFeeRecipientFlagValue(c *cobra.Command) (bellatrix.ExecutionAddress, error) {
+	var ret bellatrix.ExecutionAddress
+	byts, err := getHexFlagValue(c, feeRecipientFlag, bellatrix.ExecutionAddressLength)
+	if err != nil {
+		return ret, err
+	}
+	copy(ret[:], byts)
+	return ret, nil
+}
+
+// AddGasLimitFlag adds the gas limit flag to the command
+func AddGasLimitFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, gasLimitFlag, 30000000, "preferred gas limit of the registrations", false)
+}
+
+// GetGasLimitFlagValue gets the gas limit flag from the command
+func GetGasLimitFlagValue(c *cobra.Command) (uint64, error) {
+	gasLimit, err := c.Flags().GetInt(gasLimitFlag)
+	if err != nil {
+		return 0, err
+	}
+	if gasLimit <= 0 {
+		return 0, errors.Errorf("invalid gas limit %d", gasLimit)
+	}
+	return uint64(gasLimit), nil
+}
+
+// AddTimestampFlag adds the timestamp flag to the command
+func AddTimestampFlag(c *cobra.Command) {
+	cliflag.AddPersistentIntFlag(c, timestampFlag, 0, "unix timestamp of the registrations, now if not set", false)
+}
+
+// GetTimestampFlagValue gets the timestamp flag from the command, now if it's not set
+func GetTimestampFlagValue(c *cobra.Command) (time.Time, error) {
+	timestamp, err := c.Flags().GetInt(timestampFlag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if timestamp < 0 {
+		return time.Time{}, errors.Errorf("invalid timestamp %d", timestamp)
+	}
+	if timestamp == 0 {
+		return time.Now(), nil
+	}
+	return time.Unix(int64(timestamp), 0), nil
+}
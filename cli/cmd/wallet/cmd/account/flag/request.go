@@ -0,0 +1,111 @@
+package flag
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/util/cliflag"
+)
+
+// Flag names.
+const (
+	targetPublicKeyFlag             = "target-public-key"
+	targetWithdrawalCredentialsFlag = "target-withdrawal-credentials"
+	feeFlag                         = "fee"
+	allowForeignTargetFlag          = "allow-foreign-target"
+)
+
+// AddRequestValidatorPublicKeyFlag adds the public key flag of the vault account sending a request to the command
+func AddRequestValidatorPublicKeyFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, validatorPublicKey, "", "validator public key of an account of the vault", true)
+}
+
+// AddRequestWithdrawalCredentialsFlag adds the withdrawal credentials flag of the validator sending a request to the command
+func AddRequestWithdrawalCredentialsFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, withdrawalCredentials, "", "execution address (0x01) or compounding (0x02) withdrawal credentials of the validator", true)
+}
+
+// GetRequestWithdrawalCredentialsFlagValue gets the withdrawal credentials flag of the validator sending a request from the command
+func GetRequestWithdrawalCredentialsFlagValue(c *cobra.Command) ([]byte, error) {
+	return getHexFlagValue(c, withdrawalCredentials, 32)
+}
+
+// AddWithdrawalAmountFlag adds the amount flag of withdrawal requests to the command
+func AddWithdrawalAmountFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, amountFlag, "0", "amount in ETH to withdraw, 0 requests a full exit", false)
+}
+
+// AddTargetPublicKeyFlag adds the consolidation target public key flag to the command
+func AddTargetPublicKeyFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, targetPublicKeyFlag, "", "validator public key of the consolidation target, the source one to switch to compounding credentials", true)
+}
+
+// GetTargetPublicKeyFlagValue gets the consolidation target public key flag from the command
+func GetTargetPublicKeyFlagValue(c *cobra.Command) (phase0.BLSPubKey, error) {
+	var ret phase0.BLSPubKey
+	byts, err := getHexFlagValue(c, targetPublicKeyFlag, phase0.PublicKeyLength)
+	if err != nil {
+		return ret, err
+	}
+	copy(ret[:], byts)
+	return ret, nil
+}
+
+// AddTargetWithdrawalCredentialsFlag adds the consolidation target withdrawal credentials flag to the command
+func AddTargetWithdrawalCredentialsFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, targetWithdrawalCredentialsFlag, "", "withdrawal credentials of the consolidation target", true)
+}
+
+// GetTargetWithdrawalCredentialsFlagValue gets the consolidation target withdrawal credentials flag from the command
+func GetTargetWithdrawalCredentialsFlagValue(c *cobra.Command) ([]byte, error) {
+	return getHexFlagValue(c, targetWithdrawalCredentialsFlag, 32)
+}
+
+// AddAllowForeignTargetFlag adds the flag allowing a consolidation target of another withdrawal address to the command
+func AddAllowForeignTargetFlag(c *cobra.Command) {
+	cliflag.AddPersistentBoolFlag(c, allowForeignTargetFlag, false, "allow a consolidation target of another withdrawal address, which then owns the consolidated balance", false)
+}
+
+// GetAllowForeignTargetFlagValue gets the flag allowing a consolidation target of another withdrawal address from the command
+func GetAllowForeignTargetFlagValue(c *cobra.Command) (bool, error) {
+	return c.Flags().GetBool(allowForeignTargetFlag)
+}
+
+// AddFeeFlag adds the request fee flag to the command
+func AddFeeFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, feeFlag, "", "request fee in wei, read with an eth_call to the system contract", true)
+}
+
+// GetFeeFlagValue gets the request fee flag from the command, in wei
+func GetFeeFlagValue(c *cobra.Command) (*big.Int, error) {
+	value, err := c.Flags().GetString(feeFlag)
+	if err != nil {
+		return nil, err
+	}
+	fee, ok := new(big.Int).SetString(value, 10)
+	if !ok || fee.Sign() <= 0 {
+		return nil, errors.Errorf("invalid fee %s", value)
+	}
+	return fee, nil
+}
+
+// getHexFlagValue gets the hex flag of the given name, with or without 0x prefix, of the given length
+func getHexFlagValue(c *cobra.Command, name string, length int) ([]byte, error) {
+	value, err := c.Flags().GetString(name)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s supplied", name)
+	}
+	if len(ret) != length {
+		return nil, errors.Errorf("invalid length for %s", name)
+	}
+	return ret, nil
+}
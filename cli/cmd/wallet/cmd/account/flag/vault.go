@@ -0,0 +1,52 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/util/cliflag"
+)
+
+// Flag names.
+const (
+	dbPathFlag = "db-path"
+)
+
+// VaultFlagValues are the flags of the keys to sign with, at most one of keystore dir, storage or db path is set
+type VaultFlagValues struct {
+	KeystoreDir  string
+	PasswordFile string
+	Storage      string
+	DBPath       string
+}
+
+// IsSet returns true if any of keystore dir, storage or db path is set
+func (v *VaultFlagValues) IsSet() bool {
+	return len(v.KeystoreDir) > 0 || len(v.Storage) > 0 || len(v.DBPath) > 0
+}
+
+// AddVaultFlags adds the optional flags of the keys to sign with: EIP-2335 keystores, a storage or a bolt database
+func AddVaultFlags(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, keystoreDirFlag, "", "directory of the EIP-2335 keystore files to sign with", false)
+	cliflag.AddPersistentStringFlag(c, passwordFileFlag, "", "file holding the keystores password, or the bolt database password if its keys are encrypted", false)
+	cliflag.AddPersistentStringFlag(c, storageFlag, "", "key-vault storage to sign with", false)
+	cliflag.AddPersistentStringFlag(c, dbPathFlag, "", "path of a bolt key-vault database to sign with", false)
+}
+
+// GetVaultFlagValues gets the vault flags from the command, the password file isn't read
+func GetVaultFlagValues(c *cobra.Command) (*VaultFlagValues, error) {
+	var ret VaultFlagValues
+	var err error
+	if ret.KeystoreDir, err = c.Flags().GetString(keystoreDirFlag); err != nil {
+		return nil, err
+	}
+	if ret.PasswordFile, err = c.Flags().GetString(passwordFileFlag); err != nil {
+		return nil, err
+	}
+	if ret.Storage, err = c.Flags().GetString(storageFlag); err != nil {
+		return nil, err
+	}
+	if ret.DBPath, err = c.Flags().GetString(dbPathFlag); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
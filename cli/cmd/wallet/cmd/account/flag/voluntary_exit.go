@@ -32,7 +32,13 @@ func GetEpochFlagValue(c *cobra.Command) (int, error) {
 
 // AddCurrentForkVersionFlag adds the current fork version flag to the command
 func AddCurrentForkVersionFlag(c *cobra.Command) {
-	cliflag.AddPersistentStringFlag(c, currentForkVersionFlag, "", "current fork version (ForkVersionLength: 4 bytes)", true)
+	cliflag.AddPersistentStringFlag(c, currentForkVersionFlag, "", "current fork version (ForkVersionLength: 4 bytes), required without a vault on networks without a fork schedule, checked against the fork schedule otherwise", false)
+}
+
+// IsCurrentForkVersionFlagSet returns true if the current fork version flag is set to a non empty value
+func IsCurrentForkVersionFlagSet(c *cobra.Command) bool {
+	value, err := c.Flags().GetString(currentForkVersionFlag)
+	return err == nil && value != ""
 }
 
 // GetCurrentForkVersionFlagValue gets the current fork version flag from the command
@@ -57,7 +63,7 @@ func GetCurrentForkVersionFlagValue(c *cobra.Command) (phase0.Version, error) {
 
 // AddValidatorPublicKeyFlag adds the validator public key flag to the command
 func AddValidatorPublicKeyFlag(c *cobra.Command) {
-	cliflag.AddPersistentStringFlag(c, validatorPublicKey, "", "validator public key", true)
+	cliflag.AddPersistentStringFlag(c, validatorPublicKey, "", "validator public key, required without a vault", false)
 }
 
 // GetValidatorPublicKeyFlagValue gets the validator public key flag from the command
@@ -82,7 +88,7 @@ func GetValidatorPublicKeyFlagValue(c *cobra.Command) (phase0.BLSPubKey, error)
 
 // AddValidatorIndexFlag adds the validator index flag to the command
 func AddValidatorIndexFlag(c *cobra.Command) {
-	cliflag.AddPersistentIntFlag(c, validatorIndex, 0, "validator index", true)
+	cliflag.AddPersistentIntFlag(c, validatorIndex, 0, "validator index, required without a vault", false)
 }
 
 // GetValidatorIndexFlagValue gets the validator index flag from the command
@@ -95,6 +101,31 @@ func GetValidatorIndexFlagValue(c *cobra.Command) (phase0.ValidatorIndex, error)
 	return phase0.ValidatorIndex(str), nil
 }
 
+// AddExitValidatorIndicesFlag adds the validator indices flag of voluntary exits signed with a vault to the command
+func AddExitValidatorIndicesFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, validatorIndices, "", "comma separate string of validator indices to exit, required with a vault", false)
+}
+
+// AddExitValidatorPublicKeysFlag adds the validator public keys flag of voluntary exits signed with a vault to the command
+func AddExitValidatorPublicKeysFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, validatorPublicKeys, "", "comma separate string of validator public keys to exit, required with a vault", false)
+}
+
+// AddExitOutputDirFlag adds the output dir flag of voluntary exits signed with a vault to the command
+func AddExitOutputDirFlag(c *cobra.Command) {
+	cliflag.AddPersistentStringFlag(c, outputDirFlag, "", "directory to write the signed voluntary exit files to, required with a vault", false)
+}
+
+// CheckVoluntaryExitFlagsSet returns an error if a validator flag required without a vault isn't set
+func CheckVoluntaryExitFlagsSet(c *cobra.Command) error {
+	for _, name := range []string{validatorIndex, validatorPublicKey} {
+		if !c.Flags().Changed(name) {
+			return errors.Errorf("%s flag is required without keystore-dir, storage or db-path", name)
+		}
+	}
+	return nil
+}
+
 // GetVoluntaryExitInfoFlagValue gets the voluntary exit info flag from the command
 func GetVoluntaryExitInfoFlagValue(c *cobra.Command) (*core.ValidatorInfo, error) {
 	validatorIndex, err := GetValidatorIndexFlagValue(c)
@@ -3,6 +3,11 @@ package handler
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -15,6 +20,7 @@ import (
 	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
 	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
 	"github.com/ssvlabs/eth2-key-manager/core"
+	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
 	"github.com/ssvlabs/eth2-key-manager/signer"
 	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
 )
@@ -28,8 +34,6 @@ type CredentialsFlagValues struct {
 	network    core.Network
 }
 
-var domainBlsToExecutionChange = types.DomainType{0x0a, 0x00, 0x00, 0x00}
-
 // Credentials creates a new wallet account(s) and prints the storage.
 func (h *Account) Credentials(cmd *cobra.Command, args []string) error {
 	err := core.InitBLS()
@@ -37,37 +41,28 @@ func (h *Account) Credentials(cmd *cobra.Command, args []string) error {
 		return errors.Wrap(err, "failed to init BLS")
 	}
 
-	credentialsFlags, err := CollectCredentialsFlags(cmd)
+	file, err := flag.GetCredentialsFileFlagValue(cmd)
 	if err != nil {
-		return errors.Wrap(err, "failed to collect credentials flags")
+		return errors.Wrap(err, "failed to retrieve the file flag value")
+	}
+	if len(file) > 0 {
+		return h.fileCredentials(cmd, file)
 	}
 
-	// Initialize store
-	store := inmemory.NewInMemStore(credentialsFlags.network)
-	options := &eth2keymanager.KeyVaultOptions{}
-	options.SetStorage(store)
-
-	// Create new key vault
-	_, err = eth2keymanager.NewKeyVault(options)
+	credentialsFlags, err := CollectCredentialsFlags(cmd)
 	if err != nil {
-		return errors.Wrap(err, "failed to create key vault")
+		return errors.Wrap(err, "failed to collect credentials flags")
 	}
 
-	wallet, err := store.OpenWallet()
+	store, wallet, err := withdrawalWallet(credentialsFlags.network)
 	if err != nil {
-		return errors.Wrap(err, "failed to open wallet")
+		return err
 	}
 
-	// set the context for wallet to use withdrawal key as primary key
-	wallet.SetContext(&core.WalletContext{
-		Storage:        store,
-		WithdrawalMode: true,
-	})
-
 	// Compute domain
 	genesisValidatorsRoot := store.Network().GenesisValidatorsRoot()
 	genesisForkVersion := store.Network().GenesisForkVersion()
-	domainBytes, err := types.ComputeDomain(domainBlsToExecutionChange, genesisForkVersion[:], genesisValidatorsRoot[:])
+	domainBytes, err := types.ComputeDomain(signer.DomainBLSToExecutionChange, genesisForkVersion[:], genesisValidatorsRoot[:])
 	if err != nil {
 		return errors.Wrap(err, "failed to calculate domain")
 	}
@@ -142,8 +137,146 @@ func (h *Account) Credentials(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// fileCredentials signs the BLS to execution changes of the validators of the given file with the withdrawal keys
+// found in the index range of the seed, and writes them to a bls_to_execution_changes file.
+func (h *Account) fileCredentials(cmd *cobra.Command, file string) error {
+	validators, err := flag.ReadCredentialsFile(file)
+	if err != nil {
+		return err
+	}
+
+	seedFlagValue, err := rootcmd.GetSeedFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the seed flag value")
+	}
+	seedBytes, err := hex.DecodeString(seedFlagValue)
+	if err != nil {
+		return errors.Wrap(err, "failed to HEX decode seed")
+	}
+	index, err := rootcmd.GetIndexFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the index flag value")
+	}
+	indexRange, err := flag.GetIndexRangeFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the index range flag value")
+	}
+	network, err := rootcmd.GetNetworkFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the network flag value")
+	}
+	outputDir, err := flag.GetOutputDirFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the output dir flag value")
+	}
+	if len(outputDir) == 0 {
+		return errors.New("output-dir flag is required with a file")
+	}
+
+	store, wallet, err := withdrawalWallet(network)
+	if err != nil {
+		return err
+	}
+
+	// derive the withdrawal keys of the index range, keyed by their withdrawal credentials
+	accounts := make(map[string]core.ValidatorAccount, indexRange)
+	for i := index; i < index+indexRange; i++ {
+		accountIndex := i
+		// Its actually withdrawal account, since the wallet is in withdrawal mode
+		acc, err := wallet.CreateValidatorAccount(seedBytes, &accountIndex)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create withdrawal account %d", accountIndex)
+		}
+		accounts[hex.EncodeToString(eth1deposit.BLSWithdrawalCredentials(acc.ValidatorPublicKey()))] = acc
+	}
+
+	simpleSigner := signer.NewSimpleSigner(wallet, nil, store.Network())
+	signedBLSToExecutionChanges := make([]*capella.SignedBLSToExecutionChange, 0, len(validators))
+	for _, validator := range validators {
+		acc, ok := accounts[hex.EncodeToString(validator.WithdrawalCredentials)]
+		if !ok {
+			return errors.Errorf("withdrawal credentials 0x%x of validator %d don't match a withdrawal key of indices %d to %d", validator.WithdrawalCredentials, validator.Index, index, index+indexRange-1)
+		}
+
+		// Since the wallet is in withdrawal mode the validator account is the withdrawal account
+		derivedWithdrawalPubKey := acc.ValidatorPublicKey()
+		if !bytes.Equal(acc.WithdrawalPublicKey(), validator.Pubkey[:]) {
+			return errors.Errorf("derived validator public key: 0x%x, does not match with the provided one: %s", acc.WithdrawalPublicKey(), validator.Pubkey.String())
+		}
+
+		blsToExecutionChange := &capella.BLSToExecutionChange{
+			ValidatorIndex:     validator.Index,
+			ToExecutionAddress: validator.ToExecutionAddress,
+		}
+		copy(blsToExecutionChange.FromBLSPubkey[:], derivedWithdrawalPubKey)
+
+		signature, _, err := simpleSigner.SignBLSToExecutionChangeAutoDomain(blsToExecutionChange, derivedWithdrawalPubKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to sign BLS to execution change of validator %d", validator.Index)
+		}
+
+		signedBLSToExecutionChange := &capella.SignedBLSToExecutionChange{
+			Message: blsToExecutionChange,
+		}
+		copy(signedBLSToExecutionChange.Signature[:], signature)
+		signedBLSToExecutionChanges = append(signedBLSToExecutionChanges, signedBLSToExecutionChange)
+	}
+
+	byts, err := json.Marshal(signedBLSToExecutionChanges)
+	if err != nil {
+		return errors.Wrap(err, "failed to JSON marshal signed BLS to execution changes")
+	}
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create output dir")
+	}
+	outputFile := filepath.Join(outputDir, fmt.Sprintf("bls_to_execution_changes-%d.json", time.Now().Unix()))
+	if err := os.WriteFile(outputFile, byts, 0600); err != nil {
+		return errors.Wrap(err, "failed to write signed BLS to execution changes file")
+	}
+
+	err = h.printer.JSON(map[string]interface{}{
+		"file":    outputFile,
+		"changes": len(signedBLSToExecutionChanges),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to print signed BLS to execution changes file JSON")
+	}
+
+	return nil
+}
+
+// withdrawalWallet returns a new in-memory wallet, in withdrawal mode, of the given network
+func withdrawalWallet(network core.Network) (*inmemory.InMemStore, core.Wallet, error) {
+	// Initialize store
+	store := inmemory.NewInMemStore(network)
+	options := &eth2keymanager.KeyVaultOptions{}
+	options.SetStorage(store)
+
+	// Create new key vault
+	_, err := eth2keymanager.NewKeyVault(options)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create key vault")
+	}
+
+	wallet, err := store.OpenWallet()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open wallet")
+	}
+
+	// set the context for wallet to use withdrawal key as primary key
+	wallet.SetContext(&core.WalletContext{
+		Storage:        store,
+		WithdrawalMode: true,
+	})
+	return store, wallet, nil
+}
+
 // CollectCredentialsFlags returns collected flags for seed
 func CollectCredentialsFlags(cmd *cobra.Command) (*CredentialsFlagValues, error) {
+	if err := flag.CheckCredentialsFlagsSet(cmd); err != nil {
+		return nil, err
+	}
+
 	credentialsFlagValues := CredentialsFlagValues{}
 
 	// Get seed flag value.
@@ -2,6 +2,10 @@ package handler
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/ssvlabs/eth2-key-manager/core"
 
@@ -11,10 +15,13 @@ import (
 	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
 	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
 	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
 	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
 )
 
 // DepositData generates account deposit-data and prints it.
+// With an output dir, it writes a staking-deposit-cli deposit_data file of the accounts from the index on and prints the file.
 func (h *Account) DepositData(cmd *cobra.Command, _ []string) error {
 	err := core.InitBLS()
 	if err != nil {
@@ -50,6 +57,48 @@ func (h *Account) DepositData(cmd *cobra.Command, _ []string) error {
 		return errors.Wrap(err, "failed to retrieve the public key flag value")
 	}
 
+	// Get withdrawal address flag.
+	withdrawalAddress, err := flag.GetWithdrawalAddressFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the withdrawal address flag value")
+	}
+
+	// Get compounding flag.
+	compounding, err := flag.GetCompoundingFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the compounding flag value")
+	}
+
+	// Get amount flag.
+	amount, err := flag.GetAmountFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the amount flag value")
+	}
+
+	// Get output dir flag.
+	outputDir, err := flag.GetOutputDirFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the output dir flag value")
+	}
+
+	// Get count flag.
+	count, err := flag.GetCountFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the count flag value")
+	}
+
+	if len(outputDir) == 0 && len(publicKeyFlagValue) == 0 {
+		return errors.New("public key is required unless an output dir is set")
+	}
+
+	depositDataOptions := []core.DepositDataOption{core.WithDepositAmount(amount)}
+	if withdrawalAddress != nil {
+		depositDataOptions = append(depositDataOptions, core.WithWithdrawalAddress(*withdrawalAddress))
+	}
+	if compounding {
+		depositDataOptions = append(depositDataOptions, core.WithCompounding())
+	}
+
 	// TODO get rid of network
 	store := inmemory.NewInMemStore(network)
 	options := &eth2keymanager.KeyVaultOptions{}
@@ -65,6 +114,10 @@ func (h *Account) DepositData(cmd *cobra.Command, _ []string) error {
 		return errors.Wrap(err, "failed to open wallet")
 	}
 
+	if len(outputDir) != 0 {
+		return h.writeDepositDataFile(wallet, seedBytes, indexFlagValue, count, network, outputDir, depositDataOptions)
+	}
+
 	_, err = wallet.CreateValidatorAccount(seedBytes, &indexFlagValue)
 	if err != nil {
 		return errors.Wrap(err, "failed to create validator account")
@@ -75,7 +128,7 @@ func (h *Account) DepositData(cmd *cobra.Command, _ []string) error {
 		return errors.Wrap(err, "failed to get account by public key")
 	}
 
-	depositData, err := account.GetDepositData()
+	depositData, err := account.GetDepositData(depositDataOptions...)
 	if err != nil {
 		return errors.Wrap(err, "failed to get deposit data")
 	}
@@ -86,3 +139,50 @@ func (h *Account) DepositData(cmd *cobra.Command, _ []string) error {
 	}
 	return nil
 }
+
+// writeDepositDataFile writes the deposit data of count accounts from the given index to a deposit_data file and prints the file.
+func (h *Account) writeDepositDataFile(wallet core.Wallet, seed []byte, index int, count int, network core.Network, outputDir string, depositDataOptions []core.DepositDataOption) error {
+	deposits := make([]*eth1deposit.DepositDataJSON, 0, count)
+	for i := index; i < index+count; i++ {
+		accountIndex := i
+		a, err := wallet.CreateValidatorAccount(seed, &accountIndex)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create validator account %d", accountIndex)
+		}
+		account, ok := a.(*wallets.HDAccount)
+		if !ok {
+			return errors.Errorf("account %d has no deposit data", accountIndex)
+		}
+
+		depositData, _, err := account.DepositData(depositDataOptions...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get deposit data of account %d", accountIndex)
+		}
+		deposit, err := eth1deposit.NewDepositDataJSON(depositData, network)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get deposit data of account %d", accountIndex)
+		}
+		deposits = append(deposits, deposit)
+	}
+
+	byts, err := json.Marshal(deposits)
+	if err != nil {
+		return errors.Wrap(err, "failed to JSON marshal deposit data")
+	}
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create output dir")
+	}
+	file := filepath.Join(outputDir, eth1deposit.DepositDataFileName(time.Now().Unix()))
+	if err := os.WriteFile(file, byts, 0600); err != nil {
+		return errors.Wrap(err, "failed to write deposit data file")
+	}
+
+	err = h.printer.JSON(map[string]interface{}{
+		"file":     file,
+		"deposits": len(deposits),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to print deposit data file JSON")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
+)
+
+// Export writes an EIP-2335 keystore file for every wallet account and prints the written files.
+func (h *Account) Export(cmd *cobra.Command, _ []string) error {
+	err := core.InitBLS()
+	if err != nil {
+		return errors.Wrap(err, "failed to init BLS")
+	}
+
+	// Get storage flag.
+	storageFlagValue, err := flag.GetStorageFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the storage flag value")
+	}
+
+	storageBytes, err := hex.DecodeString(storageFlagValue)
+	if err != nil {
+		return errors.Wrap(err, "failed to HEX decode storage")
+	}
+
+	// Get password file flag value.
+	password, err := flag.GetPasswordFileFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the password file flag value")
+	}
+
+	// Get output dir flag value.
+	outputDir, err := flag.GetOutputDirFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the output dir flag value")
+	}
+
+	// Get KDF flag value.
+	kdf, err := flag.GetKDFFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the KDF flag value")
+	}
+
+	var store inmemory.InMemStore
+	err = store.UnmarshalJSON(storageBytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to JSON un-marshal storage")
+	}
+
+	wallet, err := store.OpenWallet()
+	if err != nil {
+		return errors.Wrap(err, "failed to open wallet")
+	}
+
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create output dir")
+	}
+
+	encryptor := keystorev4.New(keystorev4.WithCipher(kdf))
+	timestamp := time.Now().Unix()
+	var files []map[string]string
+	for _, a := range wallet.Accounts() {
+		account, ok := a.(*wallets.HDAccount)
+		if !ok {
+			return errors.Errorf("account %s can't be exported", a.ID())
+		}
+
+		ks, err := account.ExportKeystore(encryptor, password)
+		if err != nil {
+			return errors.Wrapf(err, "failed to export account %s", a.ID())
+		}
+		byts, err := json.Marshal(ks)
+		if err != nil {
+			return errors.Wrap(err, "failed to JSON marshal keystore")
+		}
+
+		// named like staking-deposit-cli keystores
+		name := ks.PubKey
+		if ks.Path != "" {
+			name = strings.ReplaceAll(ks.Path, "/", "_")
+		}
+		file := filepath.Join(outputDir, fmt.Sprintf("keystore-%s-%d.json", name, timestamp))
+		if err := os.WriteFile(file, byts, 0600); err != nil {
+			return errors.Wrap(err, "failed to write keystore file")
+		}
+
+		files = append(files, map[string]string{
+			"file":             file,
+			"id":               account.ID().String(),
+			"validationPubKey": ks.PubKey,
+		})
+	}
+
+	err = h.printer.JSON(files)
+	if err != nil {
+		return errors.Wrap(err, "failed to print keystore files JSON")
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+)
+
+// Import imports the EIP-2335 keystores of a directory and prints the storage, or every file result.
+func (h *Account) Import(cmd *cobra.Command, _ []string) error {
+	err := core.InitBLS()
+	if err != nil {
+		return errors.Wrap(err, "failed to init BLS")
+	}
+
+	// Get network flag
+	network, err := rootcmd.GetNetworkFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the network flag value")
+	}
+
+	// Get response-type flag value.
+	responseType, err := rootcmd.GetResponseTypeFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the response type value")
+	}
+
+	// Get keystore dir flag value.
+	keystoreDir, err := flag.GetKeystoreDirFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the keystore dir flag value")
+	}
+
+	// Get password file flag value.
+	password, err := flag.GetPasswordFileFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the password file flag value")
+	}
+
+	// Get wallet type flag value.
+	walletType, err := flag.GetWalletTypeFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the wallet type flag value")
+	}
+
+	store := inmemory.NewInMemStore(network)
+	options := &eth2keymanager.KeyVaultOptions{}
+	options.SetStorage(store).SetWalletType(walletType)
+	vault, err := eth2keymanager.NewKeyVault(options)
+	if err != nil {
+		return errors.Wrap(err, "failed to create key vault")
+	}
+
+	results, err := vault.ImportKeystoreDir(keystoreDir, password)
+	if err != nil {
+		return errors.Wrap(err, "failed to import keystores")
+	}
+
+	if responseType == rootcmd.StorageResponseType {
+		// a partial storage would silently miss validators
+		var failures []string
+		for _, result := range results {
+			if result.Err != nil {
+				failures = append(failures, filepath.Base(result.File)+": "+result.Err.Error())
+			}
+		}
+		if len(failures) > 0 {
+			return errors.Errorf("failed to import keystores: %s", strings.Join(failures, ", "))
+		}
+
+		bytes, err := store.MarshalJSON()
+		if err != nil {
+			return errors.Wrap(err, "failed to JSON marshal storage")
+		}
+		h.printer.Text(hex.EncodeToString(bytes))
+		return nil
+	}
+
+	ret := make([]map[string]string, len(results))
+	for i, result := range results {
+		ret[i] = map[string]string{
+			"file": filepath.Base(result.File),
+		}
+		if result.Err != nil {
+			ret[i]["error"] = result.Err.Error()
+			continue
+		}
+		ret[i]["id"] = result.Account.ID().String()
+		ret[i]["name"] = result.Account.Name()
+		ret[i]["validationPubKey"] = hex.EncodeToString(result.Account.ValidatorPublicKey())
+	}
+	if err := h.printer.JSON(ret); err != nil {
+		return errors.Wrap(err, "failed to print import results JSON")
+	}
+	return nil
+}
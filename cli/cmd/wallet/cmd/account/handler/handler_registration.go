@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/signer"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
+)
+
+// Registration signs the builder validator registrations of every account of the vault and prints them,
+// ordered by public key. Share accounts are skipped, their partial signatures aren't valid registrations.
+func (h *Account) Registration(cmd *cobra.Command, _ []string) error {
+	// Get network flag.
+	network, err := rootcmd.GetNetworkFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the network flag value")
+	}
+
+	// Get fee recipient flag.
+	feeRecipient, err := flag.GetFeeRecipientFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the fee recipient flag value")
+	}
+
+	// Get gas limit flag.
+	gasLimit, err := flag.GetGasLimitFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the gas limit flag value")
+	}
+
+	// Get timestamp flag.
+	timestamp, err := flag.GetTimestampFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the timestamp flag value")
+	}
+
+	// Get vault flags.
+	vault, err := flag.GetVaultFlagValues(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the vault flag values")
+	}
+	wallet, closeVault, err := openVault(vault, network)
+	if err != nil {
+		return errors.Wrap(err, "failed to open vault")
+	}
+	defer closeVault()
+
+	accounts := make([]core.ValidatorAccount, 0)
+	for _, account := range wallet.Accounts() {
+		if _, isShare := account.(*wallets.ShareAccount); !isShare {
+			accounts = append(accounts, account)
+		}
+	}
+	if len(accounts) == 0 {
+		return errors.New("vault has no accounts to register, share accounts are skipped")
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].ValidatorPublicKey(), accounts[j].ValidatorPublicKey()) < 0
+	})
+
+	simpleSigner := signer.NewSimpleSigner(wallet, nil, network)
+	signedRegistrations := make([]*apiv1.SignedValidatorRegistration, 0, len(accounts))
+	for _, account := range accounts {
+		registration := &apiv1.ValidatorRegistration{
+			FeeRecipient: feeRecipient,
+			GasLimit:     gasLimit,
+			Timestamp:    timestamp,
+		}
+		copy(registration.Pubkey[:], account.ValidatorPublicKey())
+
+		signature, _, err := simpleSigner.SignRegistrationAutoDomain(&api.VersionedValidatorRegistration{
+			Version: spec.BuilderVersionV1,
+			V1:      registration,
+		}, account.ValidatorPublicKey())
+		if err != nil {
+			return errors.Wrapf(err, "failed to sign validator registration of %s", registration.Pubkey.String())
+		}
+
+		signedRegistration := &apiv1.SignedValidatorRegistration{
+			Message: registration,
+		}
+		copy(signedRegistration.Signature[:], signature)
+		signedRegistrations = append(signedRegistrations, signedRegistration)
+	}
+
+	err = h.printer.JSON(signedRegistrations)
+	if err != nil {
+		return errors.Wrap(err, "failed to print signed validator registrations JSON")
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
+	eth1request "github.com/ssvlabs/eth2-key-manager/eth1_request"
+)
+
+// RequestFlagValues keeps the collected values of the request commands
+type RequestFlagValues struct {
+	network               core.Network
+	account               core.ValidatorAccount
+	withdrawalCredentials []byte
+	fee                   *big.Int
+	nonce                 uint64
+	maxFeePerGas          *big.Int
+	maxPriorityFeePerGas  *big.Int
+}
+
+// WithdrawalRequest builds the unsigned EIP-7002 withdrawal request transaction of an account of the vault and prints it.
+func (h *Account) WithdrawalRequest(cmd *cobra.Command, _ []string) error {
+	requestFlags, closeVault, err := CollectRequestFlags(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to collect request flags")
+	}
+	defer closeVault()
+
+	amount, err := flag.GetAmountFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the amount flag value")
+	}
+
+	request, err := eth1request.NewWithdrawalRequest(requestFlags.account, requestFlags.withdrawalCredentials, amount)
+	if err != nil {
+		return errors.Wrap(err, "invalid withdrawal request")
+	}
+	tx, err := request.Transaction(requestFlags.network, requestFlags.fee)
+	if err != nil {
+		return errors.Wrap(err, "failed to build the withdrawal request transaction")
+	}
+	return h.printRequestTransaction(requestFlags, request.SourceAddress[:], tx)
+}
+
+// ConsolidationRequest builds the unsigned EIP-7251 consolidation request transaction of an account of the vault and prints it.
+func (h *Account) ConsolidationRequest(cmd *cobra.Command, _ []string) error {
+	requestFlags, closeVault, err := CollectRequestFlags(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to collect request flags")
+	}
+	defer closeVault()
+
+	targetPubKey, err := flag.GetTargetPublicKeyFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the target public key flag value")
+	}
+	targetWithdrawalCredentials, err := flag.GetTargetWithdrawalCredentialsFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the target withdrawal credentials flag value")
+	}
+
+	allowForeignTarget, err := flag.GetAllowForeignTargetFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the allow foreign target flag value")
+	}
+	var opts []eth1request.ConsolidationOption
+	if allowForeignTarget {
+		opts = append(opts, eth1request.WithForeignTarget())
+	}
+
+	request, err := eth1request.NewConsolidationRequest(requestFlags.account, requestFlags.withdrawalCredentials, targetPubKey, targetWithdrawalCredentials, opts...)
+	if err != nil {
+		return errors.Wrap(err, "invalid consolidation request")
+	}
+	tx, err := request.Transaction(requestFlags.network, requestFlags.fee)
+	if err != nil {
+		return errors.Wrap(err, "failed to build the consolidation request transaction")
+	}
+	return h.printRequestTransaction(requestFlags, request.SourceAddress[:], tx)
+}
+
+// printRequestTransaction sets the nonce and gas fees of the request transaction and prints it, with the address that must sign it.
+func (h *Account) printRequestTransaction(requestFlags *RequestFlagValues, from []byte, tx *eth1deposit.Transaction) error {
+	tx.Nonce = requestFlags.nonce
+	tx.MaxFeePerGas = requestFlags.maxFeePerGas
+	tx.MaxPriorityFeePerGas = requestFlags.maxPriorityFeePerGas
+
+	unsigned, err := tx.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the request transaction")
+	}
+	signingHash, err := tx.SigningHash()
+	if err != nil {
+		return errors.Wrap(err, "failed to hash the request transaction")
+	}
+
+	err = h.printer.JSON(map[string]interface{}{
+		"pubkey":              "0x" + hex.EncodeToString(requestFlags.account.ValidatorPublicKey()),
+		"from":                "0x" + hex.EncodeToString(from),
+		"transaction":         tx,
+		"unsignedTransaction": "0x" + hex.EncodeToString(unsigned),
+		"signingHash":         "0x" + hex.EncodeToString(signingHash[:]),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to print request transaction JSON")
+	}
+	return nil
+}
+
+// CollectRequestFlags returns the collected flags of the request commands, with the account of the vault.
+// The returned function closes the vault.
+func CollectRequestFlags(cmd *cobra.Command) (*RequestFlagValues, func(), error) {
+	requestFlagValues := RequestFlagValues{}
+
+	// Get network flag value.
+	network, err := rootcmd.GetNetworkFlagValue(cmd)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the network flag value")
+	}
+	requestFlagValues.network = network
+
+	// Get validator public key flag value.
+	pubKey, err := flag.GetValidatorPublicKeyFlagValue(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get withdrawal credentials flag value.
+	if requestFlagValues.withdrawalCredentials, err = flag.GetRequestWithdrawalCredentialsFlagValue(cmd); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the withdrawal credentials flag value")
+	}
+
+	// Get fee flag value.
+	if requestFlagValues.fee, err = flag.GetFeeFlagValue(cmd); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the fee flag value")
+	}
+
+	// Get nonce and gas fees flag values.
+	if requestFlagValues.nonce, err = rootcmd.GetNonceFlagValue(cmd); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the nonce flag value")
+	}
+	if requestFlagValues.maxFeePerGas, err = rootcmd.GetMaxFeePerGasFlagValue(cmd); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the max fee per gas flag value")
+	}
+	if requestFlagValues.maxPriorityFeePerGas, err = rootcmd.GetMaxPriorityFeePerGasFlagValue(cmd); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the max priority fee per gas flag value")
+	}
+
+	// Get vault flag values.
+	vault, err := flag.GetVaultFlagValues(cmd)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve the vault flag values")
+	}
+	wallet, closeVault, err := openVault(vault, network)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to open vault")
+	}
+	if requestFlagValues.account, err = wallet.AccountByPublicKey(hex.EncodeToString(pubKey[:])); err != nil {
+		closeVault()
+		return nil, nil, errors.Wrapf(err, "failed to find the account of public key %s", pubKey.String())
+	}
+
+	return &requestFlagValues, closeVault, nil
+}
@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
@@ -22,7 +26,7 @@ import (
 type VoluntaryExitFlagValues struct {
 	index              int
 	seedBytes          []byte
-	currentForkVersion phase0.Version
+	currentForkVersion *phase0.Version
 	epoch              int
 	validator          *core.ValidatorInfo
 	network            core.Network
@@ -38,12 +42,21 @@ type SignRequestEncoded struct {
 }
 
 // VoluntaryExit creates a new wallet account(s) and prints the storage.
+// With a vault, it signs the exits of the given validators and writes a signed voluntary exit file per validator.
 func (h *Account) VoluntaryExit(cmd *cobra.Command, args []string) error {
 	err := core.InitBLS()
 	if err != nil {
 		return errors.Wrap(err, "failed to init BLS")
 	}
 
+	vault, err := flag.GetVaultFlagValues(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the vault flag values")
+	}
+	if vault.IsSet() {
+		return h.vaultVoluntaryExits(cmd, vault)
+	}
+
 	voluntaryExitFlags, err := CollectVoluntaryExitFlags(cmd)
 	if err != nil {
 		return errors.Wrap(err, "failed to collect voluntary exit flags")
@@ -65,15 +78,6 @@ func (h *Account) VoluntaryExit(cmd *cobra.Command, args []string) error {
 		return errors.Wrap(err, "failed to open wallet")
 	}
 
-	// Compute domain
-	genesisValidatorsRoot := store.Network().GenesisValidatorsRoot()
-	domainBytes, err := types.ComputeDomain(types.DomainVoluntaryExit, voluntaryExitFlags.currentForkVersion[:], genesisValidatorsRoot[:])
-	if err != nil {
-		return errors.Wrap(err, "failed to calculate domain")
-	}
-	var domain phase0.Domain
-	copy(domain[:], domainBytes)
-
 	voluntaryExit := &phase0.VoluntaryExit{
 		Epoch:          phase0.Epoch(voluntaryExitFlags.epoch),
 		ValidatorIndex: voluntaryExitFlags.validator.Index,
@@ -93,7 +97,16 @@ func (h *Account) VoluntaryExit(cmd *cobra.Command, args []string) error {
 			return errors.Errorf("derived validator public key: %s, does not match with the provided one: %s", derivedPubKey, providedPubKey)
 		}
 
-		signature, _, err := simpleSigner.SignVoluntaryExit(voluntaryExit, domain, acc.ValidatorPublicKey())
+		var signature []byte
+		if voluntaryExitFlags.currentForkVersion == nil {
+			signature, _, err = simpleSigner.SignVoluntaryExitAutoDomain(voluntaryExit, acc.ValidatorPublicKey())
+		} else {
+			var domain phase0.Domain
+			if domain, err = voluntaryExitDomain(voluntaryExitFlags); err != nil {
+				return err
+			}
+			signature, _, err = simpleSigner.SignVoluntaryExit(voluntaryExit, domain, acc.ValidatorPublicKey())
+		}
 		if err != nil {
 			return errors.Wrap(err, "failed to sign voluntary exit")
 		}
@@ -111,6 +124,10 @@ func (h *Account) VoluntaryExit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Sign request
+	domain, err := voluntaryExitDomain(voluntaryExitFlags)
+	if err != nil {
+		return err
+	}
 	marshalSSZ, err := voluntaryExit.MarshalSSZ()
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal voluntary exit")
@@ -137,6 +154,10 @@ func (h *Account) VoluntaryExit(cmd *cobra.Command, args []string) error {
 func CollectVoluntaryExitFlags(cmd *cobra.Command) (*VoluntaryExitFlagValues, error) {
 	voluntaryExitFlagValues := VoluntaryExitFlagValues{}
 
+	if err := flag.CheckVoluntaryExitFlagsSet(cmd); err != nil {
+		return nil, err
+	}
+
 	// Get network flag value.
 	network, err := rootcmd.GetNetworkFlagValue(cmd)
 	if err != nil {
@@ -177,13 +198,6 @@ func CollectVoluntaryExitFlags(cmd *cobra.Command) (*VoluntaryExitFlagValues, er
 	}
 	voluntaryExitFlagValues.index = indexFlagValue
 
-	// Get current fork version flag value.
-	currentForkVersionFlagValue, err := flag.GetCurrentForkVersionFlagValue(cmd)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to retrieve the current fork version flag value")
-	}
-	voluntaryExitFlagValues.currentForkVersion = currentForkVersionFlagValue
-
 	// Get epoch flag value.
 	epochFlagValue, err := flag.GetEpochFlagValue(cmd)
 	if err != nil {
@@ -191,6 +205,28 @@ func CollectVoluntaryExitFlags(cmd *cobra.Command) (*VoluntaryExitFlagValues, er
 	}
 	voluntaryExitFlagValues.epoch = epochFlagValue
 
+	// Get current fork version flag value, required for networks without a fork schedule.
+	// Networks with a fork schedule compute the domain from it, a given fork version must be the one of the epoch.
+	if _, err := network.ForkSchedule(); err != nil {
+		currentForkVersionFlagValue, err := flag.GetCurrentForkVersionFlagValue(cmd)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to retrieve the current fork version flag value, required for networks without a fork schedule")
+		}
+		voluntaryExitFlagValues.currentForkVersion = &currentForkVersionFlagValue
+	} else if flag.IsCurrentForkVersionFlagSet(cmd) {
+		currentForkVersionFlagValue, err := flag.GetCurrentForkVersionFlagValue(cmd)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to retrieve the current fork version flag value")
+		}
+		fork, err := network.ForkAtEpoch(phase0.Epoch(epochFlagValue))
+		if err != nil {
+			return nil, err
+		}
+		if currentForkVersionFlagValue != fork.Version {
+			return nil, errors.Errorf("current fork version %#x doesn't match the fork version %#x of network %s at epoch %d", currentForkVersionFlagValue, fork.Version, network, epochFlagValue)
+		}
+	}
+
 	// Get validator info flag value.
 	validator, err := flag.GetVoluntaryExitInfoFlagValue(cmd)
 	if err != nil {
@@ -200,3 +236,116 @@ func CollectVoluntaryExitFlags(cmd *cobra.Command) (*VoluntaryExitFlagValues, er
 
 	return &voluntaryExitFlagValues, nil
 }
+
+// voluntaryExitDomain computes the voluntary exit domain from the network fork schedule,
+// or from the current fork version flag for networks without one.
+func voluntaryExitDomain(voluntaryExitFlags *VoluntaryExitFlagValues) (phase0.Domain, error) {
+	if voluntaryExitFlags.currentForkVersion == nil {
+		domain, err := signer.DomainFor(voluntaryExitFlags.network, types.DomainVoluntaryExit, phase0.Epoch(voluntaryExitFlags.epoch))
+		if err != nil {
+			return phase0.Domain{}, errors.Wrap(err, "failed to calculate domain")
+		}
+		return domain, nil
+	}
+
+	genesisValidatorsRoot := voluntaryExitFlags.network.GenesisValidatorsRoot()
+	domainBytes, err := types.ComputeDomain(types.DomainVoluntaryExit, voluntaryExitFlags.currentForkVersion[:], genesisValidatorsRoot[:])
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to calculate domain")
+	}
+	var domain phase0.Domain
+	copy(domain[:], domainBytes)
+	return domain, nil
+}
+
+// vaultVoluntaryExits signs the exits of the given validators with the vault keys, the domain is computed from the network fork schedule.
+// A signed voluntary exit file is written per validator once all exits are signed.
+func (h *Account) vaultVoluntaryExits(cmd *cobra.Command, vault *flag.VaultFlagValues) error {
+	// Get network flag value.
+	network, err := rootcmd.GetNetworkFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the network flag value")
+	}
+
+	// Get epoch flag value.
+	epoch, err := flag.GetEpochFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the epoch flag value")
+	}
+
+	// Get output dir flag value.
+	outputDir, err := flag.GetOutputDirFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve the output dir flag value")
+	}
+	if len(outputDir) == 0 {
+		return errors.New("output-dir flag is required with a vault")
+	}
+
+	// Get validator indices flag value.
+	validatorIndices, err := flag.GetValidatorIndicesFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validator indices")
+	}
+
+	// Get validator public keys flag value.
+	validatorPubKeys, err := flag.GetValidatorPublicKeysFlagValue(cmd)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validator public keys")
+	}
+	if len(validatorIndices) != len(validatorPubKeys) {
+		return errors.New("validator indices and public keys must be of equal length")
+	}
+
+	wallet, closeVault, err := openVault(vault, network)
+	if err != nil {
+		return errors.Wrap(err, "failed to open vault")
+	}
+	defer closeVault()
+
+	simpleSigner := signer.NewSimpleSigner(wallet, nil, network)
+	signedVoluntaryExits := make([]*phase0.SignedVoluntaryExit, len(validatorIndices))
+	for i, validatorIndex := range validatorIndices {
+		voluntaryExit := &phase0.VoluntaryExit{
+			Epoch:          phase0.Epoch(epoch),
+			ValidatorIndex: phase0.ValidatorIndex(validatorIndex),
+		}
+		signature, _, err := simpleSigner.SignVoluntaryExitAutoDomain(voluntaryExit, validatorPubKeys[i][:])
+		if err != nil {
+			return errors.Wrapf(err, "failed to sign voluntary exit of validator %d", validatorIndex)
+		}
+
+		signedVoluntaryExits[i] = &phase0.SignedVoluntaryExit{
+			Message: voluntaryExit,
+		}
+		copy(signedVoluntaryExits[i].Signature[:], signature)
+	}
+
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create output dir")
+	}
+	timestamp := time.Now().Unix()
+	files := make([]map[string]string, len(signedVoluntaryExits))
+	for i, signedVoluntaryExit := range signedVoluntaryExits {
+		byts, err := json.Marshal(signedVoluntaryExit)
+		if err != nil {
+			return errors.Wrap(err, "failed to JSON marshal signed voluntary exit")
+		}
+		file := filepath.Join(outputDir, fmt.Sprintf("voluntary_exit-%d-%d.json", signedVoluntaryExit.Message.ValidatorIndex, timestamp))
+		if err := os.WriteFile(file, byts, 0600); err != nil {
+			return errors.Wrap(err, "failed to write signed voluntary exit file")
+		}
+
+		files[i] = map[string]string{
+			"file":               file,
+			"validatorIndex":     fmt.Sprintf("%d", signedVoluntaryExit.Message.ValidatorIndex),
+			"validatorPublicKey": validatorPubKeys[i].String(),
+		}
+	}
+
+	err = h.printer.JSON(files)
+	if err != nil {
+		return errors.Wrap(err, "failed to print signed voluntary exit files JSON")
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	encryptor2 "github.com/ssvlabs/eth2-key-manager/encryptor"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+	"github.com/ssvlabs/eth2-key-manager/stores/bolt"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+)
+
+// openVault opens the wallet of the keystores, storage or bolt database of the vault flags.
+// The returned function closes the underlying storage.
+func openVault(vault *flag.VaultFlagValues, network core.Network) (core.Wallet, func(), error) {
+	var password string
+	if len(vault.PasswordFile) > 0 {
+		var err error
+		if password, err = flag.ReadPasswordFile(vault.PasswordFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sources := 0
+	for _, value := range []string{vault.KeystoreDir, vault.Storage, vault.DBPath} {
+		if len(value) > 0 {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, nil, errors.New("exactly one of keystore-dir, storage or db-path must be set")
+	}
+
+	switch {
+	case len(vault.KeystoreDir) > 0:
+		if len(vault.PasswordFile) == 0 {
+			return nil, nil, errors.New("password-file is required with keystore-dir")
+		}
+		store := inmemory.NewInMemStore(network)
+		options := &eth2keymanager.KeyVaultOptions{}
+		options.SetStorage(store)
+		keyVault, err := eth2keymanager.NewKeyVault(options)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to create key vault")
+		}
+		results, err := keyVault.ImportKeystoreDir(vault.KeystoreDir, password)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to import keystores")
+		}
+		// a keystore that failed to import would silently miss its validator
+		var failures []string
+		for _, result := range results {
+			if result.Err != nil {
+				failures = append(failures, filepath.Base(result.File)+": "+result.Err.Error())
+			}
+		}
+		if len(failures) > 0 {
+			return nil, nil, errors.Errorf("failed to import keystores: %s", strings.Join(failures, ", "))
+		}
+		wallet, err := keyVault.Wallet()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to open wallet")
+		}
+		return wallet, func() {}, nil
+	case len(vault.Storage) > 0:
+		storageBytes, err := hex.DecodeString(vault.Storage)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to HEX decode storage")
+		}
+		var store inmemory.InMemStore
+		if err := store.UnmarshalJSON(storageBytes); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to JSON un-marshal storage")
+		}
+		if store.Network() != network {
+			return nil, nil, errors.Errorf("storage network %s doesn't match %s", store.Network(), network)
+		}
+		if len(vault.PasswordFile) > 0 {
+			store.SetEncryptor(keystorev4.New(), []byte(password))
+		}
+		wallet, err := store.OpenWallet()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to open wallet")
+		}
+		return wallet, func() {}, nil
+	default:
+		var encryptor encryptor2.Encryptor
+		if len(vault.PasswordFile) > 0 {
+			encryptor = keystorev4.New()
+		}
+		store, err := bolt.NewBoltStoreWithEncryptor(vault.DBPath, network, encryptor, []byte(password))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to open bolt storage")
+		}
+		wallet, err := store.OpenWallet()
+		if err != nil {
+			_ = store.Close()
+			return nil, nil, errors.Wrap(err, "failed to open wallet")
+		}
+		return wallet, func() { _ = store.Close() }, nil
+	}
+}
@@ -0,0 +1,31 @@
+package account
+
+import (
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/handler"
+)
+
+// importCmd represents the import keystores command.
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports EIP-2335 keystores.",
+	Long:  `This command creates a wallet account from every keystore*.json file of a directory, like the ones of staking-deposit-cli, keeping their path and UUID.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler := handler.New(rootcmd.ResultPrinter)
+		return handler.Import(cmd, args)
+	},
+}
+
+func init() {
+	// Define flags for the command.
+	rootcmd.AddNetworkFlag(importCmd)
+	rootcmd.AddResponseTypeFlag(importCmd)
+	flag.AddKeystoreDirFlag(importCmd)
+	flag.AddPasswordFileFlag(importCmd)
+	flag.AddWalletTypeFlag(importCmd)
+
+	Command.AddCommand(importCmd)
+}
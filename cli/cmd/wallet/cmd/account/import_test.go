@@ -0,0 +1,142 @@
+package account_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+)
+
+func writeKeystore(t *testing.T, dir string, secret string, path string, password string) *keystorev4.Keystore {
+	require.NoError(t, core.InitBLS())
+	secretBytes, err := hex.DecodeString(secret)
+	require.NoError(t, err)
+	key, err := core.NewHDKeyFromPrivateKey(secretBytes, path)
+	require.NoError(t, err)
+	crypto, err := keystorev4.New(keystorev4.WithCipher("pbkdf2")).Encrypt(secretBytes, password)
+	require.NoError(t, err)
+
+	ks := &keystorev4.Keystore{
+		Crypto:  crypto,
+		PubKey:  hex.EncodeToString(key.PublicKey().Serialize()),
+		Path:    path,
+		UUID:    uuid.New(),
+		Version: 4,
+	}
+	byts, err := json.Marshal(ks)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keystore-"+ks.PubKey[:8]+".json"), byts, 0600))
+	return ks
+}
+
+func TestAccountImport(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(t.TempDir(), "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+	ks := writeKeystore(t, dir, "2c083f2c8fc923fa2bd32a70ab72b4b46247e8c1f347adc9fd2ac29e6b5b51ad", "m/12381/3600/3/0/0", "password")
+
+	t.Run("Successfully import keystores and return as object", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"import",
+			"--keystore-dir=" + dir,
+			"--password-file=" + passwordFile,
+			"--response-type=object",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var results []map[string]string
+		require.NoError(t, json.Unmarshal(output.Bytes(), &results))
+		require.Len(t, results, 1)
+		require.Equal(t, ks.UUID.String(), results[0]["id"])
+		require.Equal(t, "account-3", results[0]["name"])
+		require.Equal(t, ks.PubKey, results[0]["validationPubKey"])
+	})
+
+	t.Run("Successfully import keystores and return as storage", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"import",
+			"--keystore-dir=" + dir,
+			"--password-file=" + passwordFile,
+			"--response-type=storage",
+			"--wallet-type=HD",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+		require.NotEmpty(t, output.String())
+	})
+
+	t.Run("Failing keystores are reported", func(t *testing.T) {
+		writeKeystore(t, dir, "6327b1e58c41d60dd7c3c8b9634204255707c2d12e2513c345001d8926745eea", "m/12381/3600/4/0/0", "other")
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"import",
+			"--keystore-dir=" + dir,
+			"--password-file=" + passwordFile,
+			"--response-type=object",
+			"--wallet-type=ND",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var results []map[string]string
+		require.NoError(t, json.Unmarshal(output.Bytes(), &results))
+		require.Len(t, results, 2)
+		failures := 0
+		for _, result := range results {
+			if result["error"] != "" {
+				failures++
+				require.Equal(t, "failed to decrypt keystore: invalid checksum", result["error"])
+			}
+		}
+		require.Equal(t, 1, failures)
+
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"import",
+			"--keystore-dir=" + dir,
+			"--password-file=" + passwordFile,
+			"--response-type=storage",
+			"--network=mainnet",
+		})
+		require.ErrorContains(t, cmd.RootCmd.Execute(), "failed to import keystores: keystore-")
+	})
+
+	t.Run("Unknown wallet type", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"import",
+			"--keystore-dir=" + dir,
+			"--password-file=" + passwordFile,
+			"--wallet-type=XX",
+			"--network=mainnet",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "failed to retrieve the wallet type flag value: unknown wallet type XX")
+	})
+}
@@ -0,0 +1,31 @@
+package account
+
+import (
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/handler"
+)
+
+// registrationCmd represents the registration account command.
+var registrationCmd = &cobra.Command{
+	Use:   "registration",
+	Short: "Sign validator registrations",
+	Long:  `This command signs the builder validator registrations of every account of EIP-2335 keystores, a storage or a bolt database, and prints the signed registrations accepted by /eth/v1/builder/validators.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler := handler.New(rootcmd.ResultPrinter)
+		return handler.Registration(cmd, args)
+	},
+}
+
+func init() {
+	// Define flags for the command.
+	rootcmd.AddNetworkFlag(registrationCmd)
+	flag.AddVaultFlags(registrationCmd)
+	flag.AddFeeRecipientFlag(registrationCmd)
+	flag.AddGasLimitFlag(registrationCmd)
+	flag.AddTimestampFlag(registrationCmd)
+
+	Command.AddCommand(registrationCmd)
+}
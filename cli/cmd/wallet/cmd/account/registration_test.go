@@ -0,0 +1,167 @@
+package account_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+	types "github.com/wealdtech/go-eth2-types/v2"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/signer"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
+	"github.com/ssvlabs/eth2-key-manager/wallets/threshold"
+)
+
+func TestAccountRegistration(t *testing.T) {
+	keystoreDir := t.TempDir()
+	passwordFile := filepath.Join(t.TempDir(), "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+	ks1 := writeKeystore(t, keystoreDir, "2c083f2c8fc923fa2bd32a70ab72b4b46247e8c1f347adc9fd2ac29e6b5b51ad", "m/12381/3600/3/0/0", "password")
+	ks2 := writeKeystore(t, keystoreDir, "175db1c5411459893301c3f2ebe740e5da07db8f17c2df4fa0be6d31a48a4f79", "m/12381/3600/4/0/0", "password")
+
+	t.Run("Successfully sign registrations of every account", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"registration",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--fee-recipient=0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
+			"--gas-limit=36000000",
+			"--timestamp=1700000000",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var v []map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &v))
+		require.Len(t, v, 2)
+		require.Equal(t, map[string]interface{}{
+			"fee_recipient": "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
+			"gas_limit":     "36000000",
+			"timestamp":     "1700000000",
+			"pubkey":        v[0]["message"].(map[string]interface{})["pubkey"],
+		}, v[0]["message"])
+
+		var registrations []*apiv1.SignedValidatorRegistration
+		require.NoError(t, json.Unmarshal(output.Bytes(), &registrations))
+
+		// registrations are signed with the builder domain, of the genesis fork version and a zero genesis validators root
+		domainBytes, err := types.ComputeDomain(signer.DomainApplicationBuilder, []byte{0x00, 0x00, 0x00, 0x00}, make([]byte, 32))
+		require.NoError(t, err)
+		var domain phase0.Domain
+		copy(domain[:], domainBytes)
+
+		pubKeys := []string{}
+		for _, registration := range registrations {
+			pubKeys = append(pubKeys, hex.EncodeToString(registration.Message.Pubkey[:]))
+
+			root, err := signer.ComputeETHSigningRoot(registration.Message, domain)
+			require.NoError(t, err)
+			pk := &bls.PublicKey{}
+			require.NoError(t, pk.Deserialize(registration.Message.Pubkey[:]))
+			sig := &bls.Sign{}
+			signature := registration.Signature
+			require.NoError(t, sig.Deserialize(signature[:]))
+			require.True(t, sig.VerifyByte(pk, root[:]))
+		}
+		require.ElementsMatch(t, []string{ks1.PubKey, ks2.PubKey}, pubKeys)
+		require.Less(t, pubKeys[0], pubKeys[1])
+	})
+
+	t.Run("Invalid fee recipient", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"registration",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--fee-recipient=0xd8dA",
+			"--network=mainnet",
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualValues(t, "", output.String())
+		require.EqualError(t, err, "failed to retrieve the fee recipient flag value: invalid length for fee-recipient")
+	})
+
+	t.Run("Share accounts are skipped", func(t *testing.T) {
+		store := inmemory.NewInMemStore(core.MainNetwork)
+		options := &eth2keymanager.KeyVaultOptions{}
+		options.SetStorage(store).SetWalletType(core.NDWallet)
+		vault, err := eth2keymanager.NewKeyVault(options)
+		require.NoError(t, err)
+		wallet, err := vault.Wallet()
+		require.NoError(t, err)
+		secret, err := hex.DecodeString("175db1c5411459893301c3f2ebe740e5da07db8f17c2df4fa0be6d31a48a4f79")
+		require.NoError(t, err)
+		key, err := core.NewHDKeyFromPrivateKey(secret, "")
+		require.NoError(t, err)
+		shares, vv, err := threshold.Split(key, 2, 3)
+		require.NoError(t, err)
+		share, err := wallets.NewShareAccount("share", shares[0].Key, shares[0].Index, 2, vv, "", vault.Context)
+		require.NoError(t, err)
+		require.NoError(t, wallet.AddValidatorAccount(share))
+		shareOnly, err := store.MarshalJSON()
+		require.NoError(t, err)
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"registration",
+			"--keystore-dir=",
+			"--password-file=",
+			"--storage=" + hex.EncodeToString(shareOnly),
+			"--fee-recipient=0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
+			"--network=mainnet",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "vault has no accounts to register, share accounts are skipped")
+
+		keystore, err := json.Marshal(ks1)
+		require.NoError(t, err)
+		_, err = vault.ImportKeystoreTo(wallet, keystore, "password")
+		require.NoError(t, err)
+		storage, err := store.MarshalJSON()
+		require.NoError(t, err)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"registration",
+			"--storage=" + hex.EncodeToString(storage),
+			"--fee-recipient=0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+		var registrations []*apiv1.SignedValidatorRegistration
+		require.NoError(t, json.Unmarshal(output.Bytes(), &registrations))
+		require.Len(t, registrations, 1)
+		require.Equal(t, ks1.PubKey, hex.EncodeToString(registrations[0].Message.Pubkey[:]))
+
+		// reset the vault flags of the shared command
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"registration",
+			"--storage=",
+			"--network=mainnet",
+		})
+		require.Error(t, cmd.RootCmd.Execute())
+	})
+}
@@ -0,0 +1,195 @@
+package account_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+)
+
+func TestAccountRequests(t *testing.T) {
+	keystoreDir := t.TempDir()
+	passwordFile := filepath.Join(t.TempDir(), "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+	ks1 := writeKeystore(t, keystoreDir, "2c083f2c8fc923fa2bd32a70ab72b4b46247e8c1f347adc9fd2ac29e6b5b51ad", "m/12381/3600/3/0/0", "password")
+	ks2 := writeKeystore(t, keystoreDir, "175db1c5411459893301c3f2ebe740e5da07db8f17c2df4fa0be6d31a48a4f79", "m/12381/3600/4/0/0", "password")
+
+	const address = "d8da6bf26964af9d7eed9e03e53415d37aa96045"
+	const eth1Credentials = "0x010000000000000000000000" + address
+	const compoundingCredentials = "0x020000000000000000000000" + address
+
+	t.Run("Successfully build a withdrawal request", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"withdrawal-request",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--validator-public-key=" + ks1.PubKey,
+			"--withdrawal-credentials=" + eth1Credentials,
+			"--fee=1",
+			"--nonce=3",
+			"--max-fee-per-gas=30",
+			"--max-priority-fee-per-gas=1.5",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &result))
+		require.Equal(t, "0x"+ks1.PubKey, result["pubkey"])
+		require.Equal(t, "0x"+address, result["from"])
+		tx := result["transaction"].(map[string]interface{})
+		require.Equal(t, "0x00000961ef480eb55e80d19ad83579a64c007002", tx["to"])
+		require.Equal(t, "0x1", tx["value"])
+		require.Equal(t, "0x3", tx["nonce"])
+		require.Equal(t, "0x1", tx["chainId"])
+		require.Equal(t, "0x"+ks1.PubKey+"0000000000000000", tx["input"])
+		require.NotEmpty(t, result["unsignedTransaction"])
+		require.NotEmpty(t, result["signingHash"])
+	})
+
+	t.Run("Partial withdrawal requires compounding credentials", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"withdrawal-request",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--validator-public-key=" + ks1.PubKey,
+			"--withdrawal-credentials=" + eth1Credentials,
+			"--amount=1.5",
+			"--fee=1",
+			"--max-fee-per-gas=30",
+			"--max-priority-fee-per-gas=1.5",
+			"--network=mainnet",
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualValues(t, "", output.String())
+		require.EqualError(t, err, "invalid withdrawal request: partial withdrawals require compounding (0x02) withdrawal credentials")
+	})
+
+	t.Run("Successfully build a consolidation request", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"consolidation-request",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--validator-public-key=" + ks1.PubKey,
+			"--withdrawal-credentials=" + eth1Credentials,
+			"--target-public-key=" + ks2.PubKey,
+			"--target-withdrawal-credentials=" + compoundingCredentials,
+			"--fee=2",
+			"--max-fee-per-gas=30",
+			"--max-priority-fee-per-gas=1.5",
+			"--network=holesky",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &result))
+		require.Equal(t, "0x"+address, result["from"])
+		tx := result["transaction"].(map[string]interface{})
+		require.Equal(t, "0x0000bbddc7ce488642fb579f8b00f3a590007251", tx["to"])
+		require.Equal(t, "0x2", tx["value"])
+		require.Equal(t, "0x4268", tx["chainId"])
+		require.Equal(t, "0x"+ks1.PubKey+ks2.PubKey, tx["input"])
+	})
+
+	t.Run("Consolidation target requires compounding credentials", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"consolidation-request",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--validator-public-key=" + ks1.PubKey,
+			"--withdrawal-credentials=" + eth1Credentials,
+			"--target-public-key=" + ks2.PubKey,
+			"--target-withdrawal-credentials=" + eth1Credentials,
+			"--fee=2",
+			"--max-fee-per-gas=30",
+			"--max-priority-fee-per-gas=1.5",
+			"--network=holesky",
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualValues(t, "", output.String())
+		require.EqualError(t, err, "invalid consolidation request: consolidation target requires compounding (0x02) withdrawal credentials")
+	})
+
+	t.Run("Source account not in the vault", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"consolidation-request",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--validator-public-key=0xb2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c",
+			"--withdrawal-credentials=" + eth1Credentials,
+			"--target-public-key=" + ks2.PubKey,
+			"--target-withdrawal-credentials=" + compoundingCredentials,
+			"--fee=2",
+			"--max-fee-per-gas=30",
+			"--max-priority-fee-per-gas=1.5",
+			"--network=holesky",
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualValues(t, "", output.String())
+		require.ErrorContains(t, err, "failed to find the account of public key 0xb2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c")
+	})
+
+	t.Run("Consolidation into a target of another withdrawal address", func(t *testing.T) {
+		const otherCompoundingCredentials = "0x0200000000000000000000000100000000000000000000000000000000000000"
+		args := []string{
+			"wallet",
+			"account",
+			"consolidation-request",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--validator-public-key=" + ks1.PubKey,
+			"--withdrawal-credentials=" + eth1Credentials,
+			"--target-public-key=" + ks2.PubKey,
+			"--target-withdrawal-credentials=" + otherCompoundingCredentials,
+			"--fee=2",
+			"--max-fee-per-gas=30",
+			"--max-priority-fee-per-gas=1.5",
+			"--network=holesky",
+		}
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs(append(args, "--allow-foreign-target=false"))
+		err := cmd.RootCmd.Execute()
+		require.EqualValues(t, "", output.String())
+		require.ErrorContains(t, err, "doesn't match the source withdrawal address")
+
+		cmd.RootCmd.SetArgs(append(args, "--allow-foreign-target"))
+		require.NoError(t, cmd.RootCmd.Execute())
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(output.Bytes(), &result))
+		tx := result["transaction"].(map[string]interface{})
+		require.Equal(t, "0x"+ks1.PubKey+ks2.PubKey, tx["input"])
+
+		// reset the flag of the shared command
+		output.Reset()
+		cmd.RootCmd.SetArgs(append(args, "--allow-foreign-target=false"))
+		require.Error(t, cmd.RootCmd.Execute())
+	})
+}
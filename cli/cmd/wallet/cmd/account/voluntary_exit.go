@@ -12,7 +12,7 @@ import (
 var voluntaryExitCmd = &cobra.Command{
 	Use:   "voluntary-exit",
 	Short: "Sign voluntary exit message",
-	Long:  `This command signing voluntary exit message using seed or preparing request for signing using key-vault`,
+	Long:  `This command signing voluntary exit message using seed or preparing request for signing using key-vault. With EIP-2335 keystores, a storage or a bolt database, it signs the exits of many validators and writes a signed voluntary exit file per validator.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		handler := handler.New(rootcmd.ResultPrinter)
 		return handler.VoluntaryExit(cmd, args)
@@ -23,12 +23,16 @@ func init() {
 	// Define flags for the command.
 	rootcmd.AddNetworkFlag(voluntaryExitCmd)
 	rootcmd.AddSeedFlag(voluntaryExitCmd)
-	rootcmd.AddIndexFlag(voluntaryExitCmd)
+	rootcmd.AddOptionalIndexFlag(voluntaryExitCmd)
 	rootcmd.AddResponseTypeFlag(voluntaryExitCmd)
 	flag.AddCurrentForkVersionFlag(voluntaryExitCmd)
 	flag.AddValidatorPublicKeyFlag(voluntaryExitCmd)
 	flag.AddValidatorIndexFlag(voluntaryExitCmd)
 	flag.AddEpochFlag(voluntaryExitCmd)
+	flag.AddVaultFlags(voluntaryExitCmd)
+	flag.AddExitValidatorIndicesFlag(voluntaryExitCmd)
+	flag.AddExitValidatorPublicKeysFlag(voluntaryExitCmd)
+	flag.AddExitOutputDirFlag(voluntaryExitCmd)
 
 	Command.AddCommand(voluntaryExitCmd)
 }
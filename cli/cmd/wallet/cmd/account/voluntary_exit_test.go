@@ -2,15 +2,45 @@ package account_test
 
 import (
 	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
+	types "github.com/wealdtech/go-eth2-types/v2"
 
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
 	"github.com/ssvlabs/eth2-key-manager/cli/cmd"
 	"github.com/ssvlabs/eth2-key-manager/cli/util/printer"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/signer"
+	"github.com/ssvlabs/eth2-key-manager/stores/bolt"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
 )
 
 func TestAccountVoluntaryExit(t *testing.T) {
+	const seed = "847d135b3aecac8ae77c3fdfd46dc5849ad3b5bacd30a1b9082b6ff53c77357e923b12fcdc3d02728fd35c3685de1fe1e9c052c48f0d83566b1b2287cf0e54c3"
+	const validatorPubKey = "b2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c"
+
+	verifySignedVoluntaryExit := func(t *testing.T, output []byte, domain phase0.Domain) {
+		signedVoluntaryExit := &phase0.SignedVoluntaryExit{}
+		require.NoError(t, json.Unmarshal(output, signedVoluntaryExit))
+		root, err := signer.ComputeETHSigningRoot(signedVoluntaryExit.Message, domain)
+		require.NoError(t, err)
+		pubKeyBytes, err := hex.DecodeString(validatorPubKey)
+		require.NoError(t, err)
+		pk := &bls.PublicKey{}
+		require.NoError(t, pk.Deserialize(pubKeyBytes))
+		sig := &bls.Sign{}
+		signature := signedVoluntaryExit.Signature
+		require.NoError(t, sig.Deserialize(signature[:]))
+		require.True(t, sig.VerifyByte(pk, root[:]))
+	}
+
 	t.Run("Successfully sign voluntary exit", func(t *testing.T) {
 		var output bytes.Buffer
 		cmd.ResultPrinter = printer.New(&output)
@@ -19,18 +49,20 @@ func TestAccountVoluntaryExit(t *testing.T) {
 			"account",
 			"voluntary-exit",
 			"--response-type=object",
-			"--seed=847d135b3aecac8ae77c3fdfd46dc5849ad3b5bacd30a1b9082b6ff53c77357e923b12fcdc3d02728fd35c3685de1fe1e9c052c48f0d83566b1b2287cf0e54c3",
-			"--current-fork-version=0x02001020",
+			"--seed=" + seed,
 			"--index=0",
 			"--validator-index=273230",
-			"--validator-public-key=b2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c",
+			"--validator-public-key=" + validatorPubKey,
 			"--epoch=183797",
 			"--network=prater",
 		})
 		err := cmd.RootCmd.Execute()
-		actualOutput := output.String()
-		require.NotNil(t, actualOutput)
 		require.NoError(t, err)
+
+		// the domain is computed from the prater fork schedule, epoch 183797 is of the Capella fork
+		domain, err := signer.DomainFor(core.PraterNetwork, types.DomainVoluntaryExit, 183797)
+		require.NoError(t, err)
+		verifySignedVoluntaryExit(t, output.Bytes(), domain)
 	})
 
 	t.Run("Successfully prepare sign voluntary exit request for key-vault", func(t *testing.T) {
@@ -40,10 +72,9 @@ func TestAccountVoluntaryExit(t *testing.T) {
 			"wallet",
 			"account",
 			"voluntary-exit",
-			"--current-fork-version=0x02001020",
 			"--index=0",
 			"--validator-index=273230",
-			"--validator-public-key=b2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c",
+			"--validator-public-key=" + validatorPubKey,
 			"--epoch=183797",
 			"--network=prater",
 		})
@@ -53,6 +84,31 @@ func TestAccountVoluntaryExit(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	// networks without a fork schedule compute the domain from the current fork version flag
+	devnet := core.Network("voluntary-exit-devnet")
+	require.NoError(t, core.RegisterNetwork(devnet, core.NetworkConfig{
+		GenesisForkVersion:    phase0.Version{0x10, 0x00, 0x00, 0x38},
+		GenesisValidatorsRoot: "83431ec7fcf92cfc44947fc0418e831c25e1d0806590231c439830db7ad54fda",
+	}))
+
+	t.Run("Current fork version is required for networks without a fork schedule", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"voluntary-exit",
+			"--index=1",
+			"--validator-index=1",
+			"--validator-public-key=0x" + validatorPubKey,
+			"--epoch=1",
+			"--network=" + string(devnet),
+		})
+		err := cmd.RootCmd.Execute()
+		require.EqualValues(t, "", output.String())
+		require.EqualError(t, err, "failed to collect voluntary exit flags: failed to retrieve the current fork version flag value, required for networks without a fork schedule: invalid length for current fork version")
+	})
+
 	t.Run("Invalid current fork version length", func(t *testing.T) {
 		var output bytes.Buffer
 		cmd.ResultPrinter = printer.New(&output)
@@ -63,15 +119,78 @@ func TestAccountVoluntaryExit(t *testing.T) {
 			"--current-fork-version=0x020000",
 			"--index=1",
 			"--validator-index=1",
-			"--validator-public-key=0xb2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c",
+			"--validator-public-key=0x" + validatorPubKey,
 			"--epoch=1",
-			"--network=prater",
+			"--network=" + string(devnet),
 		})
 		err := cmd.RootCmd.Execute()
 		actualOutput := output.String()
 		require.EqualValues(t, actualOutput, "")
 		require.Error(t, err)
-		require.EqualError(t, err, "failed to collect voluntary exit flags: failed to retrieve the current fork version flag value: invalid length for current fork version")
+		require.EqualError(t, err, "failed to collect voluntary exit flags: failed to retrieve the current fork version flag value, required for networks without a fork schedule: invalid length for current fork version")
+	})
+
+	t.Run("Successfully sign voluntary exit with the current fork version", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"voluntary-exit",
+			"--response-type=object",
+			"--seed=" + seed,
+			"--current-fork-version=0x40000038",
+			"--index=0",
+			"--validator-index=273230",
+			"--validator-public-key=" + validatorPubKey,
+			"--epoch=1",
+			"--network=" + string(devnet),
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+
+		genesisValidatorsRoot := devnet.GenesisValidatorsRoot()
+		domainBytes, err := types.ComputeDomain(types.DomainVoluntaryExit, []byte{0x40, 0x00, 0x00, 0x38}, genesisValidatorsRoot[:])
+		require.NoError(t, err)
+		var domain phase0.Domain
+		copy(domain[:], domainBytes)
+		verifySignedVoluntaryExit(t, output.Bytes(), domain)
+	})
+
+	t.Run("Current fork version is checked against the fork schedule", func(t *testing.T) {
+		args := func(currentForkVersion string) []string {
+			return []string{
+				"wallet",
+				"account",
+				"voluntary-exit",
+				"--response-type=object",
+				"--seed=" + seed,
+				"--current-fork-version=" + currentForkVersion,
+				"--index=0",
+				"--validator-index=273230",
+				"--validator-public-key=" + validatorPubKey,
+				"--epoch=183797",
+				"--network=prater",
+			}
+		}
+
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs(args("0x020000"))
+		require.EqualError(t, cmd.RootCmd.Execute(), "failed to collect voluntary exit flags: failed to retrieve the current fork version flag value: invalid length for current fork version")
+
+		cmd.RootCmd.SetArgs(args("0x02001020"))
+		require.EqualError(t, cmd.RootCmd.Execute(), "failed to collect voluntary exit flags: current fork version 0x02001020 doesn't match the fork version 0x03001020 of network prater at epoch 183797")
+		require.EqualValues(t, "", output.String())
+
+		// epoch 183797 is of the Capella fork, the flag is reset by the last run
+		domain, err := signer.DomainFor(core.PraterNetwork, types.DomainVoluntaryExit, 183797)
+		require.NoError(t, err)
+		for _, currentForkVersion := range []string{"0x03001020", ""} {
+			output.Reset()
+			cmd.RootCmd.SetArgs(args(currentForkVersion))
+			require.NoError(t, cmd.RootCmd.Execute())
+			verifySignedVoluntaryExit(t, output.Bytes(), domain)
+		}
 	})
 
 	t.Run("Invalid validator public key", func(t *testing.T) {
@@ -81,7 +200,6 @@ func TestAccountVoluntaryExit(t *testing.T) {
 			"wallet",
 			"account",
 			"voluntary-exit",
-			"--current-fork-version=0x02001020",
 			"--index=1",
 			"--validator-index=1",
 			"--validator-public-key=0x2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c",
@@ -104,7 +222,6 @@ func TestAccountVoluntaryExit(t *testing.T) {
 			"voluntary-exit",
 			"--response-type=object",
 			"--seed=",
-			"--current-fork-version=0x02001020",
 			"--index=0",
 			"--validator-index=273230",
 			"--validator-public-key=b2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c",
@@ -117,4 +234,173 @@ func TestAccountVoluntaryExit(t *testing.T) {
 		require.Error(t, err)
 		require.EqualError(t, err, "failed to collect voluntary exit flags: seed flag is required for object response type")
 	})
+
+	// the following tests sign with a vault, whose flags are kept by the command
+	keystoreDir := t.TempDir()
+	passwordFile := filepath.Join(t.TempDir(), "password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+	ks1 := writeKeystore(t, keystoreDir, "2c083f2c8fc923fa2bd32a70ab72b4b46247e8c1f347adc9fd2ac29e6b5b51ad", "m/12381/3600/3/0/0", "password")
+	ks2 := writeKeystore(t, keystoreDir, "175db1c5411459893301c3f2ebe740e5da07db8f17c2df4fa0be6d31a48a4f79", "m/12381/3600/4/0/0", "password")
+
+	verifyExitFiles := func(t *testing.T, output []byte, pubKeys []string, validatorIndices []string) {
+		var files []map[string]string
+		require.NoError(t, json.Unmarshal(output, &files))
+		require.Len(t, files, len(pubKeys))
+
+		// mainnet exits are signed with the Capella fork version from Deneb on (EIP-7044)
+		gvr := core.MainNetwork.GenesisValidatorsRoot()
+		domainBytes, err := types.ComputeDomain(types.DomainVoluntaryExit, []byte{0x03, 0x00, 0x00, 0x00}, gvr[:])
+		require.NoError(t, err)
+		var domain phase0.Domain
+		copy(domain[:], domainBytes)
+
+		for i, file := range files {
+			require.Equal(t, validatorIndices[i], file["validatorIndex"])
+			require.Equal(t, "0x"+pubKeys[i], file["validatorPublicKey"])
+			require.Regexp(t, `^voluntary_exit-`+validatorIndices[i]+`-\d+\.json$`, filepath.Base(file["file"]))
+
+			byts, err := os.ReadFile(file["file"])
+			require.NoError(t, err)
+			var v map[string]interface{}
+			require.NoError(t, json.Unmarshal(byts, &v))
+			require.Equal(t, map[string]interface{}{"epoch": "300000", "validator_index": validatorIndices[i]}, v["message"])
+
+			var signedVoluntaryExit phase0.SignedVoluntaryExit
+			require.NoError(t, json.Unmarshal(byts, &signedVoluntaryExit))
+			root, err := signer.ComputeETHSigningRoot(signedVoluntaryExit.Message, domain)
+			require.NoError(t, err)
+			pubKeyBytes, err := hex.DecodeString(pubKeys[i])
+			require.NoError(t, err)
+			pk := &bls.PublicKey{}
+			require.NoError(t, pk.Deserialize(pubKeyBytes))
+			sig := &bls.Sign{}
+			signature := signedVoluntaryExit.Signature
+			require.NoError(t, sig.Deserialize(signature[:]))
+			require.True(t, sig.VerifyByte(pk, root[:]))
+		}
+	}
+
+	t.Run("Successfully sign voluntary exits with keystores", func(t *testing.T) {
+		outputDir := t.TempDir()
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"voluntary-exit",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--validator-indices=1001,1002",
+			"--validator-public-keys=" + ks1.PubKey + ",0x" + ks2.PubKey,
+			"--output-dir=" + outputDir,
+			"--epoch=300000",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+		verifyExitFiles(t, output.Bytes(), []string{ks1.PubKey, ks2.PubKey}, []string{"1001", "1002"})
+	})
+
+	t.Run("Successfully sign voluntary exits with storage", func(t *testing.T) {
+		store := inmemory.NewInMemStore(core.MainNetwork)
+		options := &eth2keymanager.KeyVaultOptions{}
+		options.SetStorage(store)
+		vault, err := eth2keymanager.NewKeyVault(options)
+		require.NoError(t, err)
+		_, err = vault.ImportKeystoreDir(keystoreDir, "password")
+		require.NoError(t, err)
+		storage, err := store.MarshalJSON()
+		require.NoError(t, err)
+
+		outputDir := t.TempDir()
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"voluntary-exit",
+			"--keystore-dir=",
+			"--password-file=",
+			"--storage=" + hex.EncodeToString(storage),
+			"--validator-indices=1002",
+			"--validator-public-keys=" + ks2.PubKey,
+			"--output-dir=" + outputDir,
+			"--epoch=300000",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+		verifyExitFiles(t, output.Bytes(), []string{ks2.PubKey}, []string{"1002"})
+	})
+
+	t.Run("Successfully sign voluntary exits with bolt database", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "vault.db")
+		store, err := bolt.NewBoltStore(dbPath, core.MainNetwork)
+		require.NoError(t, err)
+		options := &eth2keymanager.KeyVaultOptions{}
+		options.SetStorage(store)
+		vault, err := eth2keymanager.NewKeyVault(options)
+		require.NoError(t, err)
+		_, err = vault.ImportKeystoreDir(keystoreDir, "password")
+		require.NoError(t, err)
+		require.NoError(t, store.Close())
+
+		outputDir := t.TempDir()
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"voluntary-exit",
+			"--storage=",
+			"--db-path=" + dbPath,
+			"--validator-indices=1001",
+			"--validator-public-keys=" + ks1.PubKey,
+			"--output-dir=" + outputDir,
+			"--epoch=300000",
+			"--network=mainnet",
+		})
+		require.NoError(t, cmd.RootCmd.Execute())
+		verifyExitFiles(t, output.Bytes(), []string{ks1.PubKey}, []string{"1001"})
+	})
+
+	t.Run("Fail sign voluntary exits of unknown validator", func(t *testing.T) {
+		outputDir := t.TempDir()
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"voluntary-exit",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--db-path=",
+			"--validator-indices=1001,1003",
+			"--validator-public-keys=" + ks1.PubKey + ",b2dc1daa8c9cd104d4503028639e41a41e4f06ee5cc90ebfaeab3c41f43a148ce9afa4ebd1b8be3f54e4d6c15e870c7c",
+			"--output-dir=" + outputDir,
+			"--epoch=300000",
+			"--network=mainnet",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "failed to sign voluntary exit of validator 1003: account not found")
+		entries, err := os.ReadDir(outputDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("Fail sign voluntary exits with several vaults", func(t *testing.T) {
+		var output bytes.Buffer
+		cmd.ResultPrinter = printer.New(&output)
+		cmd.RootCmd.SetArgs([]string{
+			"wallet",
+			"account",
+			"voluntary-exit",
+			"--keystore-dir=" + keystoreDir,
+			"--password-file=" + passwordFile,
+			"--db-path=" + filepath.Join(t.TempDir(), "vault.db"),
+			"--validator-indices=1001",
+			"--validator-public-keys=" + ks1.PubKey,
+			"--output-dir=" + t.TempDir(),
+			"--epoch=300000",
+			"--network=mainnet",
+		})
+		require.EqualError(t, cmd.RootCmd.Execute(), "failed to open vault: exactly one of keystore-dir, storage or db-path must be set")
+	})
 }
@@ -0,0 +1,35 @@
+package account
+
+import (
+	"github.com/spf13/cobra"
+
+	rootcmd "github.com/ssvlabs/eth2-key-manager/cli/cmd"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/flag"
+	"github.com/ssvlabs/eth2-key-manager/cli/cmd/wallet/cmd/account/handler"
+)
+
+// withdrawalRequestCmd represents the withdrawal request account command.
+var withdrawalRequestCmd = &cobra.Command{
+	Use:   "withdrawal-request",
+	Short: "Builds an unsigned withdrawal request transaction",
+	Long:  `This command builds the unsigned EIP-7002 withdrawal request transaction, a full exit or a partial withdrawal, of an account of EIP-2335 keystores, a storage or a bolt database, to be signed offline by the withdrawal address.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler := handler.New(rootcmd.ResultPrinter)
+		return handler.WithdrawalRequest(cmd, args)
+	},
+}
+
+func init() {
+	// Define flags for the command.
+	rootcmd.AddNetworkFlag(withdrawalRequestCmd)
+	rootcmd.AddRequestNonceFlag(withdrawalRequestCmd)
+	rootcmd.AddMaxFeePerGasFlag(withdrawalRequestCmd)
+	rootcmd.AddMaxPriorityFeePerGasFlag(withdrawalRequestCmd)
+	flag.AddVaultFlags(withdrawalRequestCmd)
+	flag.AddRequestValidatorPublicKeyFlag(withdrawalRequestCmd)
+	flag.AddRequestWithdrawalCredentialsFlag(withdrawalRequestCmd)
+	flag.AddWithdrawalAmountFlag(withdrawalRequestCmd)
+	flag.AddFeeFlag(withdrawalRequestCmd)
+
+	Command.AddCommand(withdrawalRequestCmd)
+}
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// DepositDataOptions are the withdrawal credentials and amount of a deposit.
+// Without a withdrawal address the deposit has BLS (0x00) withdrawal credentials, without an amount it deposits 32 ETH.
+type DepositDataOptions struct {
+	WithdrawalAddress *bellatrix.ExecutionAddress
+	Compounding       bool
+	Amount            phase0.Gwei
+}
+
+// DepositDataOption sets an option of the deposit data
+type DepositDataOption func(*DepositDataOptions)
+
+// WithWithdrawalAddress sets execution address (0x01) withdrawal credentials
+func WithWithdrawalAddress(address bellatrix.ExecutionAddress) DepositDataOption {
+	return func(options *DepositDataOptions) {
+		options.WithdrawalAddress = &address
+	}
+}
+
+// WithCompounding sets compounding (0x02) withdrawal credentials, it requires a withdrawal address
+func WithCompounding() DepositDataOption {
+	return func(options *DepositDataOptions) {
+		options.Compounding = true
+	}
+}
+
+// WithDepositAmount sets the deposit amount
+func WithDepositAmount(amount phase0.Gwei) DepositDataOption {
+	return func(options *DepositDataOptions) {
+		options.Amount = amount
+	}
+}
+
+// NewDepositDataOptions applies the given options
+func NewDepositDataOptions(opts ...DepositDataOption) *DepositDataOptions {
+	ret := &DepositDataOptions{}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return ret
+}
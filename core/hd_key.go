@@ -1,20 +1,50 @@
 package core
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"reflect"
+	"sync"
 
 	"github.com/herumi/bls-eth-go-binary/bls"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/encryptor"
 )
 
+// KeyDecryptor decrypts the encrypted secret of a HDKey, it's provided by the storage which encrypted it
+type KeyDecryptor func(crypto map[string]interface{}) ([]byte, error)
+
+// NewKeyDecryptor returns a KeyDecryptor decrypting with the given encryptor and password
+func NewKeyDecryptor(encryptor encryptor.Encryptor, password []byte) KeyDecryptor {
+	return func(crypto map[string]interface{}) ([]byte, error) {
+		if encryptor == nil {
+			return nil, errors.New("account is encrypted but no encryptor was set")
+		}
+		return encryptor.Decrypt(crypto, string(password))
+	}
+}
+
 // HDKey is a derived key from MasterDerivableKey which is able to sign messages, return thee public key and more.
+// A key opened from an encrypted storage holds only the encrypted secret until it's first used,
+// its key cache then decides how long the decrypted secret is kept.
 type HDKey struct {
-	id      uuid.UUID
+	id     uuid.UUID
+	path   string
+	pubKey *bls.PublicKey
+
+	lock    sync.Mutex
 	privKey *bls.SecretKey
-	path    string
+	crypto  map[string]interface{}
+	decrypt KeyDecryptor
+	cache   *KeyCache
+
+	// cryptoEncryptor and cryptoPassword encrypted crypto, it's marshalled again as is with the same ones
+	cryptoEncryptor encryptor.Encryptor
+	cryptoPassword  []byte
 }
 
 // NewHDKeyFromPrivateKey is the constructor of HDKey
@@ -27,21 +57,67 @@ func NewHDKeyFromPrivateKey(priv []byte, path string) (*HDKey, error) {
 	return &HDKey{
 		id:      uuid.New(),
 		privKey: sk,
+		pubKey:  sk.GetPublicKey(),
 		path:    path,
 	}, nil
 }
 
-// MarshalJSON is the custom JSON marshaler
+// MarshalJSON is the custom JSON marshaler, the secret key is never part of it.
+// Storages persist the secret with MarshalSecretJSON.
 func (key *HDKey) MarshalJSON() ([]byte, error) {
 	data := make(map[string]interface{})
 
 	data["id"] = key.id
-	data["privKey"] = hex.EncodeToString(key.privKey.Serialize())
+	data["pubKey"] = hex.EncodeToString(key.PublicKey().Serialize())
 	data["path"] = key.path
 
 	return json.Marshal(data)
 }
 
+// MarshalSecretJSON marshals the key with its secret, encrypted with the given encryptor.
+// The secret is written in plain text if no encryptor is given.
+// An encrypted secret of the same encryptor and password is written as is, without decrypting it,
+// so it's only decrypted to change them.
+func (key *HDKey) MarshalSecretJSON(encryptor encryptor.Encryptor, password []byte) ([]byte, error) {
+	data := make(map[string]interface{})
+
+	data["id"] = key.id
+	data["path"] = key.path
+
+	key.lock.Lock()
+	defer key.lock.Unlock()
+	if encryptor != nil && key.crypto != nil && key.encryptedWith(encryptor, password) {
+		data["crypto"] = key.crypto
+		data["pubKey"] = hex.EncodeToString(key.PublicKey().Serialize())
+		return json.Marshal(data)
+	}
+
+	sk, cached, err := key.secretKey()
+	if err != nil {
+		return nil, err
+	}
+	if !cached {
+		defer zeroize(sk)
+	}
+
+	if encryptor != nil {
+		secret := sk.Serialize()
+		crypto, err := encryptor.Encrypt(secret, string(password))
+		for i := range secret {
+			secret[i] = 0
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encrypt key")
+		}
+		data["crypto"] = crypto
+		data["pubKey"] = hex.EncodeToString(key.PublicKey().Serialize())
+	} else {
+		data["privKey"] = hex.EncodeToString(sk.Serialize())
+	}
+
+	return json.Marshal(data)
+}
+
 // UnmarshalJSON is the custom JSON unmarshaler
 func (key *HDKey) UnmarshalJSON(data []byte) error {
 	// parse
@@ -71,21 +147,196 @@ func (key *HDKey) UnmarshalJSON(data []byte) error {
 		if err := key.privKey.SetHexString(val.(string)); err != nil {
 			return err
 		}
+		key.pubKey = key.privKey.GetPublicKey()
+		return nil
+	}
+
+	// keys without a plain secret are either encrypted or public only
+	if val, exists := v["pubKey"]; exists {
+		byts, err := hex.DecodeString(val.(string))
+		if err != nil {
+			return err
+		}
+		key.pubKey = &bls.PublicKey{}
+		if err := key.pubKey.Deserialize(byts); err != nil {
+			return err
+		}
 	} else {
 		return errors.New("could not find var: privKey")
 	}
 
+	if val, exists := v["crypto"]; exists {
+		crypto, ok := val.(map[string]interface{})
+		if !ok {
+			return errors.New("invalid var: crypto")
+		}
+		key.crypto = crypto
+	}
+
 	return nil
 }
 
+// SetDecryptor sets the decryptor used to decrypt the secret when it's used, and the cache keeping it once decrypted.
+// Without a cache the decrypted secret is kept for the life of the key.
+func (key *HDKey) SetDecryptor(decrypt KeyDecryptor, cache *KeyCache) {
+	key.lock.Lock()
+	defer key.lock.Unlock()
+	key.decrypt = decrypt
+	key.cache = cache
+}
+
+// SetEncryptor records the encryptor and password which encrypted the secret of the key,
+// MarshalSecretJSON then writes the encrypted secret as is when it's given the same ones.
+func (key *HDKey) SetEncryptor(encryptor encryptor.Encryptor, password []byte) {
+	key.lock.Lock()
+	defer key.lock.Unlock()
+	if key.crypto != nil {
+		key.cryptoEncryptor = encryptor
+		key.cryptoPassword = append([]byte{}, password...)
+	}
+}
+
+// encryptedWith returns true if the encrypted secret was encrypted with the given encryptor and password.
+// The key lock must be held.
+func (key *HDKey) encryptedWith(encryptor encryptor.Encryptor, password []byte) bool {
+	if key.cryptoEncryptor == nil || !bytes.Equal(key.cryptoPassword, password) {
+		return false
+	}
+	// encryptors of a type which can't be compared are never the same
+	if reflect.TypeOf(encryptor) != reflect.TypeOf(key.cryptoEncryptor) || !reflect.TypeOf(encryptor).Comparable() {
+		return false
+	}
+	return encryptor == key.cryptoEncryptor
+}
+
 // PublicKey returns the public key
 func (key *HDKey) PublicKey() *bls.PublicKey {
-	return key.privKey.GetPublicKey()
+	if key.pubKey == nil {
+		return key.privKey.GetPublicKey()
+	}
+	return key.pubKey
+}
+
+// SecretKey returns a copy of the secret key, decrypting it if needed
+func (key *HDKey) SecretKey() (*bls.SecretKey, error) {
+	key.lock.Lock()
+	defer key.lock.Unlock()
+
+	sk, cached, err := key.secretKey()
+	if err != nil {
+		return nil, err
+	}
+	if !cached {
+		return sk, nil
+	}
+	ret := *sk
+	return &ret, nil
 }
 
 // Sign signs the given data
 func (key *HDKey) Sign(data []byte) ([]byte, error) {
-	return key.privKey.SignByte(data).Serialize(), nil
+	key.lock.Lock()
+	defer key.lock.Unlock()
+
+	sk, cached, err := key.secretKey()
+	if err != nil {
+		return nil, err
+	}
+	if !cached {
+		defer zeroize(sk)
+	}
+	return sk.SignByte(data).Serialize(), nil
+}
+
+// secretKey returns the secret key, decrypting it if needed. Returns false if it's not kept by the key.
+// The key lock must be held.
+func (key *HDKey) secretKey() (*bls.SecretKey, bool, error) {
+	if key.crypto != nil && key.cache != nil && key.cache.Locked() {
+		return nil, false, ErrKeyVaultLocked
+	}
+	if key.privKey != nil {
+		return key.privKey, true, nil
+	}
+	if key.crypto == nil {
+		return nil, false, errors.New("key has no secret")
+	}
+	if key.decrypt == nil {
+		return nil, false, errors.New("key is encrypted but no decryptor was set")
+	}
+
+	sk, err := key.decryptSecret(key.decrypt)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if key.cache == nil {
+		key.privKey = sk
+		return sk, true, nil
+	}
+	keep, err := key.cache.add(key)
+	if err != nil {
+		zeroize(sk)
+		return nil, false, err
+	}
+	if keep {
+		key.privKey = sk
+	}
+	return sk, keep, nil
+}
+
+// CheckDecryptor checks the given decryptor decrypts the encrypted secret, which is zeroized right after.
+// The key is left as is, returns false if it has no encrypted secret.
+func (key *HDKey) CheckDecryptor(decrypt KeyDecryptor) (bool, error) {
+	key.lock.Lock()
+	defer key.lock.Unlock()
+
+	if key.crypto == nil {
+		return false, nil
+	}
+	sk, err := key.decryptSecret(decrypt)
+	if err != nil {
+		return true, err
+	}
+	zeroize(sk)
+	return true, nil
+}
+
+// decryptSecret decrypts the encrypted secret with the given decryptor, checking it matches the public key.
+// The key lock must be held.
+func (key *HDKey) decryptSecret(decrypt KeyDecryptor) (*bls.SecretKey, error) {
+	byts, err := decrypt(key.crypto)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt key")
+	}
+	sk := &bls.SecretKey{}
+	err = sk.Deserialize(byts)
+	for i := range byts {
+		byts[i] = 0
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize decrypted key")
+	}
+	if !sk.GetPublicKey().IsEqual(key.pubKey) {
+		zeroize(sk)
+		return nil, errors.New("decrypted key doesn't match the public key")
+	}
+	return sk, nil
+}
+
+// zeroize wipes the decrypted secret, the key is decrypted again on its next use.
+// Keys without an encrypted secret are left as is.
+func (key *HDKey) zeroize() {
+	key.lock.Lock()
+	defer key.lock.Unlock()
+	if key.privKey != nil && key.crypto != nil {
+		zeroize(key.privKey)
+		key.privKey = nil
+	}
+}
+
+// zeroize overwrites the secret key in memory
+func zeroize(sk *bls.SecretKey) {
+	*sk = bls.SecretKey{}
 }
 
 // Path returns path
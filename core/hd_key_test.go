@@ -11,6 +11,8 @@ import (
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
 )
 
 func _byteArray(input string) []byte {
@@ -72,9 +74,14 @@ func TestMarshalingHDKey(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			// marshal and unmarshal
+			// the secret is only marshaled explicitly
 			byts, err := json.Marshal(hdKey)
 			require.NoError(t, err)
+			require.NotContains(t, string(byts), "privKey")
+
+			// marshal and unmarshal
+			byts, err = hdKey.MarshalSecretJSON(nil, nil)
+			require.NoError(t, err)
 
 			newKey := &HDKey{}
 			err = json.Unmarshal(byts, newKey)
@@ -89,6 +96,80 @@ func TestMarshalingHDKey(t *testing.T) {
 	}
 }
 
+func TestEncryptedHDKey(t *testing.T) {
+	require.NoError(t, InitBLS())
+	key, err := MasterKeyFromSeed(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff"), MainNetwork)
+	require.NoError(t, err)
+	hdKey, err := key.Derive("/0/0/0")
+	require.NoError(t, err)
+	expected, err := hdKey.Sign([]byte("data"))
+	require.NoError(t, err)
+
+	enc := keystorev4.New(keystorev4.WithCipher("pbkdf2"))
+	byts, err := hdKey.MarshalSecretJSON(enc, []byte("password"))
+	require.NoError(t, err)
+	require.NotContains(t, string(byts), "privKey")
+
+	newKey := &HDKey{}
+	require.NoError(t, json.Unmarshal(byts, newKey))
+	require.Nil(t, newKey.privKey)
+	require.Equal(t, hdKey.PublicKey().Serialize(), newKey.PublicKey().Serialize())
+
+	_, err = newKey.Sign([]byte("data"))
+	require.EqualError(t, err, "key is encrypted but no decryptor was set")
+
+	newKey.SetDecryptor(NewKeyDecryptor(nil, nil), nil)
+	_, err = newKey.Sign([]byte("data"))
+	require.EqualError(t, err, "failed to decrypt key: account is encrypted but no encryptor was set")
+
+	newKey.SetDecryptor(NewKeyDecryptor(enc, []byte("wrong")), nil)
+	_, err = newKey.Sign([]byte("data"))
+	require.EqualError(t, err, "failed to decrypt key: invalid checksum")
+
+	// decrypted on first use
+	newKey.SetDecryptor(NewKeyDecryptor(enc, []byte("password")), nil)
+	sig, err := newKey.Sign([]byte("data"))
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+	require.NotNil(t, newKey.privKey)
+}
+
+func TestMarshalingEncryptedHDKeyWithUnchangedEncryptor(t *testing.T) {
+	cache := NewKeyCache()
+	key := encryptedTestKey(t, cache)
+	enc := keystorev4.New(keystorev4.WithCipher("pbkdf2"))
+	key.SetEncryptor(enc, []byte("password"))
+	crypto := key.crypto
+
+	// the encrypted secret is reused as is, without decrypting it
+	cache.Lock()
+	byts, err := key.MarshalSecretJSON(enc, []byte("password"))
+	require.NoError(t, err)
+	var v map[string]interface{}
+	require.NoError(t, json.Unmarshal(byts, &v))
+	expected, err := json.Marshal(crypto)
+	require.NoError(t, err)
+	actual, err := json.Marshal(v["crypto"])
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(actual))
+
+	// another password or encryptor needs the secret
+	_, err = key.MarshalSecretJSON(enc, []byte("new password"))
+	require.ErrorIs(t, err, ErrKeyVaultLocked)
+	_, err = key.MarshalSecretJSON(keystorev4.New(keystorev4.WithCipher("pbkdf2")), []byte("password"))
+	require.ErrorIs(t, err, ErrKeyVaultLocked)
+
+	cache.Unlock()
+	byts, err = key.MarshalSecretJSON(enc, []byte("new password"))
+	require.NoError(t, err)
+	newKey := &HDKey{}
+	require.NoError(t, json.Unmarshal(byts, newKey))
+	newKey.SetDecryptor(NewKeyDecryptor(enc, []byte("new password")), nil)
+	sk, err := newKey.SecretKey()
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey().Serialize(), sk.GetPublicKey().Serialize())
+}
+
 func TestDerivableKeyRelativePathDerivation(t *testing.T) {
 	if err := InitBLS(); err != nil {
 		os.Exit(1)
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrKeyVaultLocked is returned when an encrypted key is used while its key cache is locked
+var ErrKeyVaultLocked = errors.New("key vault is locked")
+
+const (
+	// KeepDecryptedKeys keeps decrypted keys until the key cache is locked
+	KeepDecryptedKeys time.Duration = 0
+	// NoKeyCaching decrypts keys for every use, zeroizing them right after
+	NoKeyCaching time.Duration = -1
+)
+
+// KeyCache holds the secrets of encrypted keys once they are decrypted, zeroizing them when their TTL expires.
+// A locked cache zeroizes every secret it holds and refuses to decrypt keys until it's unlocked.
+type KeyCache struct {
+	lock   sync.Mutex
+	ttl    time.Duration
+	locked bool
+	keys   map[*HDKey]*time.Timer
+}
+
+// NewKeyCache is the constructor of KeyCache, keys are kept until it's locked
+func NewKeyCache() *KeyCache {
+	return &KeyCache{
+		ttl:  KeepDecryptedKeys,
+		keys: make(map[*HDKey]*time.Timer),
+	}
+}
+
+// SetTTL sets how long decrypted keys are kept, it applies to keys decrypted from now on
+func (cache *KeyCache) SetTTL(ttl time.Duration) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	cache.ttl = ttl
+}
+
+// Lock zeroizes every decrypted key, keys can't be decrypted until the cache is unlocked
+func (cache *KeyCache) Lock() {
+	cache.lock.Lock()
+	cache.locked = true
+	keys := cache.keys
+	cache.keys = make(map[*HDKey]*time.Timer)
+	for _, timer := range keys {
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+	cache.lock.Unlock()
+
+	for key := range keys {
+		key.zeroize()
+	}
+}
+
+// Unlock allows decrypting keys again
+func (cache *KeyCache) Unlock() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	cache.locked = false
+}
+
+// Locked returns true if the cache is locked
+func (cache *KeyCache) Locked() bool {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.locked
+}
+
+// add registers a decrypted key, returns false if it shouldn't be kept
+func (cache *KeyCache) add(key *HDKey) (bool, error) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.locked {
+		return false, ErrKeyVaultLocked
+	}
+	if cache.ttl < 0 {
+		return false, nil
+	}
+
+	var timer *time.Timer
+	if cache.ttl > 0 {
+		// the timer is read by evict under the cache lock, once it's assigned and stored
+		timer = time.AfterFunc(cache.ttl, func() {
+			cache.evict(key, &timer)
+		})
+	}
+	cache.keys[key] = timer
+	return true, nil
+}
+
+// evict zeroizes a key once its TTL expired, unless it was already evicted
+func (cache *KeyCache) evict(key *HDKey, timer **time.Timer) {
+	cache.lock.Lock()
+	if current, exists := cache.keys[key]; !exists || current != *timer {
+		cache.lock.Unlock()
+		return
+	}
+	delete(cache.keys, key)
+	cache.lock.Unlock()
+
+	key.zeroize()
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+)
+
+// encryptedTestKey returns an encrypted copy of a derived key, decrypted through the given cache
+func encryptedTestKey(t *testing.T, cache *KeyCache) *HDKey {
+	require.NoError(t, InitBLS())
+	key, err := MasterKeyFromSeed(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff"), MainNetwork)
+	require.NoError(t, err)
+	hdKey, err := key.Derive("/0/0/0")
+	require.NoError(t, err)
+
+	enc := keystorev4.New(keystorev4.WithCipher("pbkdf2"))
+	byts, err := hdKey.MarshalSecretJSON(enc, []byte("password"))
+	require.NoError(t, err)
+	ret := &HDKey{}
+	require.NoError(t, json.Unmarshal(byts, ret))
+	ret.SetDecryptor(NewKeyDecryptor(enc, []byte("password")), cache)
+	return ret
+}
+
+func TestKeyCacheTTL(t *testing.T) {
+	cache := NewKeyCache()
+	cache.SetTTL(50 * time.Millisecond)
+	key := encryptedTestKey(t, cache)
+
+	_, err := key.Sign([]byte("data"))
+	require.NoError(t, err)
+	key.lock.Lock()
+	require.NotNil(t, key.privKey)
+	key.lock.Unlock()
+
+	// zeroized once evicted, decrypted again on the next use
+	require.Eventually(t, func() bool {
+		key.lock.Lock()
+		defer key.lock.Unlock()
+		return key.privKey == nil
+	}, time.Second, 10*time.Millisecond)
+	_, err = key.Sign([]byte("data"))
+	require.NoError(t, err)
+}
+
+func TestKeyCacheTinyTTL(t *testing.T) {
+	cache := NewKeyCache()
+	cache.SetTTL(time.Nanosecond)
+	key := encryptedTestKey(t, cache)
+
+	// evicted even if the TTL expires before add returns
+	for i := 0; i < 10; i++ {
+		_, err := key.Sign([]byte("data"))
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			key.lock.Lock()
+			defer key.lock.Unlock()
+			return key.privKey == nil
+		}, time.Second, time.Millisecond)
+	}
+}
+
+func TestKeyCacheNoCaching(t *testing.T) {
+	cache := NewKeyCache()
+	cache.SetTTL(NoKeyCaching)
+	key := encryptedTestKey(t, cache)
+
+	sig, err := key.Sign([]byte("data"))
+	require.NoError(t, err)
+	require.NotEmpty(t, sig)
+	require.Nil(t, key.privKey)
+}
+
+func TestKeyCacheLock(t *testing.T) {
+	cache := NewKeyCache()
+	key := encryptedTestKey(t, cache)
+
+	expected, err := key.Sign([]byte("data"))
+	require.NoError(t, err)
+	sk := key.privKey
+
+	cache.Lock()
+	require.True(t, cache.Locked())
+	require.Nil(t, key.privKey)
+	require.True(t, sk.IsZero())
+	_, err = key.Sign([]byte("data"))
+	require.EqualError(t, err, "key vault is locked")
+	_, err = key.SecretKey()
+	require.ErrorIs(t, err, ErrKeyVaultLocked)
+
+	cache.Unlock()
+	sig, err := key.Sign([]byte("data"))
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+}
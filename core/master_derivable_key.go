@@ -83,6 +83,7 @@ func (master *MasterDerivableKey) Derive(relativePath string) (*HDKey, error) {
 	return &HDKey{
 		id:      uuid.New(),
 		privKey: sk,
+		pubKey:  sk.GetPublicKey(),
 		path:    path,
 	}, nil
 }
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadNetworkConfig parses a standard consensus layer config.yaml, e.g. the one of a kurtosis devnet.
+// The genesis validators root isn't part of the config and comes from the genesis state,
+// a zero genesis time falls back to MIN_GENESIS_TIME + GENESIS_DELAY.
+// The execution layer chain id is the DEPOSIT_CHAIN_ID of the config.
+func LoadNetworkConfig(configYAML []byte, genesisValidatorsRoot phase0.Root, genesisTime uint64) (string, NetworkConfig, error) {
+	values, err := parseConfigValues(configYAML)
+	if err != nil {
+		return "", NetworkConfig{}, err
+	}
+
+	cfg := NetworkConfig{
+		GenesisValidatorsRoot:  hex.EncodeToString(genesisValidatorsRoot[:]),
+		DepositContractAddress: values["DEPOSIT_CONTRACT_ADDRESS"],
+		MinGenesisTime:         genesisTime,
+	}
+
+	forkVersion, found := values["GENESIS_FORK_VERSION"]
+	if !found {
+		return "", NetworkConfig{}, errors.New("GENESIS_FORK_VERSION is missing")
+	}
+	if cfg.GenesisForkVersion, err = parseVersion(forkVersion); err != nil {
+		return "", NetworkConfig{}, errors.Wrap(err, "invalid GENESIS_FORK_VERSION")
+	}
+
+	if cfg.SecondsPerSlot, err = parseUint(values, "SECONDS_PER_SLOT"); err != nil {
+		return "", NetworkConfig{}, err
+	}
+	if cfg.SlotsPerEpoch, err = parseUint(values, "SLOTS_PER_EPOCH"); err != nil {
+		return "", NetworkConfig{}, err
+	}
+	if cfg.ChainID, err = parseUint(values, "DEPOSIT_CHAIN_ID"); err != nil {
+		return "", NetworkConfig{}, err
+	}
+	if cfg.MinGenesisTime == 0 {
+		minGenesisTime, err := parseUint(values, "MIN_GENESIS_TIME")
+		if err != nil {
+			return "", NetworkConfig{}, err
+		}
+		genesisDelay, err := parseUint(values, "GENESIS_DELAY")
+		if err != nil {
+			return "", NetworkConfig{}, err
+		}
+		cfg.MinGenesisTime = minGenesisTime + genesisDelay
+	}
+
+	if cfg.Forks, err = parseForks(values); err != nil {
+		return "", NetworkConfig{}, err
+	}
+
+	return values["CONFIG_NAME"], cfg, nil
+}
+
+// configForks are the config.yaml key prefixes of the supported forks, in order
+var configForks = []struct {
+	prefix      string
+	dataVersion spec.DataVersion
+}{
+	{prefix: "ALTAIR", dataVersion: spec.DataVersionAltair},
+	{prefix: "BELLATRIX", dataVersion: spec.DataVersionBellatrix},
+	{prefix: "CAPELLA", dataVersion: spec.DataVersionCapella},
+	{prefix: "DENEB", dataVersion: spec.DataVersionDeneb},
+	{prefix: "ELECTRA", dataVersion: spec.DataVersionElectra},
+}
+
+// parseForks returns the scheduled forks, forks at FAR_FUTURE_EPOCH or missing from the config are not scheduled
+func parseForks(values map[string]string) ([]Fork, error) {
+	ret := make([]Fork, 0, len(configForks))
+	for _, configFork := range configForks {
+		epochValue, found := values[configFork.prefix+"_FORK_EPOCH"]
+		if !found {
+			continue
+		}
+		epoch, err := strconv.ParseUint(epochValue, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s_FORK_EPOCH", configFork.prefix)
+		}
+		if epoch == math.MaxUint64 {
+			continue
+		}
+		version, err := parseVersion(values[configFork.prefix+"_FORK_VERSION"])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s_FORK_VERSION", configFork.prefix)
+		}
+		ret = append(ret, Fork{DataVersion: configFork.dataVersion, Version: version, Epoch: phase0.Epoch(epoch)})
+	}
+	return ret, nil
+}
+
+// RegisterNetworkFromConfig loads a config.yaml with LoadNetworkConfig and registers it.
+// The network is named after CONFIG_NAME unless a name is given.
+func RegisterNetworkFromConfig(name Network, configYAML []byte, genesisValidatorsRoot phase0.Root, genesisTime uint64) (Network, error) {
+	configName, cfg, err := LoadNetworkConfig(configYAML, genesisValidatorsRoot, genesisTime)
+	if err != nil {
+		return "", err
+	}
+	if len(name) == 0 {
+		name = Network(configName)
+	}
+	if err := RegisterNetwork(name, cfg); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// parseConfigValues returns the top level scalar values of a config.yaml as written, lists like BLOB_SCHEDULE are skipped
+func parseConfigValues(configYAML []byte) (map[string]string, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(configYAML, doc); err != nil {
+		return nil, errors.Wrap(err, "failed to parse config")
+	}
+	if len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("config must be a mapping")
+	}
+
+	values := make(map[string]string)
+	content := doc.Content[0].Content
+	for i := 0; i+1 < len(content); i += 2 {
+		if content[i+1].Kind == yaml.ScalarNode {
+			values[content[i].Value] = content[i+1].Value
+		}
+	}
+	return values, nil
+}
+
+func parseVersion(value string) (phase0.Version, error) {
+	var ret phase0.Version
+	byts, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return ret, err
+	}
+	if len(byts) != len(ret) {
+		return ret, errors.Errorf("version must be %d bytes", len(ret))
+	}
+	copy(ret[:], byts)
+	return ret, nil
+}
+
+// parseUint parses an optional decimal value, missing values are 0
+func parseUint(values map[string]string, key string) (uint64, error) {
+	value, found := values[key]
+	if !found {
+		return 0, nil
+	}
+	ret, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s", key)
+	}
+	return ret, nil
+}
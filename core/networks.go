@@ -3,9 +3,13 @@ package core
 import (
 	"encoding/hex"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -13,11 +17,25 @@ import (
 type Network string
 
 // NetworkConfig stores configuration specific to an Ethereum network.
+// Zero SecondsPerSlot and SlotsPerEpoch fall back to DefaultSecondsPerSlot and DefaultSlotsPerEpoch.
 type NetworkConfig struct {
 	GenesisForkVersion     phase0.Version
 	GenesisValidatorsRoot  string
 	DepositContractAddress string
 	MinGenesisTime         uint64
+	SecondsPerSlot         uint64
+	SlotsPerEpoch          uint64
+	// ChainID is the execution layer chain id, transactions can't be built for networks without it
+	ChainID uint64
+	// Forks is the fork schedule after genesis, sorted by epoch
+	Forks []Fork
+}
+
+// Fork is a scheduled fork of a network
+type Fork struct {
+	DataVersion spec.DataVersion
+	Version     phase0.Version
+	Epoch       phase0.Epoch
 }
 
 // Available networks.
@@ -44,46 +62,131 @@ var networks = map[Network]NetworkConfig{
 		GenesisForkVersion:     phase0.Version{0x00, 0x00, 0x10, 0x20},
 		GenesisValidatorsRoot:  "043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb",
 		DepositContractAddress: "0xff50ed3d0ec03ac01d4c79aad74928bff48a7b2b",
+		ChainID:                5,
 		MinGenesisTime:         1616508000,
+		Forks: []Fork{
+			{DataVersion: spec.DataVersionAltair, Version: phase0.Version{0x01, 0x00, 0x10, 0x20}, Epoch: 36660},
+			{DataVersion: spec.DataVersionBellatrix, Version: phase0.Version{0x02, 0x00, 0x10, 0x20}, Epoch: 112260},
+			{DataVersion: spec.DataVersionCapella, Version: phase0.Version{0x03, 0x00, 0x10, 0x20}, Epoch: 162304},
+			{DataVersion: spec.DataVersionDeneb, Version: phase0.Version{0x04, 0x00, 0x10, 0x20}, Epoch: 231680},
+		},
 	},
 	SepoliaNetwork: {
 		GenesisForkVersion:     phase0.Version{0x90, 0x00, 0x00, 0x69},
 		GenesisValidatorsRoot:  "d8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078",
 		DepositContractAddress: "0x4242424242424242424242424242424242424242",
+		ChainID:                11155111,
 		MinGenesisTime:         1655733600,
+		Forks: []Fork{
+			{DataVersion: spec.DataVersionAltair, Version: phase0.Version{0x90, 0x00, 0x00, 0x70}, Epoch: 50},
+			{DataVersion: spec.DataVersionBellatrix, Version: phase0.Version{0x90, 0x00, 0x00, 0x71}, Epoch: 100},
+			{DataVersion: spec.DataVersionCapella, Version: phase0.Version{0x90, 0x00, 0x00, 0x72}, Epoch: 56832},
+			{DataVersion: spec.DataVersionDeneb, Version: phase0.Version{0x90, 0x00, 0x00, 0x73}, Epoch: 132608},
+			{DataVersion: spec.DataVersionElectra, Version: phase0.Version{0x90, 0x00, 0x00, 0x74}, Epoch: 222464},
+		},
 	},
 	HoleskyNetwork: {
 		GenesisForkVersion:     phase0.Version{0x01, 0x01, 0x70, 0x00},
 		GenesisValidatorsRoot:  "9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1",
 		DepositContractAddress: "0x4242424242424242424242424242424242424242",
+		ChainID:                17000,
 		MinGenesisTime:         1695902400,
+		Forks: []Fork{
+			{DataVersion: spec.DataVersionAltair, Version: phase0.Version{0x02, 0x01, 0x70, 0x00}, Epoch: 0},
+			{DataVersion: spec.DataVersionBellatrix, Version: phase0.Version{0x03, 0x01, 0x70, 0x00}, Epoch: 0},
+			{DataVersion: spec.DataVersionCapella, Version: phase0.Version{0x04, 0x01, 0x70, 0x00}, Epoch: 256},
+			{DataVersion: spec.DataVersionDeneb, Version: phase0.Version{0x05, 0x01, 0x70, 0x00}, Epoch: 29696},
+			{DataVersion: spec.DataVersionElectra, Version: phase0.Version{0x06, 0x01, 0x70, 0x00}, Epoch: 115968},
+		},
 	},
 	HoodiNetwork: {
 		GenesisForkVersion:     phase0.Version{0x10, 0x00, 0x09, 0x10},
 		GenesisValidatorsRoot:  "212f13fc4df078b6cb7db228f1c8307566dcecf900867401a92023d7ba99cb5f",
 		DepositContractAddress: "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+		ChainID:                560048,
 		MinGenesisTime:         1742213400,
+		Forks: []Fork{
+			{DataVersion: spec.DataVersionAltair, Version: phase0.Version{0x20, 0x00, 0x09, 0x10}, Epoch: 0},
+			{DataVersion: spec.DataVersionBellatrix, Version: phase0.Version{0x30, 0x00, 0x09, 0x10}, Epoch: 0},
+			{DataVersion: spec.DataVersionCapella, Version: phase0.Version{0x40, 0x00, 0x09, 0x10}, Epoch: 0},
+			{DataVersion: spec.DataVersionDeneb, Version: phase0.Version{0x50, 0x00, 0x09, 0x10}, Epoch: 0},
+			{DataVersion: spec.DataVersionElectra, Version: phase0.Version{0x60, 0x00, 0x09, 0x10}, Epoch: 2048},
+		},
 	},
 	MainNetwork: {
 		GenesisForkVersion:     phase0.Version{0, 0, 0, 0},
 		GenesisValidatorsRoot:  "4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
 		DepositContractAddress: "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+		ChainID:                1,
 		MinGenesisTime:         1606824023,
+		Forks: []Fork{
+			{DataVersion: spec.DataVersionAltair, Version: phase0.Version{0x01, 0x00, 0x00, 0x00}, Epoch: 74240},
+			{DataVersion: spec.DataVersionBellatrix, Version: phase0.Version{0x02, 0x00, 0x00, 0x00}, Epoch: 144896},
+			{DataVersion: spec.DataVersionCapella, Version: phase0.Version{0x03, 0x00, 0x00, 0x00}, Epoch: 194048},
+			{DataVersion: spec.DataVersionDeneb, Version: phase0.Version{0x04, 0x00, 0x00, 0x00}, Epoch: 269568},
+			{DataVersion: spec.DataVersionElectra, Version: phase0.Version{0x05, 0x00, 0x00, 0x00}, Epoch: 364032},
+		},
 	},
 }
 
+// DefaultSecondsPerSlot and DefaultSlotsPerEpoch are used when a network config doesn't set them
+const (
+	DefaultSecondsPerSlot = 12
+	DefaultSlotsPerEpoch  = 32
+)
+
+// builtinNetworks can't be overridden by RegisterNetwork
+var builtinNetworks = map[Network]bool{
+	PraterNetwork:  true,
+	SepoliaNetwork: true,
+	HoleskyNetwork: true,
+	HoodiNetwork:   true,
+	MainNetwork:    true,
+}
+
+var networksLock sync.RWMutex
+
+// RegisterNetwork registers a custom network, e.g. a local devnet.
+// Registering a custom network again overrides its config, built-in networks can't be overridden.
+func RegisterNetwork(name Network, cfg NetworkConfig) error {
+	if len(name) == 0 {
+		return errors.New("network name is required")
+	}
+	if builtinNetworks[name] {
+		return errors.Errorf("network %s is built-in and can't be overridden", name)
+	}
+	if cfg.GenesisValidatorsRoot != "" {
+		root, err := hex.DecodeString(strings.TrimPrefix(cfg.GenesisValidatorsRoot, "0x"))
+		if err != nil || len(root) != len(phase0.Root{}) {
+			return errors.Errorf("invalid genesis validators root %s", cfg.GenesisValidatorsRoot)
+		}
+		cfg.GenesisValidatorsRoot = hex.EncodeToString(root)
+	}
+	for i := 1; i < len(cfg.Forks); i++ {
+		if cfg.Forks[i].Epoch < cfg.Forks[i-1].Epoch || cfg.Forks[i].DataVersion <= cfg.Forks[i-1].DataVersion {
+			return errors.New("forks must be sorted by epoch and data version")
+		}
+	}
+
+	networksLock.Lock()
+	defer networksLock.Unlock()
+	networks[name] = cfg
+	return nil
+}
+
 // NetworkFromString converts a string to a Network type.
 func NetworkFromString(n string) (Network, error) {
-	_, ok := networks[Network(n)]
-	if !ok {
-		return "", fmt.Errorf("unknown network %s", n)
+	if _, err := Network(n).Config(); err != nil {
+		return "", err
 	}
-
 	return Network(n), nil
 }
 
 // NetworkFromForkVersion returns network from the given fork version
 func NetworkFromForkVersion(version phase0.Version) (Network, error) {
+	networksLock.RLock()
+	defer networksLock.RUnlock()
+
 	for net, cfg := range networks {
 		if cfg.GenesisForkVersion == version {
 			return net, nil
@@ -92,49 +195,104 @@ func NetworkFromForkVersion(version phase0.Version) (Network, error) {
 	return "", fmt.Errorf("network not found for the given fork version")
 }
 
+// Config returns the configuration of the network, or an error for unknown networks.
+func (n Network) Config() (NetworkConfig, error) {
+	networksLock.RLock()
+	defer networksLock.RUnlock()
+
+	cfg, exists := networks[n]
+	if !exists {
+		return NetworkConfig{}, errors.Errorf("unknown network %s", n)
+	}
+	return cfg, nil
+}
+
+// config returns the configuration of the network, it panics for unknown networks
+// as their zero config would pass for mainnet (genesis fork version 0x00000000).
+func (n Network) config() NetworkConfig {
+	cfg, err := n.Config()
+	if err != nil {
+		logrus.WithField("network", n).Error("undefined network")
+		panic(err)
+	}
+	return cfg
+}
+
 // GenesisForkVersion returns the genesis fork version of the network.
+// It panics for unknown networks, use Config to get an error instead.
 func (n Network) GenesisForkVersion() phase0.Version {
-	if cfg, exists := networks[n]; exists {
-		return cfg.GenesisForkVersion
-	}
-	logrus.WithField("network", n).Fatal("undefined network")
-	return phase0.Version{}
+	return n.config().GenesisForkVersion
 }
 
 // GenesisValidatorsRoot returns the genesis validators root of the network.
+// It panics for unknown networks, use Config to get an error instead.
 func (n Network) GenesisValidatorsRoot() phase0.Root {
 	var root phase0.Root
-	if cfg, exists := networks[n]; exists {
-		if cfg.GenesisValidatorsRoot == "" {
-			return root
-		}
-		rootBytes, err := hex.DecodeString(cfg.GenesisValidatorsRoot)
-		if err != nil {
-			logrus.WithError(err).Fatal("invalid genesis validators root")
-		}
-		copy(root[:], rootBytes)
+	rootBytes, err := hex.DecodeString(n.config().GenesisValidatorsRoot)
+	if err != nil {
+		logrus.WithError(err).Error("invalid genesis validators root")
 		return root
 	}
-	logrus.WithField("network", n).Fatal("undefined network")
+	copy(root[:], rootBytes)
 	return root
 }
 
 // DepositContractAddress returns the deposit contract address of the network.
 func (n Network) DepositContractAddress() string {
-	if cfg, exists := networks[n]; exists {
-		return cfg.DepositContractAddress
-	}
-	logrus.WithField("network", n).Fatal("undefined network")
-	return ""
+	return n.config().DepositContractAddress
+}
+
+// ChainID returns the execution layer chain id of the network, 0 if its config has none.
+func (n Network) ChainID() uint64 {
+	return n.config().ChainID
 }
 
 // MinGenesisTime returns the min genesis time of the network.
 func (n Network) MinGenesisTime() uint64 {
-	if cfg, exists := networks[n]; exists {
-		return cfg.MinGenesisTime
+	return n.config().MinGenesisTime
+}
+
+// ForkSchedule returns every fork of the network, starting with the genesis (phase0) fork at epoch 0
+func (n Network) ForkSchedule() ([]Fork, error) {
+	cfg, err := n.Config()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Forks) == 0 {
+		return nil, errors.Errorf("network %s has no fork schedule", n)
 	}
-	logrus.WithField("network", n).Fatal("undefined network")
-	return 0
+	ret := []Fork{{DataVersion: spec.DataVersionPhase0, Version: cfg.GenesisForkVersion}}
+	return append(ret, cfg.Forks...), nil
+}
+
+// ForkAtEpoch returns the fork active at the given epoch
+func (n Network) ForkAtEpoch(epoch phase0.Epoch) (Fork, error) {
+	forks, err := n.ForkSchedule()
+	if err != nil {
+		return Fork{}, err
+	}
+	ret := forks[0]
+	for _, fork := range forks[1:] {
+		if fork.Epoch > epoch {
+			break
+		}
+		ret = fork
+	}
+	return ret, nil
+}
+
+// ForkByDataVersion returns the fork of the given data version, or an error if it isn't scheduled
+func (n Network) ForkByDataVersion(dataVersion spec.DataVersion) (Fork, error) {
+	forks, err := n.ForkSchedule()
+	if err != nil {
+		return Fork{}, err
+	}
+	for _, fork := range forks {
+		if fork.DataVersion == dataVersion {
+			return fork, nil
+		}
+	}
+	return Fork{}, errors.Errorf("fork %s is not scheduled on network %s", dataVersion, n)
 }
 
 // FullPath returns the full path of the network.
@@ -144,12 +302,18 @@ func (n Network) FullPath(relativePath string) string {
 
 // SlotDurationSec returns slot duration
 func (n Network) SlotDurationSec() time.Duration {
-	return 12 * time.Second
+	if secondsPerSlot := n.config().SecondsPerSlot; secondsPerSlot > 0 {
+		return time.Duration(secondsPerSlot) * time.Second
+	}
+	return DefaultSecondsPerSlot * time.Second
 }
 
 // SlotsPerEpoch returns number of slots per one epoch
 func (n Network) SlotsPerEpoch() uint64 {
-	return 32
+	if slotsPerEpoch := n.config().SlotsPerEpoch; slotsPerEpoch > 0 {
+		return slotsPerEpoch
+	}
+	return DefaultSlotsPerEpoch
 }
 
 // EstimatedCurrentSlot returns the estimation of the current slot
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 )
@@ -19,3 +20,165 @@ func TestNetworkMainnet(t *testing.T) {
 	require.EqualValues(t, phase0.Epoch(secondsPassedSinceGenesis/12), net.EstimatedSlotAtTime(time.Now()))
 	require.EqualValues(t, phase0.Epoch(101010/32), net.EstimatedEpochAtSlot(phase0.Slot(101010)))
 }
+
+func TestUnknownNetwork(t *testing.T) {
+	_, err := NetworkFromString("unknown")
+	require.EqualError(t, err, "unknown network unknown")
+
+	_, err = Network("unknown").Config()
+	require.EqualError(t, err, "unknown network unknown")
+
+	// a zero config would pass for mainnet, whose genesis fork version is 0x00000000
+	require.PanicsWithError(t, "unknown network unknown", func() { Network("unknown").GenesisForkVersion() })
+	require.PanicsWithError(t, "unknown network unknown", func() { Network("unknown").GenesisValidatorsRoot() })
+	require.PanicsWithError(t, "unknown network unknown", func() { Network("unknown").ChainID() })
+}
+
+func TestRegisterNetwork(t *testing.T) {
+	devnet := Network("test-devnet")
+	require.NoError(t, RegisterNetwork(devnet, NetworkConfig{
+		GenesisForkVersion:     phase0.Version{0x10, 0x00, 0x00, 0x38},
+		GenesisValidatorsRoot:  "0x212f13fc4df078b6cb7db228f1c8307566dcecf900867401a92023d7ba99cb5f",
+		DepositContractAddress: "0x4242424242424242424242424242424242424242",
+		MinGenesisTime:         1700000000,
+		SecondsPerSlot:         6,
+		SlotsPerEpoch:          8,
+	}))
+
+	net, err := NetworkFromString("test-devnet")
+	require.NoError(t, err)
+	require.Equal(t, devnet, net)
+	net, err = NetworkFromForkVersion(phase0.Version{0x10, 0x00, 0x00, 0x38})
+	require.NoError(t, err)
+	require.Equal(t, devnet, net)
+
+	require.Equal(t, "0x212f13fc4df078b6cb7db228f1c8307566dcecf900867401a92023d7ba99cb5f", devnet.GenesisValidatorsRoot().String())
+	require.Equal(t, 6*time.Second, devnet.SlotDurationSec())
+	require.EqualValues(t, 8, devnet.SlotsPerEpoch())
+	require.EqualValues(t, 100, devnet.EstimatedSlotAtTime(time.Unix(1700000600, 0)))
+	require.EqualValues(t, 12, devnet.EstimatedEpochAtSlot(100))
+
+	t.Run("built-in networks can't be overridden", func(t *testing.T) {
+		require.EqualError(t, RegisterNetwork(MainNetwork, NetworkConfig{}), "network mainnet is built-in and can't be overridden")
+	})
+
+	t.Run("invalid genesis validators root", func(t *testing.T) {
+		require.EqualError(t, RegisterNetwork("test-invalid", NetworkConfig{GenesisValidatorsRoot: "0x1234"}), "invalid genesis validators root 0x1234")
+	})
+}
+
+func TestRegisterNetworkFromConfig(t *testing.T) {
+	config := []byte(`
+PRESET_BASE: 'mainnet'
+CONFIG_NAME: 'test-kurtosis'
+MIN_GENESIS_TIME: 1700000000
+GENESIS_FORK_VERSION: 0x10000038
+GENESIS_DELAY: 60
+ALTAIR_FORK_VERSION: 0x20000038
+ALTAIR_FORK_EPOCH: 0
+BELLATRIX_FORK_VERSION: 0x30000038
+BELLATRIX_FORK_EPOCH: 10
+CAPELLA_FORK_VERSION: 0x40000038
+CAPELLA_FORK_EPOCH: 18446744073709551615
+SECONDS_PER_SLOT: 6
+DEPOSIT_CHAIN_ID: 3151908
+DEPOSIT_NETWORK_ID: 3151908
+DEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242
+FAR_FUTURE_EPOCH: 18446744073709551615
+TERMINAL_TOTAL_DIFFICULTY: 115792089237316195423570985008687907853269984665640564039457584007913129638912
+BLOB_SCHEDULE:
+  - EPOCH: 0
+    MAX_BLOBS_PER_BLOCK: 9
+`)
+	gvr := phase0.Root{0x01}
+
+	t.Run("named after CONFIG_NAME", func(t *testing.T) {
+		net, err := RegisterNetworkFromConfig("", config, gvr, 0)
+		require.NoError(t, err)
+		require.Equal(t, Network("test-kurtosis"), net)
+
+		cfg, err := net.Config()
+		require.NoError(t, err)
+		require.Equal(t, phase0.Version{0x10, 0x00, 0x00, 0x38}, cfg.GenesisForkVersion)
+		require.Equal(t, "0x4242424242424242424242424242424242424242", cfg.DepositContractAddress)
+		require.EqualValues(t, 3151908, net.ChainID())
+		require.EqualValues(t, 1700000060, cfg.MinGenesisTime)
+		require.Equal(t, gvr, net.GenesisValidatorsRoot())
+		require.Equal(t, 6*time.Second, net.SlotDurationSec())
+		require.EqualValues(t, DefaultSlotsPerEpoch, net.SlotsPerEpoch())
+
+		// forks at FAR_FUTURE_EPOCH are not scheduled
+		forks, err := net.ForkSchedule()
+		require.NoError(t, err)
+		require.Equal(t, []Fork{
+			{DataVersion: spec.DataVersionPhase0, Version: phase0.Version{0x10, 0x00, 0x00, 0x38}},
+			{DataVersion: spec.DataVersionAltair, Version: phase0.Version{0x20, 0x00, 0x00, 0x38}},
+			{DataVersion: spec.DataVersionBellatrix, Version: phase0.Version{0x30, 0x00, 0x00, 0x38}, Epoch: 10},
+		}, forks)
+	})
+
+	t.Run("custom name and genesis time", func(t *testing.T) {
+		net, err := RegisterNetworkFromConfig("test-named", config, gvr, 1800000000)
+		require.NoError(t, err)
+		require.Equal(t, Network("test-named"), net)
+		require.EqualValues(t, 1800000000, net.MinGenesisTime())
+	})
+
+	t.Run("invalid configs", func(t *testing.T) {
+		_, err := RegisterNetworkFromConfig("test-invalid", []byte("CONFIG_NAME: x"), gvr, 0)
+		require.EqualError(t, err, "GENESIS_FORK_VERSION is missing")
+		_, err = RegisterNetworkFromConfig("test-invalid", []byte("GENESIS_FORK_VERSION: 0x1000"), gvr, 0)
+		require.EqualError(t, err, "invalid GENESIS_FORK_VERSION: version must be 4 bytes")
+		_, err = RegisterNetworkFromConfig("test-invalid", []byte("GENESIS_FORK_VERSION: 0x10000038\nSECONDS_PER_SLOT: x"), gvr, 0)
+		require.ErrorContains(t, err, "invalid SECONDS_PER_SLOT")
+		_, err = RegisterNetworkFromConfig("test-invalid", []byte("GENESIS_FORK_VERSION: 0x10000038\nDEPOSIT_CHAIN_ID: -1"), gvr, 0)
+		require.ErrorContains(t, err, "invalid DEPOSIT_CHAIN_ID")
+		_, err = RegisterNetworkFromConfig("test-invalid", []byte("- a"), gvr, 0)
+		require.EqualError(t, err, "config must be a mapping")
+	})
+}
+
+func TestForkSchedule(t *testing.T) {
+	tests := []struct {
+		name        string
+		epoch       phase0.Epoch
+		dataVersion spec.DataVersion
+		version     phase0.Version
+	}{
+		{name: "genesis", epoch: 0, dataVersion: spec.DataVersionPhase0, version: phase0.Version{0x00, 0x00, 0x00, 0x00}},
+		{name: "before altair", epoch: 74239, dataVersion: spec.DataVersionPhase0, version: phase0.Version{0x00, 0x00, 0x00, 0x00}},
+		{name: "altair", epoch: 74240, dataVersion: spec.DataVersionAltair, version: phase0.Version{0x01, 0x00, 0x00, 0x00}},
+		{name: "capella", epoch: 200000, dataVersion: spec.DataVersionCapella, version: phase0.Version{0x03, 0x00, 0x00, 0x00}},
+		{name: "electra", epoch: 400000, dataVersion: spec.DataVersionElectra, version: phase0.Version{0x05, 0x00, 0x00, 0x00}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fork, err := MainNetwork.ForkAtEpoch(test.epoch)
+			require.NoError(t, err)
+			require.Equal(t, test.dataVersion, fork.DataVersion)
+			require.Equal(t, test.version, fork.Version)
+		})
+	}
+
+	t.Run("fork by data version", func(t *testing.T) {
+		fork, err := HoodiNetwork.ForkByDataVersion(spec.DataVersionCapella)
+		require.NoError(t, err)
+		require.Equal(t, phase0.Version{0x40, 0x00, 0x09, 0x10}, fork.Version)
+
+		_, err = PraterNetwork.ForkByDataVersion(spec.DataVersionElectra)
+		require.EqualError(t, err, "fork electra is not scheduled on network prater")
+	})
+
+	t.Run("no fork schedule", func(t *testing.T) {
+		require.NoError(t, RegisterNetwork("test-no-forks", NetworkConfig{}))
+		_, err := Network("test-no-forks").ForkAtEpoch(0)
+		require.EqualError(t, err, "network test-no-forks has no fork schedule")
+	})
+
+	t.Run("unsorted forks", func(t *testing.T) {
+		require.EqualError(t, RegisterNetwork("test-unsorted", NetworkConfig{Forks: []Fork{
+			{DataVersion: spec.DataVersionBellatrix, Epoch: 10},
+			{DataVersion: spec.DataVersionAltair, Epoch: 20},
+		}}), "forks must be sorted by epoch and data version")
+	})
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+)
+
+// PartialSignature is a signature by a threshold share of a validator key
+type PartialSignature struct {
+	// ShareIndex is the index of the share, starting at 1
+	ShareIndex uint64
+	Signature  []byte
+}
+
+// VerifyPartialSignature verifies the signature of the message by the share public key
+func VerifyPartialSignature(signature []byte, sharePublicKey []byte, msg []byte) error {
+	pubKey := &bls.PublicKey{}
+	if err := pubKey.Deserialize(sharePublicKey); err != nil {
+		return errors.Wrap(err, "invalid share public key")
+	}
+	sig := &bls.Sign{}
+	if err := sig.Deserialize(signature); err != nil {
+		return errors.Wrap(err, "invalid partial signature")
+	}
+	if !sig.VerifyByte(pubKey, msg) {
+		return errors.New("partial signature is not valid")
+	}
+	return nil
+}
+
+// RecoverSignature recovers the validator signature of the message from threshold valid partial signatures.
+// Each partial signature is verified against the expected public key of its share index, invalid or nil ones are skipped.
+// The recovered signature is verified against the validator public key.
+func RecoverSignature(partials []*PartialSignature, sharePublicKeys map[uint64][]byte, threshold uint64, msg []byte, validatorPublicKey []byte) ([]byte, error) {
+	if threshold == 0 {
+		return nil, errors.New("threshold must be positive")
+	}
+
+	valid := make(map[uint64][]byte)
+	for _, partial := range partials {
+		if uint64(len(valid)) == threshold {
+			break
+		}
+		if partial == nil {
+			continue
+		}
+		if _, found := valid[partial.ShareIndex]; found {
+			continue
+		}
+		sharePublicKey, found := sharePublicKeys[partial.ShareIndex]
+		if !found {
+			continue
+		}
+		if err := VerifyPartialSignature(partial.Signature, sharePublicKey, msg); err != nil {
+			continue
+		}
+		valid[partial.ShareIndex] = partial.Signature
+	}
+	if uint64(len(valid)) < threshold {
+		return nil, errors.Errorf("not enough valid partial signatures, got %d of %d", len(valid), threshold)
+	}
+
+	signature, err := InterpolateSignature(valid)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyPartialSignature(signature, validatorPublicKey, msg); err != nil {
+		return nil, errors.New("recovered signature doesn't match the validator public key")
+	}
+	return signature, nil
+}
+
+// SharePublicKey evaluates the verification vector, the public keys of the sharing polynomial coefficients, at the given share index
+func SharePublicKey(verificationVector [][]byte, index uint64) ([]byte, error) {
+	if len(verificationVector) == 0 {
+		return nil, errors.New("verification vector is empty")
+	}
+	if index == 0 {
+		return nil, errors.New("share index must be positive")
+	}
+	id := &bls.ID{}
+	if err := id.SetDecString(strconv.FormatUint(index, 10)); err != nil {
+		return nil, errors.Wrapf(err, "invalid share index %d", index)
+	}
+	mpk := make([]bls.PublicKey, len(verificationVector))
+	for i, byts := range verificationVector {
+		if err := mpk[i].Deserialize(byts); err != nil {
+			return nil, errors.Wrapf(err, "invalid verification vector public key %d", i)
+		}
+	}
+
+	pub := &bls.PublicKey{}
+	if err := pub.Set(mpk, id); err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate verification vector")
+	}
+	return pub.Serialize(), nil
+}
+
+// InterpolateSignature combines signatures by share index with bls.Sign.Recover,
+// the Lagrange interpolation at 0 of the signatures polynomial.
+func InterpolateSignature(signatures map[uint64][]byte) ([]byte, error) {
+	if len(signatures) == 0 {
+		return nil, errors.New("no partial signatures were given")
+	}
+	indexes := make([]uint64, 0, len(signatures))
+	for index := range signatures {
+		if index == 0 {
+			return nil, errors.New("share index must be positive")
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	sigs := make([]bls.Sign, len(indexes))
+	ids := make([]bls.ID, len(indexes))
+	for i, index := range indexes {
+		if err := ids[i].SetDecString(strconv.FormatUint(index, 10)); err != nil {
+			return nil, errors.Wrapf(err, "invalid share index %d", index)
+		}
+		if err := sigs[i].Deserialize(signatures[index]); err != nil {
+			return nil, errors.Wrapf(err, "invalid partial signature of share %d", index)
+		}
+	}
+
+	ret := &bls.Sign{}
+	if err := ret.Recover(sigs, ids); err != nil {
+		return nil, errors.Wrap(err, "failed to recover signature")
+	}
+	return ret.Serialize(), nil
+}
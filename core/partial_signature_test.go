@@ -0,0 +1,131 @@
+package core
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+// testShares splits a new key in count shares of the given threshold,
+// returning the validator key, the partial signatures of msg and the share public keys by index
+func testShares(t *testing.T, threshold int, count int, msg []byte) (*bls.SecretKey, []*PartialSignature, map[uint64][]byte) {
+	require.NoError(t, InitBLS())
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	msk := sk.GetMasterSecretKey(threshold)
+
+	partials := make([]*PartialSignature, count)
+	sharePublicKeys := make(map[uint64][]byte)
+	for i := range partials {
+		id := &bls.ID{}
+		require.NoError(t, id.SetDecString(strconv.Itoa(i+1)))
+		share := &bls.SecretKey{}
+		require.NoError(t, share.Set(msk, id))
+		partials[i] = &PartialSignature{
+			ShareIndex: uint64(i + 1),
+			Signature:  share.SignByte(msg).Serialize(),
+		}
+		sharePublicKeys[uint64(i+1)] = share.GetPublicKey().Serialize()
+	}
+	return sk, partials, sharePublicKeys
+}
+
+func TestVerifyPartialSignature(t *testing.T) {
+	msg := []byte("message")
+	_, partials, sharePublicKeys := testShares(t, 3, 4, msg)
+
+	require.NoError(t, VerifyPartialSignature(partials[0].Signature, sharePublicKeys[1], msg))
+	require.EqualError(t, VerifyPartialSignature(partials[0].Signature, sharePublicKeys[2], msg), "partial signature is not valid")
+	require.EqualError(t, VerifyPartialSignature(partials[0].Signature, sharePublicKeys[1], []byte("other")), "partial signature is not valid")
+	require.Error(t, VerifyPartialSignature([]byte{1, 2, 3}, sharePublicKeys[1], msg))
+	require.Error(t, VerifyPartialSignature(partials[0].Signature, []byte{1, 2, 3}, msg))
+}
+
+func TestInterpolateSignature(t *testing.T) {
+	msg := []byte("message")
+	_, partials, _ := testShares(t, 3, 7, msg)
+
+	subsets := [][]int{{0, 1, 2}, {6, 2, 4}, {1, 3, 5, 6}}
+	for _, subset := range subsets {
+		sigs := make(map[uint64][]byte)
+		herumiSigs := make([]bls.Sign, len(subset))
+		herumiIDs := make([]bls.ID, len(subset))
+		for i, j := range subset {
+			sigs[partials[j].ShareIndex] = partials[j].Signature
+			require.NoError(t, herumiSigs[i].Deserialize(partials[j].Signature))
+			require.NoError(t, herumiIDs[i].SetDecString(strconv.FormatUint(partials[j].ShareIndex, 10)))
+		}
+
+		expected := &bls.Sign{}
+		require.NoError(t, expected.Recover(herumiSigs, herumiIDs))
+
+		sig, err := InterpolateSignature(sigs)
+		require.NoError(t, err)
+		require.Equal(t, expected.Serialize(), sig)
+	}
+
+	_, err := InterpolateSignature(map[uint64][]byte{})
+	require.EqualError(t, err, "no partial signatures were given")
+	_, err = InterpolateSignature(map[uint64][]byte{0: partials[0].Signature})
+	require.EqualError(t, err, "share index must be positive")
+}
+
+func TestRecoverSignature(t *testing.T) {
+	msg := []byte("message")
+	sk, partials, sharePublicKeys := testShares(t, 3, 4, msg)
+	validatorPublicKey := sk.GetPublicKey().Serialize()
+	expected := sk.SignByte(msg).Serialize()
+
+	t.Run("valid partials", func(t *testing.T) {
+		sig, err := RecoverSignature(partials, sharePublicKeys, 3, msg, validatorPublicKey)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+	})
+
+	t.Run("invalid partials are skipped", func(t *testing.T) {
+		invalid := &PartialSignature{
+			ShareIndex: partials[0].ShareIndex,
+			Signature:  partials[1].Signature,
+		}
+		unknown := &PartialSignature{
+			ShareIndex: 5,
+			Signature:  partials[0].Signature,
+		}
+		sig, err := RecoverSignature([]*PartialSignature{invalid, unknown, partials[3], partials[1], partials[1], partials[2]}, sharePublicKeys, 3, msg, validatorPublicKey)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+	})
+
+	t.Run("partial of a wrong share index", func(t *testing.T) {
+		// valid for the public key of share 1, but claiming share 2
+		wrongIndex := &PartialSignature{
+			ShareIndex: partials[1].ShareIndex,
+			Signature:  partials[0].Signature,
+		}
+		sig, err := RecoverSignature([]*PartialSignature{wrongIndex, partials[0], partials[2], partials[3]}, sharePublicKeys, 3, msg, validatorPublicKey)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+	})
+
+	t.Run("nil partials are skipped", func(t *testing.T) {
+		sig, err := RecoverSignature([]*PartialSignature{nil, partials[0], nil, partials[1], partials[2]}, sharePublicKeys, 3, msg, validatorPublicKey)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+	})
+
+	t.Run("not enough valid partials", func(t *testing.T) {
+		_, err := RecoverSignature(partials[:2], sharePublicKeys, 3, msg, validatorPublicKey)
+		require.EqualError(t, err, "not enough valid partial signatures, got 2 of 3")
+
+		_, err = RecoverSignature([]*PartialSignature{partials[0], nil, partials[1]}, sharePublicKeys, 3, msg, validatorPublicKey)
+		require.EqualError(t, err, "not enough valid partial signatures, got 2 of 3")
+	})
+
+	t.Run("other validator", func(t *testing.T) {
+		other, _, _ := testShares(t, 3, 4, msg)
+		_, err := RecoverSignature(partials, sharePublicKeys, 3, msg, other.GetPublicKey().Serialize())
+		require.EqualError(t, err, "recovered signature doesn't match the validator public key")
+	})
+}
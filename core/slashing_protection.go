@@ -14,10 +14,76 @@ type SlashingProtector interface {
 	FetchHighestProposal(pubKey []byte) (phase0.Slot, bool, error)
 }
 
-// SlashingStore represents the behavior of the slashing store
+// SigningRootProtector is implemented by slashing protectors which know the signing roots of signed messages.
+// Signing a message with the exact same signing root as a previously signed one is not slashable,
+// so those protectors allow validator clients to re-sign after a restart.
+type SigningRootProtector interface {
+	IsSlashableAttestationWithRoot(pubKey []byte, attestation *phase0.AttestationData, signingRoot phase0.Root) (*AttestationSlashStatus, error)
+	IsSlashableProposalWithRoot(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) (*ProposalSlashStatus, error)
+	RecordAttestation(pubKey []byte, attestation *phase0.AttestationData, signingRoot phase0.Root) error
+	RecordProposal(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) error
+}
+
+// AttestationProtectionRequest is a single attestation of a batch slashing protection check
+type AttestationProtectionRequest struct {
+	PubKey      []byte
+	Attestation *phase0.AttestationData
+	SigningRoot phase0.Root
+}
+
+// BatchSlashingProtector is implemented by slashing protectors which check the attestations of many validators at once.
+type BatchSlashingProtector interface {
+	// ProtectAttestations checks every attestation and records the ones which are not slashable.
+	// Statuses and errors are returned per request, a nil status and error means the attestation can be signed.
+	ProtectAttestations(requests []*AttestationProtectionRequest) ([]*AttestationSlashStatus, []error)
+}
+
+// SlashingStore represents the behavior of the slashing store.
+// The signing roots are saved along with the target epoch or slot they were signed for,
+// a signing root only applies to the highest attestation or proposal if those match.
 type SlashingStore interface {
 	SaveHighestAttestation(pubKey []byte, attestation *phase0.AttestationData) error
 	RetrieveHighestAttestation(pubKey []byte) (*phase0.AttestationData, bool, error)
 	SaveHighestProposal(pubKey []byte, slot phase0.Slot) error
 	RetrieveHighestProposal(pubKey []byte) (phase0.Slot, bool, error)
+	SaveHighestAttestationSigningRoot(pubKey []byte, targetEpoch phase0.Epoch, signingRoot phase0.Root) error
+	RetrieveHighestAttestationSigningRoot(pubKey []byte) (phase0.Epoch, phase0.Root, bool, error)
+	SaveHighestProposalSigningRoot(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) error
+	RetrieveHighestProposalSigningRoot(pubKey []byte) (phase0.Slot, phase0.Root, bool, error)
+}
+
+// SlashingStoreTransactor is implemented by slashing stores able to run many operations in one store transaction
+type SlashingStoreTransactor interface {
+	// SlashingTx runs fn with a SlashingStore bound to a single transaction, an error returned by fn rolls it back
+	SlashingTx(fn func(store SlashingStore) error) error
+}
+
+// SignedAttestation is an attestation record kept by FullSlashingStore.
+// A zero signing root means the signing root is unknown.
+type SignedAttestation struct {
+	SourceEpoch phase0.Epoch `json:"source_epoch"`
+	TargetEpoch phase0.Epoch `json:"target_epoch"`
+	SigningRoot phase0.Root  `json:"signing_root"`
+}
+
+// SignedProposal is a proposal record kept by FullSlashingStore.
+// A zero signing root means the signing root is unknown.
+type SignedProposal struct {
+	Slot        phase0.Slot `json:"slot"`
+	SigningRoot phase0.Root `json:"signing_root"`
+}
+
+// FullSlashingStore represents the behavior of a slashing store keeping the complete signing history.
+// Attestations are keyed by target epoch and proposals by slot, saving an existing key overrides it.
+type FullSlashingStore interface {
+	SaveSignedAttestation(pubKey []byte, attestation *SignedAttestation) error
+	// ListSignedAttestations returns the attestations sorted by target epoch
+	ListSignedAttestations(pubKey []byte) ([]*SignedAttestation, error)
+	// PruneSignedAttestations deletes the attestations with a target epoch lower than the given one
+	PruneSignedAttestations(pubKey []byte, targetEpoch phase0.Epoch) error
+	SaveSignedProposal(pubKey []byte, proposal *SignedProposal) error
+	// ListSignedProposals returns the proposals sorted by slot
+	ListSignedProposals(pubKey []byte) ([]*SignedProposal, error)
+	// PruneSignedProposals deletes the proposals with a slot lower than the given one
+	PruneSignedProposals(pubKey []byte, slot phase0.Slot) error
 }
@@ -45,3 +45,10 @@ type AccountStorage interface {
 	// SetEncryptor sets the given encryptor to the wallet.
 	SetEncryptor(encryptor encryptor.Encryptor, password []byte)
 }
+
+// KeyCacheStorage is optionally implemented by storages decrypting account keys lazily.
+// Its key cache holds the decrypted keys of the accounts it opens.
+type KeyCacheStorage interface {
+	// KeyCache returns the key cache of the storage
+	KeyCache() *KeyCache
+}
@@ -29,8 +29,8 @@ type ValidatorAccount interface {
 	// ValidationKeySign signs data with the validation key.
 	ValidationKeySign(data []byte) ([]byte, error)
 
-	// GetDepositData returns deposit data
-	GetDepositData() (map[string]interface{}, error)
+	// GetDepositData returns deposit data, 32 ETH with BLS withdrawal credentials unless options are given
+	GetDepositData(opts ...DepositDataOption) (map[string]interface{}, error)
 
 	// SetContext sets the given context
 	SetContext(ctx *WalletContext)
@@ -0,0 +1,56 @@
+package keystorev4
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Keystore is an EIP-2335 keystore file
+// https://eips.ethereum.org/EIPS/eip-2335
+type Keystore struct {
+	Crypto      map[string]interface{} `json:"crypto"`
+	Description string                 `json:"description"`
+	PubKey      string                 `json:"pubkey"`
+	Path        string                 `json:"path"`
+	UUID        uuid.UUID              `json:"uuid"`
+	Version     uint                   `json:"version"`
+}
+
+// ParseKeystore parses the given EIP-2335 keystore JSON
+func ParseKeystore(data []byte) (*Keystore, error) {
+	ret := &Keystore{}
+	if err := json.Unmarshal(data, ret); err != nil {
+		return nil, errors.Wrap(err, "failed to parse keystore")
+	}
+	if ret.Version != version {
+		return nil, errors.Errorf("unsupported keystore version %d", ret.Version)
+	}
+	if ret.Crypto == nil {
+		return nil, errors.New("keystore crypto is missing")
+	}
+	return ret, nil
+}
+
+// Decrypt decrypts the keystore, returning the secret key
+func (ks *Keystore) Decrypt(passphrase string) ([]byte, error) {
+	return New().Decrypt(ks.Crypto, passphrase)
+}
+
+// EncryptKeystore encrypts the secret key in a new EIP-2335 keystore
+func (e *Encryptor) EncryptKeystore(secret []byte, pubKey []byte, path string, id uuid.UUID, description string, passphrase string) (*Keystore, error) {
+	crypto, err := e.Encrypt(secret, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt secret")
+	}
+	return &Keystore{
+		Crypto:      crypto,
+		Description: description,
+		PubKey:      hex.EncodeToString(pubKey),
+		Path:        path,
+		UUID:        id,
+		Version:     version,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package eth1deposit
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// DepositCLIVersion is the staking-deposit-cli version written in deposit data files
+const DepositCLIVersion = "2.7.0"
+
+// DepositDataJSON is a deposit in the staking-deposit-cli deposit_data-<timestamp>.json format, which the launchpad accepts.
+// Hex values have no 0x prefix.
+type DepositDataJSON struct {
+	PubKey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ForkVersion           string `json:"fork_version"`
+	NetworkName           string `json:"network_name"`
+	DepositCLIVersion     string `json:"deposit_cli_version"`
+}
+
+// NewDepositDataJSON returns the staking-deposit-cli form of the given deposit data
+func NewDepositDataJSON(depositData *phase0.DepositData, network core.Network) (*DepositDataJSON, error) {
+	cfg, err := network.Config()
+	if err != nil {
+		return nil, err
+	}
+	messageRoot, err := DepositMessageRoot(depositData)
+	if err != nil {
+		return nil, err
+	}
+	dataRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine the root hash of deposit data")
+	}
+	return &DepositDataJSON{
+		PubKey:                hex.EncodeToString(depositData.PublicKey[:]),
+		WithdrawalCredentials: hex.EncodeToString(depositData.WithdrawalCredentials),
+		Amount:                uint64(depositData.Amount),
+		Signature:             hex.EncodeToString(depositData.Signature[:]),
+		DepositMessageRoot:    hex.EncodeToString(messageRoot[:]),
+		DepositDataRoot:       hex.EncodeToString(dataRoot[:]),
+		ForkVersion:           hex.EncodeToString(cfg.GenesisForkVersion[:]),
+		NetworkName:           DepositNetworkName(network),
+		DepositCLIVersion:     DepositCLIVersion,
+	}, nil
+}
+
+// DepositData returns the deposit data of the deposit, its roots aren't verified, see VerifyDepositData
+func (d *DepositDataJSON) DepositData() (*phase0.DepositData, error) {
+	pubKey, err := decodeHex(d.PubKey, phase0.PublicKeyLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid pubkey")
+	}
+	withdrawalCredentials, err := decodeHex(d.WithdrawalCredentials, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid withdrawal_credentials")
+	}
+	signature, err := decodeHex(d.Signature, phase0.SignatureLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+
+	ret := &phase0.DepositData{
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                phase0.Gwei(d.Amount),
+	}
+	copy(ret.PublicKey[:], pubKey)
+	copy(ret.Signature[:], signature)
+	return ret, nil
+}
+
+// DepositMessageRoot returns the root of the deposit message of the given deposit data
+func DepositMessageRoot(depositData *phase0.DepositData) ([32]byte, error) {
+	depositMessage := &phase0.DepositMessage{
+		PublicKey:             depositData.PublicKey,
+		WithdrawalCredentials: depositData.WithdrawalCredentials,
+		Amount:                depositData.Amount,
+	}
+	root, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "failed to determine the root hash of deposit message")
+	}
+	return root, nil
+}
+
+// DepositNetworkName returns the staking-deposit-cli name of the network, prater is named goerli
+func DepositNetworkName(network core.Network) string {
+	if network == core.PraterNetwork {
+		return "goerli"
+	}
+	return string(network)
+}
+
+// DepositDataFileName returns the staking-deposit-cli deposit data file name of the given unix timestamp
+func DepositDataFileName(timestamp int64) string {
+	return fmt.Sprintf("deposit_data-%d.json", timestamp)
+}
@@ -0,0 +1,101 @@
+package eth1deposit
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// DepositGasLimit is the gas limit of deposit transactions
+const DepositGasLimit = 100000
+
+// depositFunctionSignature is the deposit contract function called by deposit transactions
+const depositFunctionSignature = "deposit(bytes,bytes,bytes,bytes32)"
+
+// DepositCallData returns the ABI encoded deposit(pubkey, withdrawal_credentials, signature, deposit_data_root) call of the deposit contract
+func DepositCallData(depositData *phase0.DepositData) ([]byte, error) {
+	if len(depositData.WithdrawalCredentials) != 32 {
+		return nil, errors.New("withdrawal credentials must be 32 bytes")
+	}
+	root, err := depositData.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine the root hash of deposit data")
+	}
+
+	selector := keccak256([]byte(depositFunctionSignature))
+	ret := append([]byte{}, selector[:4]...)
+
+	// the head holds the offsets of the dynamic bytes arguments and the root, the tail holds the bytes arguments
+	dynamic := [][]byte{depositData.PublicKey[:], depositData.WithdrawalCredentials, depositData.Signature[:]}
+	var tail []byte
+	for _, arg := range dynamic {
+		ret = append(ret, abiUint(uint64(32*(len(dynamic)+1)+len(tail)))...)
+		tail = append(tail, abiBytes(arg)...)
+	}
+	ret = append(ret, root[:]...)
+	return append(ret, tail...), nil
+}
+
+// DepositValue returns the deposit amount in wei
+func DepositValue(amount phase0.Gwei) *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(uint64(amount)), big.NewInt(1000000000))
+}
+
+// DepositTransaction returns the unsigned deposit contract transaction of the deposit data, its nonce and fees are set by the caller
+func DepositTransaction(depositData *phase0.DepositData, network core.Network) (*Transaction, error) {
+	if !IsSupportedDepositNetwork(network) {
+		return nil, errors.Errorf("Network %s is not supported", network)
+	}
+	if network.ChainID() == 0 {
+		return nil, errors.Errorf("chain id of network %s is unknown", network)
+	}
+	to, err := ParseExecutionAddress(network.DepositContractAddress())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid deposit contract address")
+	}
+	data, err := DepositCallData(depositData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transaction{
+		ChainID: network.ChainID(),
+		Gas:     DepositGasLimit,
+		To:      to,
+		Value:   DepositValue(depositData.Amount),
+		Data:    data,
+	}, nil
+}
+
+// ParseExecutionAddress parses a hex execution address, with or without 0x prefix
+func ParseExecutionAddress(address string) (bellatrix.ExecutionAddress, error) {
+	var ret bellatrix.ExecutionAddress
+	byts, err := decodeHex(address, bellatrix.ExecutionAddressLength)
+	if err != nil {
+		return ret, err
+	}
+	copy(ret[:], byts)
+	return ret, nil
+}
+
+// abiUint returns the ABI encoding of the given integer, a big-endian 32 bytes word
+func abiUint(i uint64) []byte {
+	ret := make([]byte, 32)
+	binary.BigEndian.PutUint64(ret[24:], i)
+	return ret
+}
+
+// abiBytes returns the ABI encoding of dynamic bytes, their length followed by the bytes padded to 32 bytes words
+func abiBytes(b []byte) []byte {
+	ret := abiUint(uint64(len(b)))
+	ret = append(ret, b...)
+	if len(b)%32 != 0 {
+		ret = append(ret, make([]byte, 32-len(b)%32)...)
+	}
+	return ret
+}
@@ -0,0 +1,126 @@
+package eth1deposit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+func TestDepositCallData(t *testing.T) {
+	require.NoError(t, core.InitBLS())
+	val, err := core.NewHDKeyFromPrivateKey(_ignoreErr(hex.DecodeString("175db1c5411459893301c3f2ebe740e5da07db8f17c2df4fa0be6d31a48a4f79")), "")
+	require.NoError(t, err)
+	depositData, root, err := DepositData(
+		val,
+		_ignoreErr(hex.DecodeString("8d176708b908f288cc0e9d43f75674e73c0db94026822c5ce2c3e0f9e773c9ee95fdba824302f1208c225b0ed2d54154")),
+		core.MainNetwork,
+		MaxEffectiveBalanceInGwei,
+	)
+	require.NoError(t, err)
+
+	data, err := DepositCallData(depositData)
+	require.NoError(t, err)
+	require.Len(t, data, 4+4*32+(32+64)+(32+32)+(32+96))
+
+	word := func(i int) []byte {
+		return data[4+32*i : 4+32*(i+1)]
+	}
+	require.Equal(t, "22895118", hex.EncodeToString(data[:4]))
+	require.Equal(t, abiUint(0x80), word(0))
+	require.Equal(t, abiUint(0xe0), word(1))
+	require.Equal(t, abiUint(0x120), word(2))
+	require.Equal(t, root[:], word(3))
+	require.Equal(t, abiUint(48), word(4))
+	require.Equal(t, depositData.PublicKey[:], data[4+5*32:4+5*32+48])
+	require.Equal(t, make([]byte, 16), data[4+5*32+48:4+7*32])
+	require.Equal(t, abiUint(32), word(7))
+	require.Equal(t, depositData.WithdrawalCredentials, word(8))
+	require.Equal(t, abiUint(96), word(9))
+	require.Equal(t, depositData.Signature[:], data[4+10*32:])
+
+	t.Run("transaction", func(t *testing.T) {
+		tx, err := DepositTransaction(depositData, core.MainNetwork)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, tx.ChainID)
+		require.EqualValues(t, DepositGasLimit, tx.Gas)
+		require.Equal(t, "00000000219ab540356cbb839cbe05303d7705fa", hex.EncodeToString(tx.To[:]))
+		require.Equal(t, "32000000000000000000", tx.Value.String())
+		require.Equal(t, data, tx.Data)
+
+		tx.Nonce = 7
+		tx.MaxPriorityFeePerGas = big.NewInt(1000000000)
+		tx.MaxFeePerGas = big.NewInt(30000000000)
+		byts, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		expectedPrefix := "02" + // type
+			"f901d8" + // list of 472 bytes
+			"01" + // chain id
+			"07" + // nonce
+			"843b9aca00" + // max priority fee
+			"8506fc23ac00" + // max fee
+			"830186a0" + // gas
+			"9400000000219ab540356cbb839cbe05303d7705fa" + // to
+			"8901bc16d674ec800000" + // value
+			"b901a4" // data of 420 bytes
+		require.True(t, strings.HasPrefix(hex.EncodeToString(byts), expectedPrefix), hex.EncodeToString(byts))
+		require.True(t, bytes.HasSuffix(byts, append(data, 0xc0)))
+
+		var v map[string]interface{}
+		byts, err = json.Marshal(tx)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(byts, &v))
+		require.Equal(t, "0x2", v["type"])
+		require.Equal(t, "0x1", v["chainId"])
+		require.Equal(t, "0x7", v["nonce"])
+		require.Equal(t, "0x186a0", v["gas"])
+		require.Equal(t, "0x6fc23ac00", v["maxFeePerGas"])
+		require.Equal(t, "0x3b9aca00", v["maxPriorityFeePerGas"])
+		require.Equal(t, "0x00000000219ab540356cbb839cbe05303d7705fa", v["to"])
+		require.Equal(t, "0x1bc16d674ec800000", v["value"])
+		require.Equal(t, "0x"+hex.EncodeToString(data), v["input"])
+		require.Equal(t, []interface{}{}, v["accessList"])
+	})
+
+	t.Run("unknown chain id", func(t *testing.T) {
+		network := core.Network("deposit-tx-test")
+		require.NoError(t, core.RegisterNetwork(network, core.NetworkConfig{
+			DepositContractAddress: "0x4242424242424242424242424242424242424242",
+		}))
+		_, err := DepositTransaction(depositData, network)
+		require.EqualError(t, err, "chain id of network deposit-tx-test is unknown")
+	})
+}
+
+func TestRLP(t *testing.T) {
+	// examples of the RLP specification
+	require.Equal(t, "83646f67", hex.EncodeToString(rlpBytes([]byte("dog"))))
+	require.Equal(t, "c88363617483646f67", hex.EncodeToString(rlpList(rlpBytes([]byte("cat")), rlpBytes([]byte("dog")))))
+	require.Equal(t, "80", hex.EncodeToString(rlpBytes(nil)))
+	require.Equal(t, "c0", hex.EncodeToString(rlpList()))
+	require.Equal(t, "80", hex.EncodeToString(rlpUint(0)))
+	require.Equal(t, "0f", hex.EncodeToString(rlpUint(15)))
+	require.Equal(t, "820400", hex.EncodeToString(rlpUint(1024)))
+	require.Equal(t, "80", hex.EncodeToString(rlpBigInt(nil)))
+	require.Equal(t,
+		"b8384c6f72656d20697073756d20646f6c6f722073697420616d65742c20636f6e7365637465747572206164697069736963696e6720656c6974",
+		hex.EncodeToString(rlpBytes([]byte("Lorem ipsum dolor sit amet, consectetur adipisicing elit"))),
+	)
+	require.Equal(t, "c7c0c1c0c3c0c1c0", hex.EncodeToString(rlpList(rlpList(), rlpList(rlpList()), rlpList(rlpList(), rlpList(rlpList())))))
+}
+
+func TestParseExecutionAddress(t *testing.T) {
+	address, err := ParseExecutionAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+	require.NoError(t, err)
+	require.Equal(t, bellatrix.ExecutionAddress{0xd8, 0xda, 0x6b, 0xf2, 0x69, 0x64, 0xaf, 0x9d, 0x7e, 0xed, 0x9e, 0x03, 0xe5, 0x34, 0x15, 0xd3, 0x7a, 0xa9, 0x60, 0x45}, address)
+
+	_, err = ParseExecutionAddress("0xd8dA")
+	require.EqualError(t, err, "expected 20 bytes, got 2")
+}
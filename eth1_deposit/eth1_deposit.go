@@ -1,6 +1,7 @@
 package eth1deposit
 
 import (
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	types "github.com/wealdtech/go-eth2-types/v2"
@@ -13,23 +14,46 @@ const (
 	// MaxEffectiveBalanceInGwei is the max effective balance
 	MaxEffectiveBalanceInGwei phase0.Gwei = 32000000000
 
+	// MaxEffectiveBalanceElectraInGwei is the max effective balance of compounding (0x02) validators
+	MaxEffectiveBalanceElectraInGwei phase0.Gwei = 2048000000000
+
+	// MinDepositAmountInGwei is the min deposit amount
+	MinDepositAmountInGwei phase0.Gwei = 1000000000
+
 	// BLSWithdrawalPrefixByte is the BLS withdrawal prefix
 	BLSWithdrawalPrefixByte = byte(0)
+
+	// ExecutionAddressWithdrawalPrefixByte is the execution address withdrawal prefix
+	ExecutionAddressWithdrawalPrefixByte = byte(1)
+
+	// CompoundingWithdrawalPrefixByte is the compounding execution address withdrawal prefix
+	CompoundingWithdrawalPrefixByte = byte(2)
 )
 
-// IsSupportedDepositNetwork returns true if the given network is supported
+// IsSupportedDepositNetwork returns true if the given network is supported, built-in or registered with core.RegisterNetwork
 var IsSupportedDepositNetwork = func(network core.Network) bool {
-	return network == core.PraterNetwork || network == core.HoleskyNetwork || network == core.MainNetwork || network == core.HoodiNetwork || network == core.SepoliaNetwork
+	_, err := network.Config()
+	return err == nil
 }
 
 // DepositData is basically copied from https://github.com/prysmaticlabs/prysm/blob/master/shared/keystore/deposit_input.go
+// The deposit has BLS (0x00) withdrawal credentials of the given withdrawal public key.
 func DepositData(validationKey *core.HDKey, withdrawalPubKey []byte, network core.Network, amount phase0.Gwei) (*phase0.DepositData, [32]byte, error) {
+	return DepositDataWithCredentials(validationKey, withdrawalCredentialsHash(withdrawalPubKey), network, amount)
+}
+
+// DepositDataWithCredentials returns the signed deposit data of the given withdrawal credentials,
+// the amount is validated against the limits of the credentials type.
+func DepositDataWithCredentials(validationKey *core.HDKey, withdrawalCredentials []byte, network core.Network, amount phase0.Gwei) (*phase0.DepositData, [32]byte, error) {
 	if !IsSupportedDepositNetwork(network) {
 		return nil, [32]byte{}, errors.Errorf("Network %s is not supported", network)
 	}
+	if err := ValidateDepositAmount(withdrawalCredentials, amount); err != nil {
+		return nil, [32]byte{}, err
+	}
 
 	depositMessage := &phase0.DepositMessage{
-		WithdrawalCredentials: withdrawalCredentialsHash(withdrawalPubKey),
+		WithdrawalCredentials: withdrawalCredentials,
 		Amount:                amount,
 	}
 	copy(depositMessage.PublicKey[:], validationKey.PublicKey().Serialize())
@@ -39,21 +63,9 @@ func DepositData(validationKey *core.HDKey, withdrawalPubKey []byte, network cor
 		return nil, [32]byte{}, errors.Wrap(err, "failed to determine the root hash of deposit data")
 	}
 
-	// Compute domain
-	genesisForkVersion := network.GenesisForkVersion()
-	domain, err := types.ComputeDomain(types.DomainDeposit, genesisForkVersion[:], types.ZeroGenesisValidatorsRoot)
+	root, err := depositSigningRoot(objRoot, network)
 	if err != nil {
-		return nil, [32]byte{}, errors.Wrap(err, "failed to calculate domain")
-	}
-
-	signingData := phase0.SigningData{
-		ObjectRoot: objRoot,
-	}
-	copy(signingData.Domain[:], domain[:])
-
-	root, err := signingData.HashTreeRoot()
-	if err != nil {
-		return nil, [32]byte{}, errors.Wrap(err, "failed to determine the root hash of signing container")
+		return nil, [32]byte{}, err
 	}
 
 	// Sign
@@ -77,6 +89,81 @@ func DepositData(validationKey *core.HDKey, withdrawalPubKey []byte, network cor
 	return signedDepositData, depositDataRoot, nil
 }
 
+// BLSWithdrawalCredentials returns the BLS (0x00) withdrawal credentials of the given withdrawal public key
+func BLSWithdrawalCredentials(withdrawalPubKey []byte) []byte {
+	return withdrawalCredentialsHash(withdrawalPubKey)
+}
+
+// ExecutionWithdrawalCredentials returns the withdrawal credentials of the given execution address,
+// compounding (0x02) or not (0x01).
+//
+//	withdrawal_credentials[:1] == ETH1_ADDRESS_WITHDRAWAL_PREFIX or COMPOUNDING_WITHDRAWAL_PREFIX
+//	withdrawal_credentials[1:12] == b'\x00' * 11
+//	withdrawal_credentials[12:] == address
+func ExecutionWithdrawalCredentials(address bellatrix.ExecutionAddress, compounding bool) []byte {
+	ret := make([]byte, 32)
+	ret[0] = ExecutionAddressWithdrawalPrefixByte
+	if compounding {
+		ret[0] = CompoundingWithdrawalPrefixByte
+	}
+	copy(ret[12:], address[:])
+	return ret
+}
+
+// MaxDepositAmount returns the max deposit amount of the withdrawal credentials type,
+// 2048 ETH for compounding credentials and 32 ETH for the others.
+func MaxDepositAmount(withdrawalCredentials []byte) (phase0.Gwei, error) {
+	if len(withdrawalCredentials) != 32 {
+		return 0, errors.New("withdrawal credentials must be 32 bytes")
+	}
+	switch withdrawalCredentials[0] {
+	case BLSWithdrawalPrefixByte, ExecutionAddressWithdrawalPrefixByte:
+		return MaxEffectiveBalanceInGwei, nil
+	case CompoundingWithdrawalPrefixByte:
+		return MaxEffectiveBalanceElectraInGwei, nil
+	default:
+		return 0, errors.Errorf("unknown withdrawal credentials prefix %#02x", withdrawalCredentials[0])
+	}
+}
+
+// ValidateDepositAmount returns an error if the amount is not within the limits of the withdrawal credentials type
+func ValidateDepositAmount(withdrawalCredentials []byte, amount phase0.Gwei) error {
+	maxAmount, err := MaxDepositAmount(withdrawalCredentials)
+	if err != nil {
+		return err
+	}
+	if amount < MinDepositAmountInGwei {
+		return errors.Errorf("deposit amount %d Gwei is lower than the minimum of %d Gwei", amount, MinDepositAmountInGwei)
+	}
+	if amount > maxAmount {
+		return errors.Errorf("deposit amount %d Gwei exceeds the maximum of %d Gwei for %#02x withdrawal credentials", amount, maxAmount, withdrawalCredentials[0])
+	}
+	return nil
+}
+
+// depositSigningRoot returns the signing root of the deposit message root, deposits are signed with the genesis fork domain
+func depositSigningRoot(depositMessageRoot [32]byte, network core.Network) ([32]byte, error) {
+	cfg, err := network.Config()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	domain, err := types.ComputeDomain(types.DomainDeposit, cfg.GenesisForkVersion[:], types.ZeroGenesisValidatorsRoot)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "failed to calculate domain")
+	}
+
+	signingData := phase0.SigningData{
+		ObjectRoot: depositMessageRoot,
+	}
+	copy(signingData.Domain[:], domain[:])
+
+	root, err := signingData.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "failed to determine the root hash of signing container")
+	}
+	return root, nil
+}
+
 // withdrawalCredentialsHash forms a 32 byte hash of the withdrawal public
 // address.
 //
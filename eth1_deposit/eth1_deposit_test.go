@@ -2,10 +2,12 @@ package eth1deposit
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 	types "github.com/wealdtech/go-eth2-types/v2"
@@ -230,3 +232,249 @@ func TestUnsupportedNetwork(t *testing.T) {
 	require.Nil(t, depositData)
 	require.EqualValues(t, root, [32]byte{})
 }
+
+func TestDepositDataJSONUnknownNetwork(t *testing.T) {
+	_, err := NewDepositDataJSON(&phase0.DepositData{WithdrawalCredentials: make([]byte, 32)}, "not_supported")
+	require.EqualError(t, err, "unknown network not_supported")
+}
+
+func TestExecutionWithdrawalCredentials(t *testing.T) {
+	require.NoError(t, core.InitBLS())
+	val, err := core.NewHDKeyFromPrivateKey(_ignoreErr(hex.DecodeString("175db1c5411459893301c3f2ebe740e5da07db8f17c2df4fa0be6d31a48a4f79")), "")
+	require.NoError(t, err)
+	var address bellatrix.ExecutionAddress
+	copy(address[:], _ignoreErr(hex.DecodeString("d8da6bf26964af9d7eed9e03e53415d37aa96045")))
+
+	tests := []struct {
+		name                          string
+		compounding                   bool
+		amount                        phase0.Gwei
+		expectedWithdrawalCredentials string
+		err                           string
+	}{
+		{
+			name:                          "0x01 credentials",
+			amount:                        MaxEffectiveBalanceInGwei,
+			expectedWithdrawalCredentials: "010000000000000000000000d8da6bf26964af9d7eed9e03e53415d37aa96045",
+		},
+		{
+			name:   "0x01 credentials above 32 ETH",
+			amount: MaxEffectiveBalanceInGwei + 1,
+			err:    "deposit amount 32000000001 Gwei exceeds the maximum of 32000000000 Gwei for 0x01 withdrawal credentials",
+		},
+		{
+			name:                          "0x02 credentials",
+			compounding:                   true,
+			amount:                        MaxEffectiveBalanceElectraInGwei,
+			expectedWithdrawalCredentials: "020000000000000000000000d8da6bf26964af9d7eed9e03e53415d37aa96045",
+		},
+		{
+			name:                          "0x02 credentials of 1 ETH",
+			compounding:                   true,
+			amount:                        MinDepositAmountInGwei,
+			expectedWithdrawalCredentials: "020000000000000000000000d8da6bf26964af9d7eed9e03e53415d37aa96045",
+		},
+		{
+			name:        "0x02 credentials above 2048 ETH",
+			compounding: true,
+			amount:      MaxEffectiveBalanceElectraInGwei + 1,
+			err:         "deposit amount 2048000000001 Gwei exceeds the maximum of 2048000000000 Gwei for 0x02 withdrawal credentials",
+		},
+		{
+			name:        "below 1 ETH",
+			compounding: true,
+			amount:      MinDepositAmountInGwei - 1,
+			err:         "deposit amount 999999999 Gwei is lower than the minimum of 1000000000 Gwei",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			credentials := ExecutionWithdrawalCredentials(address, test.compounding)
+			depositData, _, err := DepositDataWithCredentials(val, credentials, core.MainNetwork, test.amount)
+			if len(test.err) > 0 {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedWithdrawalCredentials, hex.EncodeToString(depositData.WithdrawalCredentials))
+			require.Equal(t, test.amount, depositData.Amount)
+			VerifyOperation(t, depositData, core.MainNetwork)
+		})
+	}
+
+	t.Run("unknown prefix", func(t *testing.T) {
+		credentials := ExecutionWithdrawalCredentials(address, false)
+		credentials[0] = 3
+		_, _, err := DepositDataWithCredentials(val, credentials, core.MainNetwork, MaxEffectiveBalanceInGwei)
+		require.EqualError(t, err, "unknown withdrawal credentials prefix 0x03")
+	})
+
+	t.Run("BLS credentials above 32 ETH", func(t *testing.T) {
+		_, _, err := DepositData(val, val.PublicKey().Serialize(), core.MainNetwork, MaxEffectiveBalanceElectraInGwei)
+		require.EqualError(t, err, "deposit amount 2048000000000 Gwei exceeds the maximum of 32000000000 Gwei for 0x00 withdrawal credentials")
+	})
+}
+
+func TestDepositDataJSON(t *testing.T) {
+	require.NoError(t, core.InitBLS())
+	val, err := core.NewHDKeyFromPrivateKey(_ignoreErr(hex.DecodeString("175db1c5411459893301c3f2ebe740e5da07db8f17c2df4fa0be6d31a48a4f79")), "")
+	require.NoError(t, err)
+	withdrawalPubKey := _ignoreErr(hex.DecodeString("8d176708b908f288cc0e9d43f75674e73c0db94026822c5ce2c3e0f9e773c9ee95fdba824302f1208c225b0ed2d54154"))
+
+	tests := []struct {
+		network             core.Network
+		expectedForkVersion string
+		expectedNetworkName string
+	}{
+		{network: core.MainNetwork, expectedForkVersion: "00000000", expectedNetworkName: "mainnet"},
+		{network: core.PraterNetwork, expectedForkVersion: "00001020", expectedNetworkName: "goerli"},
+		{network: core.HoleskyNetwork, expectedForkVersion: "01017000", expectedNetworkName: "holesky"},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.network), func(t *testing.T) {
+			depositData, root, err := DepositData(val, withdrawalPubKey, test.network, MaxEffectiveBalanceInGwei)
+			require.NoError(t, err)
+
+			depositDataJSON, err := NewDepositDataJSON(depositData, test.network)
+			require.NoError(t, err)
+			byts, err := json.Marshal(depositDataJSON)
+			require.NoError(t, err)
+			var v map[string]interface{}
+			require.NoError(t, json.Unmarshal(byts, &v))
+
+			messageRoot, err := (&phase0.DepositMessage{
+				PublicKey:             depositData.PublicKey,
+				WithdrawalCredentials: depositData.WithdrawalCredentials,
+				Amount:                depositData.Amount,
+			}).HashTreeRoot()
+			require.NoError(t, err)
+
+			require.Len(t, v, 9)
+			require.Equal(t, val.PublicKey().SerializeToHexStr(), v["pubkey"])
+			require.Equal(t, "005b55a6c968852666b132a80f53712e5097b0fca86301a16992e695a8e86f16", v["withdrawal_credentials"])
+			require.EqualValues(t, 32000000000, v["amount"])
+			require.Equal(t, hex.EncodeToString(depositData.Signature[:]), v["signature"])
+			require.Equal(t, hex.EncodeToString(messageRoot[:]), v["deposit_message_root"])
+			require.Equal(t, hex.EncodeToString(root[:]), v["deposit_data_root"])
+			require.Equal(t, test.expectedForkVersion, v["fork_version"])
+			require.Equal(t, test.expectedNetworkName, v["network_name"])
+			require.Equal(t, DepositCLIVersion, v["deposit_cli_version"])
+		})
+	}
+}
+
+func TestVerifyDepositData(t *testing.T) {
+	require.NoError(t, core.InitBLS())
+	val, err := core.NewHDKeyFromPrivateKey(_ignoreErr(hex.DecodeString("175db1c5411459893301c3f2ebe740e5da07db8f17c2df4fa0be6d31a48a4f79")), "")
+	require.NoError(t, err)
+	var address bellatrix.ExecutionAddress
+	copy(address[:], _ignoreErr(hex.DecodeString("d8da6bf26964af9d7eed9e03e53415d37aa96045")))
+
+	newDeposit := func(t *testing.T, credentials []byte, amount phase0.Gwei) *DepositDataJSON {
+		depositData, _, err := DepositDataWithCredentials(val, credentials, core.MainNetwork, amount)
+		require.NoError(t, err)
+		ret, err := NewDepositDataJSON(depositData, core.MainNetwork)
+		require.NoError(t, err)
+		return ret
+	}
+
+	tests := []struct {
+		name   string
+		modify func(deposit *DepositDataJSON)
+		errors []string // prefixes of the expected errors
+	}{
+		{
+			name:   "valid",
+			modify: func(deposit *DepositDataJSON) {},
+		},
+		{
+			name:   "invalid pubkey",
+			modify: func(deposit *DepositDataJSON) { deposit.PubKey = "0102" },
+			errors: []string{"invalid pubkey: expected 48 bytes, got 2"},
+		},
+		{
+			name:   "other network",
+			modify: func(deposit *DepositDataJSON) { deposit.NetworkName = "holesky"; deposit.ForkVersion = "01017000" },
+			errors: []string{
+				"network_name holesky doesn't match mainnet",
+				"fork_version 01017000 doesn't match the mainnet genesis fork version 00000000",
+			},
+		},
+		{
+			name: "unknown withdrawal credentials prefix",
+			modify: func(deposit *DepositDataJSON) {
+				deposit.WithdrawalCredentials = "03" + deposit.WithdrawalCredentials[2:]
+			},
+			errors: []string{
+				"unknown withdrawal credentials prefix 0x03",
+				"deposit_message_root",
+				"deposit_data_root",
+				"signature doesn't match the pubkey and the genesis fork domain",
+			},
+		},
+		{
+			name:   "modified amount",
+			modify: func(deposit *DepositDataJSON) { deposit.Amount = 31000000000 },
+			errors: []string{
+				"deposit_message_root",
+				"deposit_data_root",
+				"signature doesn't match the pubkey and the genesis fork domain",
+			},
+		},
+		{
+			name: "signature of another network",
+			modify: func(deposit *DepositDataJSON) {
+				depositData, _, err := DepositDataWithCredentials(val, ExecutionWithdrawalCredentials(address, false), core.HoleskyNetwork, MaxEffectiveBalanceInGwei)
+				require.NoError(t, err)
+				deposit.Signature = hex.EncodeToString(depositData.Signature[:])
+			},
+			errors: []string{
+				"deposit_data_root",
+				"signature doesn't match the pubkey and the genesis fork domain",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deposit := newDeposit(t, ExecutionWithdrawalCredentials(address, false), MaxEffectiveBalanceInGwei)
+			test.modify(deposit)
+
+			report, err := VerifyDepositData([]*DepositDataJSON{deposit}, core.MainNetwork)
+			require.NoError(t, err)
+			require.Len(t, report, 1)
+			require.Equal(t, len(test.errors) == 0, report[0].Valid)
+			require.Len(t, report[0].Errors, len(test.errors), report[0].Errors)
+			for i, expected := range test.errors {
+				require.True(t, strings.HasPrefix(report[0].Errors[i], expected), report[0].Errors[i])
+			}
+			require.Empty(t, report[0].Warnings)
+		})
+	}
+
+	t.Run("duplicate pubkeys", func(t *testing.T) {
+		deposits := []*DepositDataJSON{
+			newDeposit(t, ExecutionWithdrawalCredentials(address, false), MaxEffectiveBalanceInGwei),
+			newDeposit(t, ExecutionWithdrawalCredentials(address, true), MaxEffectiveBalanceElectraInGwei),
+		}
+		byts, err := json.Marshal(deposits)
+		require.NoError(t, err)
+		parsed, err := ParseDepositDataJSON(byts)
+		require.NoError(t, err)
+
+		report, err := VerifyDepositData(parsed, core.MainNetwork)
+		require.NoError(t, err)
+		require.Len(t, report, 2)
+		require.True(t, report[0].Valid)
+		require.Empty(t, report[0].Warnings)
+		require.True(t, report[1].Valid)
+		require.Equal(t, []string{"duplicate pubkey, also deposited by entry 0"}, report[1].Warnings)
+	})
+
+	t.Run("unsupported network", func(t *testing.T) {
+		_, err := VerifyDepositData(nil, "not_supported")
+		require.EqualError(t, err, "Network not_supported is not supported")
+	})
+}
@@ -0,0 +1,44 @@
+package eth1deposit
+
+import (
+	"math/big"
+)
+
+// rlpBytes returns the RLP encoding of the given bytes
+func rlpBytes(b []byte) []byte {
+	if len(b) == 1 && b[0] < 0x80 {
+		return []byte{b[0]}
+	}
+	return append(rlpHeader(0x80, len(b)), b...)
+}
+
+// rlpUint returns the RLP encoding of the given integer, big-endian without leading zeros
+func rlpUint(i uint64) []byte {
+	return rlpBytes(new(big.Int).SetUint64(i).Bytes())
+}
+
+// rlpBigInt returns the RLP encoding of the given non-negative integer, nil is encoded as zero
+func rlpBigInt(i *big.Int) []byte {
+	if i == nil {
+		return rlpBytes(nil)
+	}
+	return rlpBytes(i.Bytes())
+}
+
+// rlpList returns the RLP encoding of the list of the given encoded items
+func rlpList(items ...[]byte) []byte {
+	var payload []byte
+	for _, item := range items {
+		payload = append(payload, item...)
+	}
+	return append(rlpHeader(0xc0, len(payload)), payload...)
+}
+
+// rlpHeader returns the header of a string (0x80 offset) or list (0xc0 offset) payload of the given length
+func rlpHeader(offset byte, length int) []byte {
+	if length <= 55 {
+		return []byte{offset + byte(length)}
+	}
+	lengthBytes := new(big.Int).SetUint64(uint64(length)).Bytes()
+	return append([]byte{offset + 55 + byte(len(lengthBytes))}, lengthBytes...)
+}
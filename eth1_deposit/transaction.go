@@ -0,0 +1,84 @@
+package eth1deposit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"golang.org/x/crypto/sha3"
+)
+
+// DynamicFeeTxType is the EIP-1559 transaction type
+const DynamicFeeTxType = byte(2)
+
+// Transaction is an unsigned EIP-1559 transaction with an empty access list, to be signed offline.
+type Transaction struct {
+	ChainID              uint64
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	Gas                  uint64
+	To                   bellatrix.ExecutionAddress
+	Value                *big.Int
+	Data                 []byte
+}
+
+// MarshalBinary returns the unsigned transaction, 0x02 || rlp([chain_id, nonce, max_priority_fee_per_gas, max_fee_per_gas, gas_limit, destination, amount, data, access_list]).
+// Signers sign its keccak256 hash, see SigningHash.
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	payload := rlpList(
+		rlpUint(tx.ChainID),
+		rlpUint(tx.Nonce),
+		rlpBigInt(tx.MaxPriorityFeePerGas),
+		rlpBigInt(tx.MaxFeePerGas),
+		rlpUint(tx.Gas),
+		rlpBytes(tx.To[:]),
+		rlpBigInt(tx.Value),
+		rlpBytes(tx.Data),
+		rlpList(),
+	)
+	return append([]byte{DynamicFeeTxType}, payload...), nil
+}
+
+// SigningHash returns the hash signed by the sender of the transaction
+func (tx *Transaction) SigningHash() ([32]byte, error) {
+	byts, err := tx.MarshalBinary()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return keccak256(byts), nil
+}
+
+// MarshalJSON returns the transaction in the JSON-RPC format, as accepted by eth_signTransaction
+func (tx *Transaction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":                 fmt.Sprintf("%#x", DynamicFeeTxType),
+		"chainId":              hexUint(new(big.Int).SetUint64(tx.ChainID)),
+		"nonce":                hexUint(new(big.Int).SetUint64(tx.Nonce)),
+		"maxPriorityFeePerGas": hexUint(tx.MaxPriorityFeePerGas),
+		"maxFeePerGas":         hexUint(tx.MaxFeePerGas),
+		"gas":                  hexUint(new(big.Int).SetUint64(tx.Gas)),
+		"to":                   "0x" + hex.EncodeToString(tx.To[:]),
+		"value":                hexUint(tx.Value),
+		"input":                "0x" + hex.EncodeToString(tx.Data),
+		"accessList":           []interface{}{},
+	})
+}
+
+// hexUint returns the JSON-RPC quantity of the given integer, nil is zero
+func hexUint(i *big.Int) string {
+	if i == nil {
+		return "0x0"
+	}
+	return "0x" + i.Text(16)
+}
+
+func keccak256(data []byte) [32]byte {
+	var ret [32]byte
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	copy(ret[:], h.Sum(nil))
+	return ret
+}
@@ -0,0 +1,171 @@
+package eth1deposit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// DepositVerification is the verification report of a deposit data entry, it's valid if it has no errors
+type DepositVerification struct {
+	Index    int      `json:"index"`
+	PubKey   string   `json:"pubkey"`
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ParseDepositDataJSON parses the deposits of a staking-deposit-cli deposit_data file
+func ParseDepositDataJSON(byts []byte) ([]*DepositDataJSON, error) {
+	var ret []*DepositDataJSON
+	if err := json.Unmarshal(byts, &ret); err != nil {
+		return nil, errors.Wrap(err, "failed to JSON un-marshal deposit data")
+	}
+	return ret, nil
+}
+
+// VerifyDepositData verifies every deposit for the given network and returns a report per deposit.
+// The deposit message and deposit data roots are recomputed, the signature is verified against the genesis fork domain,
+// the withdrawal credentials prefix and the amount are checked. Duplicate pubkeys are reported as warnings.
+func VerifyDepositData(deposits []*DepositDataJSON, network core.Network) ([]*DepositVerification, error) {
+	if !IsSupportedDepositNetwork(network) {
+		return nil, errors.Errorf("Network %s is not supported", network)
+	}
+	if err := core.InitBLS(); err != nil {
+		return nil, errors.Wrap(err, "failed to init BLS")
+	}
+
+	ret := make([]*DepositVerification, 0, len(deposits))
+	firstIndex := make(map[string]int)
+	for i, deposit := range deposits {
+		verification := &DepositVerification{
+			Index: i,
+		}
+		if deposit == nil {
+			verification.Errors = []string{"deposit is empty"}
+			ret = append(ret, verification)
+			continue
+		}
+
+		verification.PubKey = deposit.PubKey
+		verification.Errors = verifyDeposit(deposit, network)
+		verification.Valid = len(verification.Errors) == 0
+
+		pubKey := strings.ToLower(strings.TrimPrefix(deposit.PubKey, "0x"))
+		if first, found := firstIndex[pubKey]; found {
+			verification.Warnings = append(verification.Warnings, fmt.Sprintf("duplicate pubkey, also deposited by entry %d", first))
+		} else {
+			firstIndex[pubKey] = i
+		}
+		ret = append(ret, verification)
+	}
+	return ret, nil
+}
+
+// verifyDeposit returns the verification errors of the deposit
+func verifyDeposit(deposit *DepositDataJSON, network core.Network) []string {
+	var ret []string
+
+	pubKey, err := decodeHex(deposit.PubKey, phase0.PublicKeyLength)
+	if err != nil {
+		ret = append(ret, "invalid pubkey: "+err.Error())
+	}
+	withdrawalCredentials, err := decodeHex(deposit.WithdrawalCredentials, 32)
+	if err != nil {
+		ret = append(ret, "invalid withdrawal_credentials: "+err.Error())
+	}
+	signature, err := decodeHex(deposit.Signature, phase0.SignatureLength)
+	if err != nil {
+		ret = append(ret, "invalid signature: "+err.Error())
+	}
+	if len(ret) > 0 {
+		return ret
+	}
+
+	if expected := DepositNetworkName(network); deposit.NetworkName != expected {
+		ret = append(ret, fmt.Sprintf("network_name %s doesn't match %s", deposit.NetworkName, expected))
+	}
+	forkVersion := network.GenesisForkVersion()
+	if expected := hex.EncodeToString(forkVersion[:]); strings.TrimPrefix(deposit.ForkVersion, "0x") != expected {
+		ret = append(ret, fmt.Sprintf("fork_version %s doesn't match the %s genesis fork version %s", deposit.ForkVersion, network, expected))
+	}
+
+	// the amount limits depend on the withdrawal credentials prefix, which is validated first
+	if err := ValidateDepositAmount(withdrawalCredentials, phase0.Gwei(deposit.Amount)); err != nil {
+		ret = append(ret, err.Error())
+	}
+	if withdrawalCredentials[0] == ExecutionAddressWithdrawalPrefixByte || withdrawalCredentials[0] == CompoundingWithdrawalPrefixByte {
+		for _, b := range withdrawalCredentials[1:12] {
+			if b != 0 {
+				ret = append(ret, "execution withdrawal credentials must have 11 zero bytes after the prefix")
+				break
+			}
+		}
+	}
+
+	depositData := &phase0.DepositData{
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                phase0.Gwei(deposit.Amount),
+	}
+	copy(depositData.PublicKey[:], pubKey)
+	copy(depositData.Signature[:], signature)
+
+	messageRoot, err := DepositMessageRoot(depositData)
+	if err != nil {
+		return append(ret, err.Error())
+	}
+	if expected := hex.EncodeToString(messageRoot[:]); strings.TrimPrefix(deposit.DepositMessageRoot, "0x") != expected {
+		ret = append(ret, fmt.Sprintf("deposit_message_root %s doesn't match the computed root %s", deposit.DepositMessageRoot, expected))
+	}
+	dataRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		return append(ret, errors.Wrap(err, "failed to determine the root hash of deposit data").Error())
+	}
+	if expected := hex.EncodeToString(dataRoot[:]); strings.TrimPrefix(deposit.DepositDataRoot, "0x") != expected {
+		ret = append(ret, fmt.Sprintf("deposit_data_root %s doesn't match the computed root %s", deposit.DepositDataRoot, expected))
+	}
+
+	signingRoot, err := depositSigningRoot(messageRoot, network)
+	if err != nil {
+		return append(ret, err.Error())
+	}
+	if err := verifySignature(pubKey, signature, signingRoot[:]); err != nil {
+		ret = append(ret, err.Error())
+	}
+	return ret
+}
+
+// verifySignature returns an error if the signature of the message doesn't match the public key
+func verifySignature(pubKey []byte, signature []byte, msg []byte) error {
+	var pk bls.PublicKey
+	if err := pk.Deserialize(pubKey); err != nil {
+		return errors.Wrap(err, "invalid pubkey")
+	}
+	var sig bls.Sign
+	if err := sig.Deserialize(signature); err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !sig.VerifyByte(&pk, msg) {
+		return errors.New("signature doesn't match the pubkey and the genesis fork domain")
+	}
+	return nil
+}
+
+// decodeHex decodes the hex value, with or without 0x prefix, of the given length
+func decodeHex(value string, length int) ([]byte, error) {
+	ret, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) != length {
+		return nil, errors.Errorf("expected %d bytes, got %d", length, len(ret))
+	}
+	return ret, nil
+}
@@ -0,0 +1,185 @@
+package eth1request
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
+)
+
+const (
+	// WithdrawalRequestContractAddress is the EIP-7002 withdrawal request system contract, the same on all networks
+	WithdrawalRequestContractAddress = "0x00000961Ef480Eb55e80D19ad83579A64c007002"
+
+	// ConsolidationRequestContractAddress is the EIP-7251 consolidation request system contract, the same on all networks
+	ConsolidationRequestContractAddress = "0x0000BBdDc7CE488642fb579F8B00f3a590007251"
+
+	// RequestGasLimit is the gas limit of request transactions
+	RequestGasLimit = 200000
+)
+
+// WithdrawalRequest is an EIP-7002 withdrawal request, sent by the execution address of the validator withdrawal credentials.
+// An amount of 0 requests a full exit, other amounts a partial withdrawal.
+type WithdrawalRequest struct {
+	SourceAddress   bellatrix.ExecutionAddress
+	ValidatorPubkey phase0.BLSPubKey
+	Amount          phase0.Gwei
+}
+
+// NewWithdrawalRequest returns the withdrawal request of the given account, whose withdrawal credentials are the ones of the beacon chain.
+// Partial withdrawals require compounding (0x02) withdrawal credentials.
+func NewWithdrawalRequest(account core.ValidatorAccount, withdrawalCredentials []byte, amount phase0.Gwei) (*WithdrawalRequest, error) {
+	sourceAddress, err := ExecutionAddress(withdrawalCredentials)
+	if err != nil {
+		return nil, err
+	}
+	if amount > 0 && withdrawalCredentials[0] != eth1deposit.CompoundingWithdrawalPrefixByte {
+		return nil, errors.New("partial withdrawals require compounding (0x02) withdrawal credentials")
+	}
+
+	ret := &WithdrawalRequest{
+		SourceAddress: sourceAddress,
+		Amount:        amount,
+	}
+	copy(ret.ValidatorPubkey[:], account.ValidatorPublicKey())
+	return ret, nil
+}
+
+// CallData returns the call data of the withdrawal request contract, validator_pubkey || amount as big-endian uint64
+func (r *WithdrawalRequest) CallData() []byte {
+	ret := append([]byte{}, r.ValidatorPubkey[:]...)
+	return binary.BigEndian.AppendUint64(ret, uint64(r.Amount))
+}
+
+// Transaction returns the unsigned withdrawal request transaction paying the given fee in wei, to be signed by the source address.
+// The fee is read with an eth_call to the contract, its nonce and gas fees are set by the caller.
+func (r *WithdrawalRequest) Transaction(network core.Network, fee *big.Int) (*eth1deposit.Transaction, error) {
+	return requestTransaction(network, WithdrawalRequestContractAddress, r.CallData(), fee)
+}
+
+// ConsolidationRequest is an EIP-7251 consolidation request, sent by the execution address of the source validator withdrawal credentials.
+// A request whose source is its target switches the validator to compounding withdrawal credentials.
+type ConsolidationRequest struct {
+	SourceAddress bellatrix.ExecutionAddress
+	SourcePubkey  phase0.BLSPubKey
+	TargetPubkey  phase0.BLSPubKey
+}
+
+// ConsolidationOptions are the policy checks of consolidation requests, which aren't protocol rules
+type ConsolidationOptions struct {
+	AllowForeignTarget bool
+}
+
+// ConsolidationOption sets an option of consolidation requests
+type ConsolidationOption func(*ConsolidationOptions)
+
+// WithForeignTarget allows consolidating into a target of another withdrawal address, whose owner then gets the consolidated balance
+func WithForeignTarget() ConsolidationOption {
+	return func(options *ConsolidationOptions) {
+		options.AllowForeignTarget = true
+	}
+}
+
+// NewConsolidationRequest returns the consolidation request of the given source account into the target validator,
+// both withdrawal credentials are the ones of the beacon chain.
+// The target must have compounding (0x02) withdrawal credentials and a switch to compounding credentials requires 0x01 source credentials.
+// By policy, not a protocol rule, the target must also have the source withdrawal address so the consolidated balance keeps its owner,
+// WithForeignTarget lifts it.
+func NewConsolidationRequest(source core.ValidatorAccount, sourceWithdrawalCredentials []byte, targetPubkey phase0.BLSPubKey, targetWithdrawalCredentials []byte, opts ...ConsolidationOption) (*ConsolidationRequest, error) {
+	options := &ConsolidationOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	sourceAddress, err := ExecutionAddress(sourceWithdrawalCredentials)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid source withdrawal credentials")
+	}
+
+	ret := &ConsolidationRequest{
+		SourceAddress: sourceAddress,
+		TargetPubkey:  targetPubkey,
+	}
+	copy(ret.SourcePubkey[:], source.ValidatorPublicKey())
+
+	if ret.SourcePubkey == targetPubkey {
+		if !bytes.Equal(sourceWithdrawalCredentials, targetWithdrawalCredentials) {
+			return nil, errors.New("source and target are the same validator but their withdrawal credentials differ")
+		}
+		if sourceWithdrawalCredentials[0] != eth1deposit.ExecutionAddressWithdrawalPrefixByte {
+			return nil, errors.New("switching to compounding withdrawal credentials requires execution address (0x01) withdrawal credentials")
+		}
+		return ret, nil
+	}
+
+	targetAddress, err := ExecutionAddress(targetWithdrawalCredentials)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid target withdrawal credentials")
+	}
+	if targetWithdrawalCredentials[0] != eth1deposit.CompoundingWithdrawalPrefixByte {
+		return nil, errors.New("consolidation target requires compounding (0x02) withdrawal credentials")
+	}
+	if targetAddress != sourceAddress && !options.AllowForeignTarget {
+		return nil, errors.Errorf("target withdrawal address %#x doesn't match the source withdrawal address %#x", targetAddress, sourceAddress)
+	}
+	return ret, nil
+}
+
+// CallData returns the call data of the consolidation request contract, source_pubkey || target_pubkey
+func (r *ConsolidationRequest) CallData() []byte {
+	return append(append([]byte{}, r.SourcePubkey[:]...), r.TargetPubkey[:]...)
+}
+
+// Transaction returns the unsigned consolidation request transaction paying the given fee in wei, to be signed by the source address.
+// The fee is read with an eth_call to the contract, its nonce and gas fees are set by the caller.
+func (r *ConsolidationRequest) Transaction(network core.Network, fee *big.Int) (*eth1deposit.Transaction, error) {
+	return requestTransaction(network, ConsolidationRequestContractAddress, r.CallData(), fee)
+}
+
+// ExecutionAddress returns the execution address of execution address (0x01) or compounding (0x02) withdrawal credentials
+func ExecutionAddress(withdrawalCredentials []byte) (bellatrix.ExecutionAddress, error) {
+	var ret bellatrix.ExecutionAddress
+	if len(withdrawalCredentials) != 32 {
+		return ret, errors.New("withdrawal credentials must be 32 bytes")
+	}
+	if withdrawalCredentials[0] != eth1deposit.ExecutionAddressWithdrawalPrefixByte && withdrawalCredentials[0] != eth1deposit.CompoundingWithdrawalPrefixByte {
+		return ret, errors.Errorf("requests require execution address (0x01) or compounding (0x02) withdrawal credentials, got prefix %#02x", withdrawalCredentials[0])
+	}
+	if !bytes.Equal(withdrawalCredentials[1:12], make([]byte, 11)) {
+		return ret, errors.New("execution withdrawal credentials must have 11 zero bytes after the prefix")
+	}
+	copy(ret[:], withdrawalCredentials[12:])
+	return ret, nil
+}
+
+// requestTransaction returns the unsigned transaction of a request system contract call
+func requestTransaction(network core.Network, contractAddress string, data []byte, fee *big.Int) (*eth1deposit.Transaction, error) {
+	cfg, err := network.Config()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ChainID == 0 {
+		return nil, errors.Errorf("chain id of network %s is unknown", network)
+	}
+	if fee == nil || fee.Sign() <= 0 {
+		return nil, errors.New("request fee must be positive")
+	}
+	to, err := eth1deposit.ParseExecutionAddress(contractAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid request contract address")
+	}
+
+	return &eth1deposit.Transaction{
+		ChainID: cfg.ChainID,
+		Gas:     RequestGasLimit,
+		To:      to,
+		Value:   fee,
+		Data:    data,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package eth1request
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+)
+
+func testAccounts(t *testing.T) (core.ValidatorAccount, core.ValidatorAccount) {
+	require.NoError(t, core.InitBLS())
+	options := &eth2keymanager.KeyVaultOptions{}
+	options.SetStorage(inmemory.NewInMemStore(core.MainNetwork))
+	vault, err := eth2keymanager.NewKeyVault(options)
+	require.NoError(t, err)
+	wallet, err := vault.Wallet()
+	require.NoError(t, err)
+
+	seed, err := hex.DecodeString("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")
+	require.NoError(t, err)
+	source, err := wallet.CreateValidatorAccount(seed, nil)
+	require.NoError(t, err)
+	target, err := wallet.CreateValidatorAccount(seed, nil)
+	require.NoError(t, err)
+	return source, target
+}
+
+func TestWithdrawalRequest(t *testing.T) {
+	account, _ := testAccounts(t)
+	address := bellatrix.ExecutionAddress{0xd8, 0xda, 0x6b, 0xf2, 0x69, 0x64, 0xaf, 0x9d, 0x7e, 0xed, 0x9e, 0x03, 0xe5, 0x34, 0x15, 0xd3, 0x7a, 0xa9, 0x60, 0x45}
+
+	t.Run("full exit", func(t *testing.T) {
+		request, err := NewWithdrawalRequest(account, eth1deposit.ExecutionWithdrawalCredentials(address, false), 0)
+		require.NoError(t, err)
+		require.Equal(t, address, request.SourceAddress)
+		require.Equal(t, append(account.ValidatorPublicKey(), make([]byte, 8)...), request.CallData())
+
+		tx, err := request.Transaction(core.MainNetwork, big.NewInt(1))
+		require.NoError(t, err)
+		require.EqualValues(t, 1, tx.ChainID)
+		require.EqualValues(t, RequestGasLimit, tx.Gas)
+		require.Equal(t, "00000961ef480eb55e80d19ad83579a64c007002", hex.EncodeToString(tx.To[:]))
+		require.Equal(t, "1", tx.Value.String())
+		require.Equal(t, request.CallData(), tx.Data)
+	})
+
+	t.Run("partial withdrawal", func(t *testing.T) {
+		request, err := NewWithdrawalRequest(account, eth1deposit.ExecutionWithdrawalCredentials(address, true), 1000000000)
+		require.NoError(t, err)
+		require.Len(t, request.CallData(), 56)
+		require.Equal(t, "000000003b9aca00", hex.EncodeToString(request.CallData()[48:]))
+	})
+
+	t.Run("partial withdrawal without compounding credentials", func(t *testing.T) {
+		_, err := NewWithdrawalRequest(account, eth1deposit.ExecutionWithdrawalCredentials(address, false), 1000000000)
+		require.EqualError(t, err, "partial withdrawals require compounding (0x02) withdrawal credentials")
+	})
+
+	t.Run("BLS credentials", func(t *testing.T) {
+		_, err := NewWithdrawalRequest(account, eth1deposit.BLSWithdrawalCredentials(account.WithdrawalPublicKey()), 0)
+		require.EqualError(t, err, "requests require execution address (0x01) or compounding (0x02) withdrawal credentials, got prefix 0x00")
+	})
+
+	t.Run("no fee", func(t *testing.T) {
+		request, err := NewWithdrawalRequest(account, eth1deposit.ExecutionWithdrawalCredentials(address, false), 0)
+		require.NoError(t, err)
+		_, err = request.Transaction(core.MainNetwork, big.NewInt(0))
+		require.EqualError(t, err, "request fee must be positive")
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		request, err := NewWithdrawalRequest(account, eth1deposit.ExecutionWithdrawalCredentials(address, false), 0)
+		require.NoError(t, err)
+		_, err = request.Transaction("unknown", big.NewInt(1))
+		require.EqualError(t, err, "unknown network unknown")
+	})
+}
+
+func TestConsolidationRequest(t *testing.T) {
+	source, target := testAccounts(t)
+	var targetPubkey phase0.BLSPubKey
+	copy(targetPubkey[:], target.ValidatorPublicKey())
+	var sourcePubkey phase0.BLSPubKey
+	copy(sourcePubkey[:], source.ValidatorPublicKey())
+	address := bellatrix.ExecutionAddress{0xd8, 0xda, 0x6b, 0xf2, 0x69, 0x64, 0xaf, 0x9d, 0x7e, 0xed, 0x9e, 0x03, 0xe5, 0x34, 0x15, 0xd3, 0x7a, 0xa9, 0x60, 0x45}
+	otherAddress := bellatrix.ExecutionAddress{0x01}
+
+	t.Run("consolidation", func(t *testing.T) {
+		request, err := NewConsolidationRequest(source, eth1deposit.ExecutionWithdrawalCredentials(address, false), targetPubkey, eth1deposit.ExecutionWithdrawalCredentials(address, true))
+		require.NoError(t, err)
+		require.Equal(t, append(source.ValidatorPublicKey(), target.ValidatorPublicKey()...), request.CallData())
+
+		tx, err := request.Transaction(core.HoleskyNetwork, big.NewInt(2))
+		require.NoError(t, err)
+		require.EqualValues(t, 17000, tx.ChainID)
+		require.Equal(t, "0000bbddc7ce488642fb579f8b00f3a590007251", hex.EncodeToString(tx.To[:]))
+		require.Equal(t, "2", tx.Value.String())
+	})
+
+	t.Run("switch to compounding credentials", func(t *testing.T) {
+		credentials := eth1deposit.ExecutionWithdrawalCredentials(address, false)
+		request, err := NewConsolidationRequest(source, credentials, sourcePubkey, credentials)
+		require.NoError(t, err)
+		require.Equal(t, request.SourcePubkey, request.TargetPubkey)
+
+		credentials = eth1deposit.ExecutionWithdrawalCredentials(address, true)
+		_, err = NewConsolidationRequest(source, credentials, sourcePubkey, credentials)
+		require.EqualError(t, err, "switching to compounding withdrawal credentials requires execution address (0x01) withdrawal credentials")
+	})
+
+	t.Run("target without compounding credentials", func(t *testing.T) {
+		_, err := NewConsolidationRequest(source, eth1deposit.ExecutionWithdrawalCredentials(address, true), targetPubkey, eth1deposit.ExecutionWithdrawalCredentials(address, false))
+		require.EqualError(t, err, "consolidation target requires compounding (0x02) withdrawal credentials")
+	})
+
+	t.Run("target of another address", func(t *testing.T) {
+		_, err := NewConsolidationRequest(source, eth1deposit.ExecutionWithdrawalCredentials(address, false), targetPubkey, eth1deposit.ExecutionWithdrawalCredentials(otherAddress, true))
+		require.EqualError(t, err, "target withdrawal address 0x0100000000000000000000000000000000000000 doesn't match the source withdrawal address 0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+
+		request, err := NewConsolidationRequest(source, eth1deposit.ExecutionWithdrawalCredentials(address, false), targetPubkey, eth1deposit.ExecutionWithdrawalCredentials(otherAddress, true), WithForeignTarget())
+		require.NoError(t, err)
+		require.Equal(t, targetPubkey, request.TargetPubkey)
+	})
+
+	t.Run("source with BLS credentials", func(t *testing.T) {
+		_, err := NewConsolidationRequest(source, eth1deposit.BLSWithdrawalCredentials(source.WithdrawalPublicKey()), targetPubkey, eth1deposit.ExecutionWithdrawalCredentials(address, true))
+		require.EqualError(t, err, "invalid source withdrawal credentials: requests require execution address (0x01) or compounding (0x02) withdrawal credentials, got prefix 0x00")
+	})
+}
@@ -1,11 +1,15 @@
 package eth2keymanager
 
 import (
+	"io"
+
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ssvlabs/eth2-key-manager/core"
+	encryptor2 "github.com/ssvlabs/eth2-key-manager/encryptor"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
 	"github.com/ssvlabs/eth2-key-manager/wallets/hd"
 	"github.com/ssvlabs/eth2-key-manager/wallets/nd"
 
@@ -27,8 +31,9 @@ func InitCrypto() {
 // https://github.com/ethereum/EIPs/blob/master/EIPS/eip-2334.md
 // https://eips.ethereum.org/EIPS/eip-2335
 type KeyVault struct {
-	Context  *core.WalletContext
-	walletID uuid.UUID
+	Context   *core.WalletContext
+	walletID  uuid.UUID
+	encryptor encryptor2.Encryptor
 }
 
 // Wallet returns wallet
@@ -36,6 +41,75 @@ func (kv *KeyVault) Wallet() (core.Wallet, error) {
 	return kv.Context.Storage.OpenWallet()
 }
 
+// Lock zeroizes the decrypted account keys, signing with an encrypted key fails with core.ErrKeyVaultLocked until Unlock is called.
+// Plain keys aren't affected by the lock, so a vault without an encryptor can't be locked.
+func (kv *KeyVault) Lock() error {
+	cache, err := kv.keyCache()
+	if err != nil {
+		return err
+	}
+	if kv.encryptor == nil {
+		return errors.New("no encryptor was set")
+	}
+	cache.Lock()
+	return nil
+}
+
+// Unlock sets the password decrypting the account keys.
+// It's checked by decrypting an encrypted account key first, the vault is left as is if it's wrong.
+func (kv *KeyVault) Unlock(password string) error {
+	cache, err := kv.keyCache()
+	if err != nil {
+		return err
+	}
+	if kv.encryptor == nil {
+		return errors.New("no encryptor was set")
+	}
+
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return errors.Wrap(err, "failed to open wallet")
+	}
+	decrypt := core.NewKeyDecryptor(kv.encryptor, []byte(password))
+	checked := false
+	for _, account := range wallet.Accounts() {
+		encrypted, err := wallets.CheckAccountDecryptor(account, decrypt)
+		if err != nil {
+			return errors.Wrap(err, "failed to unlock key vault")
+		}
+		if encrypted {
+			checked = true
+			break
+		}
+	}
+	if !checked {
+		return errors.New("failed to unlock key vault: no encrypted account to check the password with")
+	}
+
+	kv.Context.Storage.SetEncryptor(kv.encryptor, []byte(password))
+	cache.Unlock()
+	return nil
+}
+
+// Close zeroizes the decrypted account keys and closes the storage, if it can be closed.
+func (kv *KeyVault) Close() error {
+	if cache, err := kv.keyCache(); err == nil {
+		cache.Lock()
+	}
+	if closer, ok := kv.Context.Storage.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (kv *KeyVault) keyCache() (*core.KeyCache, error) {
+	storage, ok := kv.Context.Storage.(core.KeyCacheStorage)
+	if !ok {
+		return nil, errors.Errorf("storage %s doesn't support locking", kv.Context.Storage.Name())
+	}
+	return storage.KeyCache(), nil
+}
+
 // OpenKeyVault opens an existing KeyVault (and wallet) from memory
 func OpenKeyVault(options *KeyVaultOptions) (*KeyVault, error) {
 	storage, err := setupStorage(options)
@@ -55,8 +129,9 @@ func OpenKeyVault(options *KeyVaultOptions) (*KeyVault, error) {
 	}
 
 	return &KeyVault{
-		Context:  context,
-		walletID: wallet.ID(),
+		Context:   context,
+		walletID:  wallet.ID(),
+		encryptor: options.encryptor,
 	}, nil
 }
 
@@ -82,8 +157,9 @@ func NewKeyVault(options *KeyVaultOptions) (*KeyVault, error) {
 	}
 
 	ret := &KeyVault{
-		Context:  context,
-		walletID: wallet.ID(),
+		Context:   context,
+		walletID:  wallet.ID(),
+		encryptor: options.encryptor,
 	}
 
 	storage, ok := options.storage.(core.Storage)
@@ -108,6 +184,14 @@ func setupStorage(options *KeyVaultOptions) (core.Storage, error) {
 		storage.SetEncryptor(options.encryptor, options.password)
 	}
 
+	if options.keyCacheTTL != core.KeepDecryptedKeys {
+		cacheStorage, ok := storage.(core.KeyCacheStorage)
+		if !ok {
+			return nil, errors.Errorf("storage %s doesn't support a key cache TTL", storage.Name())
+		}
+		cacheStorage.KeyCache().SetTTL(options.keyCacheTTL)
+	}
+
 	return storage, nil
 }
 
@@ -0,0 +1,85 @@
+package eth2keymanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+)
+
+func TestKeyVaultLock(t *testing.T) {
+	options := &KeyVaultOptions{}
+	options.SetStorage(inmemStorage()).
+		SetEncryptor(keystorev4.New(keystorev4.WithCipher("pbkdf2"))).
+		SetPassword("password").
+		SetKeyCacheTTL(time.Minute)
+	vault, err := NewKeyVault(options)
+	require.NoError(t, err)
+	wallet, err := vault.Wallet()
+	require.NoError(t, err)
+	created, err := wallet.CreateValidatorAccount(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff"), nil)
+	require.NoError(t, err)
+
+	// the stored account holds the encrypted key only
+	account, err := wallet.AccountByID(created.ID())
+	require.NoError(t, err)
+	expected, err := account.ValidationKeySign([]byte("data"))
+	require.NoError(t, err)
+
+	require.NoError(t, vault.Lock())
+	_, err = account.ValidationKeySign([]byte("data"))
+	require.EqualError(t, err, "key vault is locked")
+
+	require.EqualError(t, vault.Unlock("wrong"), "failed to unlock key vault: failed to decrypt key: invalid checksum")
+	_, err = account.ValidationKeySign([]byte("data"))
+	require.EqualError(t, err, "key vault is locked")
+
+	require.NoError(t, vault.Unlock("password"))
+	sig, err := account.ValidationKeySign([]byte("data"))
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+
+	// a wrong password leaves the unlocked vault as is
+	require.EqualError(t, vault.Unlock("wrong"), "failed to unlock key vault: failed to decrypt key: invalid checksum")
+	account, err = wallet.AccountByID(created.ID())
+	require.NoError(t, err)
+	sig, err = account.ValidationKeySign([]byte("data"))
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+
+	require.NoError(t, vault.Close())
+	_, err = account.ValidationKeySign([]byte("data"))
+	require.EqualError(t, err, "key vault is locked")
+}
+
+func TestKeyVaultUnlockWithoutAccounts(t *testing.T) {
+	options := &KeyVaultOptions{}
+	options.SetStorage(inmemStorage()).
+		SetEncryptor(keystorev4.New(keystorev4.WithCipher("pbkdf2"))).
+		SetPassword("password")
+	vault, err := NewKeyVault(options)
+	require.NoError(t, err)
+
+	require.NoError(t, vault.Lock())
+	require.EqualError(t, vault.Unlock("any"), "failed to unlock key vault: no encrypted account to check the password with")
+}
+
+func TestKeyVaultLockWithoutEncryptor(t *testing.T) {
+	options := &KeyVaultOptions{}
+	options.SetStorage(inmemStorage())
+	vault, err := NewKeyVault(options)
+	require.NoError(t, err)
+
+	// plain keys sign regardless of the lock
+	require.EqualError(t, vault.Lock(), "no encryptor was set")
+	require.EqualError(t, vault.Unlock("password"), "no encryptor was set")
+	require.NoError(t, vault.Close())
+
+	options.SetStorage(&struct{ core.Storage }{inmemStorage()})
+	vault, err = NewKeyVault(options)
+	require.NoError(t, err)
+	require.EqualError(t, vault.Lock(), "storage in-memory doesn't support locking")
+}
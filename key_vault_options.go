@@ -1,6 +1,8 @@
 package eth2keymanager
 
 import (
+	"time"
+
 	"github.com/ssvlabs/eth2-key-manager/core"
 	encryptor2 "github.com/ssvlabs/eth2-key-manager/encryptor"
 )
@@ -11,6 +13,8 @@ type KeyVaultOptions struct {
 	password   []byte
 	storage    interface{} // a generic interface as there are a few core storage interfaces (storage, slashing storage and so on)
 	walletType core.WalletType
+
+	keyCacheTTL time.Duration
 }
 
 // SetEncryptor is the encryptor setter
@@ -36,3 +40,10 @@ func (options *KeyVaultOptions) SetWalletType(walletType core.WalletType) *KeyVa
 	options.walletType = walletType
 	return options
 }
+
+// SetKeyCacheTTL sets how long decrypted account keys are kept before being zeroized.
+// core.NoKeyCaching decrypts keys for every signature, by default they are kept until the key vault is locked.
+func (options *KeyVaultOptions) SetKeyCacheTTL(ttl time.Duration) *KeyVaultOptions {
+	options.keyCacheTTL = ttl
+	return options
+}
@@ -0,0 +1,128 @@
+package eth2keymanager
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
+	"github.com/ssvlabs/eth2-key-manager/wallets/hd"
+)
+
+// validatorKeyPath matches EIP-2334 validator key paths, m/12381/3600/<account index>/0/0
+var validatorKeyPath = regexp.MustCompile(`^m/12381/3600/(\d+)/0/0$`)
+
+// AccountExistsError is returned when importing the keystore of a key the wallet already holds
+type AccountExistsError struct {
+	PubKey string
+}
+
+// Error implements error
+func (e *AccountExistsError) Error() string {
+	return fmt.Sprintf("account %s already exists", e.PubKey)
+}
+
+// KeystoreImportResult is the result of importing a single keystore file, Err is set if it was not imported
+type KeystoreImportResult struct {
+	File    string
+	Account core.ValidatorAccount
+	Err     error
+}
+
+// ImportKeystoreDir imports every EIP-2335 keystore file (keystore*.json) of the directory, decrypted with the given password.
+// A failing file doesn't fail the others, results are sorted by file name.
+func (kv *KeyVault) ImportKeystoreDir(dir string, password string) ([]*KeystoreImportResult, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "keystore*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list keystore files")
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("no keystore files found in %s", dir)
+	}
+	sort.Strings(files)
+
+	ret := make([]*KeystoreImportResult, len(files))
+	for i, file := range files {
+		ret[i] = &KeystoreImportResult{File: file}
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			ret[i].Err = errors.Wrap(err, "failed to read keystore file")
+			continue
+		}
+		ret[i].Account, ret[i].Err = kv.ImportKeystore(data, password)
+	}
+	return ret, nil
+}
+
+// ImportKeystore decrypts the given EIP-2335 keystore and adds its key to the wallet, keeping the keystore path and UUID.
+// Keys of EIP-2334 validator paths are added at their account index.
+// An *AccountExistsError is returned if the wallet already holds the key.
+func (kv *KeyVault) ImportKeystore(keystore []byte, password string) (core.ValidatorAccount, error) {
+	wallet, err := kv.Wallet()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open wallet")
+	}
+	return kv.ImportKeystoreTo(wallet, keystore, password)
+}
+
+// ImportKeystoreTo imports the keystore like ImportKeystore, to the given wallet instance of the vault.
+// Signers sharing the wallet instance are able to sign with the key right away.
+func (kv *KeyVault) ImportKeystoreTo(wallet core.Wallet, keystore []byte, password string) (core.ValidatorAccount, error) {
+	ks, err := keystorev4.ParseKeystore(keystore)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := ks.Decrypt(password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt keystore")
+	}
+	defer func() {
+		for i := range secret {
+			secret[i] = 0
+		}
+	}()
+	key, err := core.NewHDKeyFromPrivateKey(secret, ks.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create key")
+	}
+
+	pubKey := hex.EncodeToString(key.PublicKey().Serialize())
+	if len(ks.PubKey) > 0 && strings.TrimPrefix(ks.PubKey, "0x") != pubKey {
+		return nil, errors.New("keystore public key does not match its secret key")
+	}
+
+	if account, err := wallet.AccountByPublicKey(pubKey); err == nil && account != nil {
+		return nil, &AccountExistsError{PubKey: pubKey}
+	}
+
+	id := ks.UUID
+	if id == uuid.Nil {
+		id = uuid.New()
+	} else if account, err := wallet.AccountByID(id); err == nil && account != nil {
+		return nil, errors.Errorf("account id %s already exists", id)
+	}
+	name, basePath := pubKey, ""
+	if match := validatorKeyPath.FindStringSubmatch(ks.Path); match != nil {
+		index, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid account index")
+		}
+		name, basePath = fmt.Sprintf("account-%d", index), fmt.Sprintf(hd.BaseAccountPath, index)
+	}
+
+	account := wallets.NewValidatorAccountWithID(id, name, key, nil, basePath, kv.Context)
+	if err := wallet.AddValidatorAccount(account); err != nil {
+		return nil, errors.Wrap(err, "failed to add account")
+	}
+	return account, nil
+}
@@ -0,0 +1,93 @@
+package eth2keymanager
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+)
+
+func writeTestKeystore(t *testing.T, file string, secret []byte, path string, password string) *keystorev4.Keystore {
+	key, err := core.NewHDKeyFromPrivateKey(secret, path)
+	require.NoError(t, err)
+	crypto, err := keystorev4.New(keystorev4.WithCipher("pbkdf2")).Encrypt(secret, password)
+	require.NoError(t, err)
+
+	ks := &keystorev4.Keystore{
+		Crypto:  crypto,
+		PubKey:  hex.EncodeToString(key.PublicKey().Serialize()),
+		Path:    path,
+		UUID:    uuid.New(),
+		Version: 4,
+	}
+	byts, err := json.Marshal(ks)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, byts, 0600))
+	return ks
+}
+
+func TestImportKeystoreDir(t *testing.T) {
+	for _, walletType := range []core.WalletType{core.NDWallet, core.HDWallet} {
+		t.Run(walletType, func(t *testing.T) {
+			dir := t.TempDir()
+			ks0 := writeTestKeystore(t, filepath.Join(dir, "keystore-m_12381_3600_0_0_0-1.json"), _byteArray("2c083f2c8fc923fa2bd32a70ab72b4b46247e8c1f347adc9fd2ac29e6b5b51ad"), "m/12381/3600/0/0/0", "password")
+			ks1 := writeTestKeystore(t, filepath.Join(dir, "keystore-m_12381_3600_5_0_0-1.json"), _byteArray("6327b1e58c41d60dd7c3c8b9634204255707c2d12e2513c345001d8926745eea"), "m/12381/3600/5/0/0", "password")
+			writeTestKeystore(t, filepath.Join(dir, "keystore-m_12381_3600_6_0_0-1.json"), _byteArray("5470813f7deef638dc531188ca89e36976d536f680e89849cd9077fd096e20bc"), "m/12381/3600/6/0/0", "other")
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "keystore-broken.json"), []byte("{"), 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "deposit_data-1.json"), []byte("[]"), 0600))
+
+			options := &KeyVaultOptions{}
+			options.SetStorage(inmemStorage()).SetWalletType(walletType)
+			vault, err := NewKeyVault(options)
+			require.NoError(t, err)
+
+			results, err := vault.ImportKeystoreDir(dir, "password")
+			require.NoError(t, err)
+			require.Len(t, results, 4)
+
+			require.Equal(t, filepath.Join(dir, "keystore-broken.json"), results[0].File)
+			require.Error(t, results[0].Err)
+			require.EqualError(t, results[3].Err, "failed to decrypt keystore: invalid checksum")
+
+			for i, ks := range []*keystorev4.Keystore{ks0, ks1} {
+				require.NoError(t, results[i+1].Err)
+				require.Equal(t, ks.UUID, results[i+1].Account.ID())
+				require.Equal(t, ks.PubKey, hex.EncodeToString(results[i+1].Account.ValidatorPublicKey()))
+			}
+			require.Equal(t, "/5", results[2].Account.BasePath())
+			require.Equal(t, "account-5", results[2].Account.Name())
+
+			wallet, err := vault.Wallet()
+			require.NoError(t, err)
+			require.Len(t, wallet.Accounts(), 2)
+			account, err := wallet.AccountByID(ks1.UUID)
+			require.NoError(t, err)
+			require.Equal(t, ks1.PubKey, hex.EncodeToString(account.ValidatorPublicKey()))
+
+			// importing again fails as the accounts exist
+			results, err = vault.ImportKeystoreDir(dir, "password")
+			require.NoError(t, err)
+			require.EqualError(t, results[1].Err, "account "+ks0.PubKey+" already exists")
+			var existsErr *AccountExistsError
+			require.ErrorAs(t, results[1].Err, &existsErr)
+		})
+	}
+
+	t.Run("no keystores", func(t *testing.T) {
+		options := &KeyVaultOptions{}
+		options.SetStorage(inmemStorage())
+		vault, err := NewKeyVault(options)
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		_, err = vault.ImportKeystoreDir(dir, "password")
+		require.EqualError(t, err, "no keystore files found in "+dir)
+	})
+}
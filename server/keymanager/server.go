@@ -0,0 +1,251 @@
+package keymanager
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/slashing_protection/interchange"
+)
+
+// Endpoint paths
+const (
+	KeystoresPath  = "/eth/v1/keystores"
+	RemoteKeysPath = "/eth/v1/remotekeys"
+)
+
+// Server implements the standard Keymanager API
+// https://ethereum.github.io/keymanager-APIs/
+type Server struct {
+	vault         *eth2keymanager.KeyVault
+	wallet        core.Wallet
+	slashingStore core.SlashingStore
+	network       core.Network
+	token         string
+
+	lock sync.Mutex
+	mux  *http.ServeMux
+}
+
+// New is the constructor of Server.
+// The wallet should be the same instance the signer uses so imported and deleted keys take effect without a restart.
+// Requests must carry the given bearer token, which can't be empty.
+func New(vault *eth2keymanager.KeyVault, wallet core.Wallet, slashingStore core.SlashingStore, token string) (*Server, error) {
+	if len(token) == 0 {
+		return nil, errors.New("keymanager API token is required")
+	}
+
+	s := &Server{
+		vault:         vault,
+		wallet:        wallet,
+		slashingStore: slashingStore,
+		network:       vault.Context.Storage.Network(),
+		token:         token,
+		mux:           http.NewServeMux(),
+	}
+	s.mux.HandleFunc(KeystoresPath, s.handleKeystores)
+	s.mux.HandleFunc(RemoteKeysPath, s.handleRemoteKeys)
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if len(auth) == 0 {
+		writeError(w, http.StatusUnauthorized, "missing authorization token")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(s.token)) != 1 {
+		writeError(w, http.StatusForbidden, "invalid authorization token")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleKeystores(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.listKeystores(w)
+	case http.MethodPost:
+		s.importKeystores(w, r)
+	case http.MethodDelete:
+		s.deleteKeystores(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleRemoteKeys(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		// all keys are held locally, there are never remote keys to list
+		writeJSON(w, http.StatusOK, &ListRemoteKeysResponse{Data: make([]*RemoteKeyData, 0)})
+	case http.MethodPost:
+		req := &ImportRemoteKeysRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid request body").Error())
+			return
+		}
+		ret := make([]*StatusData, len(req.RemoteKeys))
+		for i := range req.RemoteKeys {
+			ret[i] = &StatusData{Status: StatusError, Message: "remote keys are not supported"}
+		}
+		writeJSON(w, http.StatusOK, &StatusResponse{Data: ret})
+	case http.MethodDelete:
+		req := &DeleteKeysRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid request body").Error())
+			return
+		}
+		ret := make([]*StatusData, len(req.PubKeys))
+		for i := range req.PubKeys {
+			ret[i] = &StatusData{Status: StatusNotFound}
+		}
+		writeJSON(w, http.StatusOK, &StatusResponse{Data: ret})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) listKeystores(w http.ResponseWriter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ret := make([]*KeystoreData, 0)
+	for _, account := range s.wallet.Accounts() {
+		ret = append(ret, &KeystoreData{
+			ValidatingPubKey: "0x" + hex.EncodeToString(account.ValidatorPublicKey()),
+		})
+	}
+	writeJSON(w, http.StatusOK, &ListKeystoresResponse{Data: ret})
+}
+
+func (s *Server) importKeystores(w http.ResponseWriter, r *http.Request) {
+	req := &ImportKeystoresRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid request body").Error())
+		return
+	}
+	if len(req.Keystores) != len(req.Passwords) {
+		writeError(w, http.StatusBadRequest, "keystores and passwords count mismatch")
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// slashing protection data must be in place before the keys are able to sign
+	if len(req.SlashingProtection) > 0 {
+		if err := interchange.Import([]byte(req.SlashingProtection), s.slashingStore, s.network); err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "failed to import slashing protection data").Error())
+			return
+		}
+	}
+
+	ret := make([]*StatusData, len(req.Keystores))
+	for i := range req.Keystores {
+		ret[i] = s.importKeystore(req.Keystores[i], req.Passwords[i])
+	}
+	writeJSON(w, http.StatusOK, &StatusResponse{Data: ret})
+}
+
+func (s *Server) importKeystore(keystore string, password string) *StatusData {
+	if _, err := s.vault.ImportKeystoreTo(s.wallet, []byte(keystore), password); err != nil {
+		var existsErr *eth2keymanager.AccountExistsError
+		if errors.As(err, &existsErr) {
+			return &StatusData{Status: StatusDuplicate}
+		}
+		return errorStatus(err)
+	}
+	return &StatusData{Status: StatusImported}
+}
+
+func (s *Server) deleteKeystores(w http.ResponseWriter, r *http.Request) {
+	req := &DeleteKeysRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid request body").Error())
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ret := make([]*StatusData, len(req.PubKeys))
+	exported := make([][]byte, 0)
+	for i, pubKeyStr := range req.PubKeys {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(pubKeyStr, "0x"))
+		if err != nil {
+			ret[i] = errorStatus(errors.Wrap(err, "invalid public key"))
+			continue
+		}
+
+		ret[i] = s.deleteKey(pubKey)
+		if ret[i].Status == StatusDeleted || ret[i].Status == StatusNotActive {
+			exported = append(exported, pubKey)
+		}
+	}
+
+	// exported after deletion, though a signature still in flight when its key is deleted can be missing from the data
+	slashingProtection, err := interchange.ExportJSON(s.slashingStore, s.network, exported)
+	if err != nil {
+		logrus.WithError(err).Error("failed to export slashing protection data")
+		writeError(w, http.StatusInternalServerError, errors.Wrap(err, "failed to export slashing protection data").Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &DeleteKeystoresResponse{
+		Data:               ret,
+		SlashingProtection: string(slashingProtection),
+	})
+}
+
+func (s *Server) deleteKey(pubKey []byte) *StatusData {
+	if account, err := s.wallet.AccountByPublicKey(hex.EncodeToString(pubKey)); err != nil || account == nil {
+		found, err := s.hasSlashingData(pubKey)
+		if err != nil {
+			return errorStatus(err)
+		}
+		if found {
+			return &StatusData{Status: StatusNotActive}
+		}
+		return &StatusData{Status: StatusNotFound}
+	}
+
+	if err := s.wallet.DeleteAccountByPublicKey(hex.EncodeToString(pubKey)); err != nil {
+		return errorStatus(err)
+	}
+	return &StatusData{Status: StatusDeleted}
+}
+
+func (s *Server) hasSlashingData(pubKey []byte) (bool, error) {
+	_, found, err := s.slashingStore.RetrieveHighestAttestation(pubKey)
+	if err != nil || found {
+		return found, err
+	}
+	_, found, err = s.slashingStore.RetrieveHighestProposal(pubKey)
+	return found, err
+}
+
+func errorStatus(err error) *StatusData {
+	return &StatusData{Status: StatusError, Message: err.Error()}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, &ErrorResponse{Code: status, Message: message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		logrus.WithError(err).Error("failed to write response")
+	}
+}
@@ -0,0 +1,267 @@
+package keymanager
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
+	"github.com/ssvlabs/eth2-key-manager/signer"
+	prot "github.com/ssvlabs/eth2-key-manager/slashing_protection"
+	"github.com/ssvlabs/eth2-key-manager/slashing_protection/interchange"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+)
+
+const (
+	testSK     = "2c083f2c8fc923fa2bd32a70ab72b4b46247e8c1f347adc30b2f8036a355086c"
+	testPubKey = "a9cf360aa15fb1d1d30ee2b578dc5884823c19661886ae8b892775ccb3bd96b7d7345569a2aa0b14e4d015c54a6a0c54"
+	testToken  = "api-token"
+)
+
+func _byteArray(input string) []byte {
+	res, _ := hex.DecodeString(input)
+	return res
+}
+
+func setupServer(t *testing.T) (*Server, *inmemory.InMemStore) {
+	store := inmemory.NewInMemStore(core.MainNetwork)
+	options := &eth2keymanager.KeyVaultOptions{}
+	options.SetStorage(store)
+	options.SetWalletType(core.NDWallet)
+	vault, err := eth2keymanager.NewKeyVault(options)
+	require.NoError(t, err)
+	wallet, err := vault.Wallet()
+	require.NoError(t, err)
+	s, err := New(vault, wallet, store, testToken)
+	require.NoError(t, err)
+	return s, store
+}
+
+func testKeystore(t *testing.T, password string) string {
+	crypto, err := keystorev4.New(keystorev4.WithCipher("pbkdf2")).Encrypt(_byteArray(testSK), password)
+	require.NoError(t, err)
+	byts, err := json.Marshal(&keystorev4.Keystore{
+		Crypto:  crypto,
+		PubKey:  testPubKey,
+		Path:    "m/12381/3600/0/0/0",
+		UUID:    uuid.New(),
+		Version: 4,
+	})
+	require.NoError(t, err)
+	return string(byts)
+}
+
+func do(t *testing.T, s *Server, method string, path string, body interface{}, ret interface{}) int {
+	byts, err := json.Marshal(body)
+	require.NoError(t, err)
+	r := httptest.NewRequest(method, path, bytes.NewReader(byts))
+	r.Header.Set("Authorization", "Bearer "+testToken)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if ret != nil {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), ret), w.Body.String())
+	}
+	return w.Code
+}
+
+func TestAuthorization(t *testing.T) {
+	s, _ := setupServer(t)
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, KeystoresPath, nil))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	r := httptest.NewRequest(http.MethodGet, KeystoresPath, nil)
+	r.Header.Set("Authorization", "Bearer wrong")
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	r = httptest.NewRequest(http.MethodGet, KeystoresPath, nil)
+	r.Header.Set("Authorization", "Bearer ")
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	t.Run("empty token", func(t *testing.T) {
+		_, err := New(s.vault, s.wallet, s.slashingStore, "")
+		require.EqualError(t, err, "keymanager API token is required")
+	})
+}
+
+func TestImportKeystores(t *testing.T) {
+	s, _ := setupServer(t)
+	keystore := testKeystore(t, "password")
+
+	resp := &StatusResponse{}
+	code := do(t, s, http.MethodPost, KeystoresPath, &ImportKeystoresRequest{
+		Keystores: []string{keystore, keystore, keystore},
+		Passwords: []string{"password", "password", "wrong"},
+	}, resp)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Data, 3)
+	require.Equal(t, StatusImported, resp.Data[0].Status)
+	require.Equal(t, StatusDuplicate, resp.Data[1].Status)
+	require.Equal(t, StatusError, resp.Data[2].Status)
+	require.Contains(t, resp.Data[2].Message, "failed to decrypt keystore")
+
+	list := &ListKeystoresResponse{}
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodGet, KeystoresPath, nil, list))
+	require.Len(t, list.Data, 1)
+	require.Equal(t, "0x"+testPubKey, list.Data[0].ValidatingPubKey)
+
+	t.Run("with slashing protection", func(t *testing.T) {
+		s, store := setupServer(t)
+		var pubKey phase0.BLSPubKey
+		copy(pubKey[:], _byteArray(testPubKey))
+		byts, err := json.Marshal(&interchange.Interchange{
+			Metadata: &interchange.Metadata{
+				InterchangeFormatVersion: interchange.FormatVersion,
+				GenesisValidatorsRoot:    core.MainNetwork.GenesisValidatorsRoot(),
+			},
+			Data: []*interchange.Data{
+				{
+					PublicKey:          pubKey,
+					SignedBlocks:       []*interchange.SignedBlock{{Slot: 10}},
+					SignedAttestations: []*interchange.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		resp := &StatusResponse{}
+		code := do(t, s, http.MethodPost, KeystoresPath, &ImportKeystoresRequest{
+			Keystores:          []string{keystore},
+			Passwords:          []string{"password"},
+			SlashingProtection: string(byts),
+		}, resp)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, StatusImported, resp.Data[0].Status)
+
+		slot, found, err := store.RetrieveHighestProposal(pubKey[:])
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 10, slot)
+	})
+
+	t.Run("passwords count mismatch", func(t *testing.T) {
+		code := do(t, s, http.MethodPost, KeystoresPath, &ImportKeystoresRequest{
+			Keystores: []string{keystore},
+		}, nil)
+		require.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestDeleteKeystores(t *testing.T) {
+	s, store := setupServer(t)
+	resp := &StatusResponse{}
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodPost, KeystoresPath, &ImportKeystoresRequest{
+		Keystores: []string{testKeystore(t, "password")},
+		Passwords: []string{"password"},
+	}, resp))
+	require.Equal(t, StatusImported, resp.Data[0].Status)
+
+	require.NoError(t, store.SaveHighestAttestation(_byteArray(testPubKey), &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: 3},
+		Target: &phase0.Checkpoint{Epoch: 4},
+	}))
+
+	unknown := "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+	deleted := &DeleteKeystoresResponse{}
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodDelete, KeystoresPath, &DeleteKeysRequest{
+		PubKeys: []string{"0x" + testPubKey, unknown, "0xzz"},
+	}, deleted))
+	require.Len(t, deleted.Data, 3)
+	require.Equal(t, StatusDeleted, deleted.Data[0].Status)
+	require.Equal(t, StatusNotFound, deleted.Data[1].Status)
+	require.Equal(t, StatusError, deleted.Data[2].Status)
+
+	ic := &interchange.Interchange{}
+	require.NoError(t, json.Unmarshal([]byte(deleted.SlashingProtection), ic))
+	require.NoError(t, ic.Validate(core.MainNetwork))
+	require.Len(t, ic.Data, 1)
+	require.Equal(t, _byteArray(testPubKey), ic.Data[0].PublicKey[:])
+	require.EqualValues(t, 4, ic.Data[0].SignedAttestations[0].TargetEpoch)
+
+	list := &ListKeystoresResponse{}
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodGet, KeystoresPath, nil, list))
+	require.Len(t, list.Data, 0)
+
+	t.Run("deleting again is not active", func(t *testing.T) {
+		deleted := &DeleteKeystoresResponse{}
+		require.Equal(t, http.StatusOK, do(t, s, http.MethodDelete, KeystoresPath, &DeleteKeysRequest{
+			PubKeys: []string{"0x" + testPubKey},
+		}, deleted))
+		require.Equal(t, StatusNotActive, deleted.Data[0].Status)
+	})
+}
+
+func TestRemoteKeys(t *testing.T) {
+	s, _ := setupServer(t)
+
+	list := &ListRemoteKeysResponse{}
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodGet, RemoteKeysPath, nil, list))
+	require.Len(t, list.Data, 0)
+
+	resp := &StatusResponse{}
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodPost, RemoteKeysPath, &ImportRemoteKeysRequest{
+		RemoteKeys: []*RemoteKeyData{{PubKey: "0x" + testPubKey, URL: "https://remote.signer"}},
+	}, resp))
+	require.Equal(t, StatusError, resp.Data[0].Status)
+
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodDelete, RemoteKeysPath, &DeleteKeysRequest{
+		PubKeys: []string{"0x" + testPubKey},
+	}, resp))
+	require.Equal(t, StatusNotFound, resp.Data[0].Status)
+}
+
+func TestImportWhileSigning(t *testing.T) {
+	s, store := setupServer(t)
+	keystore := testKeystore(t, "password")
+	simpleSigner := signer.NewSimpleSigner(s.wallet, prot.NewNormalProtection(store), core.MainNetwork)
+
+	// run with -race, the key is imported and deleted while the signer looks it up and signs with it
+	done := make(chan struct{})
+	signed := make(chan struct{})
+	go func() {
+		defer close(signed)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			_, _, _ = simpleSigner.SignSlot(1, phase0.Domain{}, _byteArray(testPubKey))
+			_ = s.wallet.Accounts()
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		resp := &StatusResponse{}
+		require.Equal(t, http.StatusOK, do(t, s, http.MethodPost, KeystoresPath, &ImportKeystoresRequest{
+			Keystores: []string{keystore},
+			Passwords: []string{"password"},
+		}, resp))
+		require.Equal(t, StatusImported, resp.Data[0].Status)
+
+		_, _, err := simpleSigner.SignSlot(1, phase0.Domain{}, _byteArray(testPubKey))
+		require.NoError(t, err)
+
+		deleteResp := &DeleteKeystoresResponse{}
+		require.Equal(t, http.StatusOK, do(t, s, http.MethodDelete, KeystoresPath, &DeleteKeysRequest{
+			PubKeys: []string{"0x" + testPubKey},
+		}, deleteResp))
+		require.Equal(t, StatusDeleted, deleteResp.Data[0].Status)
+	}
+	close(done)
+	<-signed
+}
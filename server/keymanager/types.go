@@ -0,0 +1,80 @@
+package keymanager
+
+// Status is the per key result status of import and delete requests
+type Status string
+
+// Available statuses
+const (
+	StatusImported  Status = "imported"
+	StatusDuplicate Status = "duplicate"
+	StatusDeleted   Status = "deleted"
+	StatusNotActive Status = "not_active"
+	StatusNotFound  Status = "not_found"
+	StatusError     Status = "error"
+)
+
+// StatusData is the per key result of import and delete requests
+type StatusData struct {
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// StatusResponse is the response of import requests and remote keys deletion
+type StatusResponse struct {
+	Data []*StatusData `json:"data"`
+}
+
+// KeystoreData describes a local key
+type KeystoreData struct {
+	ValidatingPubKey string `json:"validating_pubkey"`
+	DerivationPath   string `json:"derivation_path,omitempty"`
+	ReadOnly         bool   `json:"readonly"`
+}
+
+// ListKeystoresResponse is the response of GET /eth/v1/keystores
+type ListKeystoresResponse struct {
+	Data []*KeystoreData `json:"data"`
+}
+
+// ImportKeystoresRequest is the body of POST /eth/v1/keystores.
+// Keystores are EIP-2335 JSON strings, passwords are matched to keystores by index.
+type ImportKeystoresRequest struct {
+	Keystores          []string `json:"keystores"`
+	Passwords          []string `json:"passwords"`
+	SlashingProtection string   `json:"slashing_protection,omitempty"`
+}
+
+// DeleteKeysRequest is the body of DELETE /eth/v1/keystores and DELETE /eth/v1/remotekeys
+type DeleteKeysRequest struct {
+	PubKeys []string `json:"pubkeys"`
+}
+
+// DeleteKeystoresResponse is the response of DELETE /eth/v1/keystores.
+// SlashingProtection is an EIP-3076 JSON string covering the deleted and inactive keys.
+type DeleteKeystoresResponse struct {
+	Data               []*StatusData `json:"data"`
+	SlashingProtection string        `json:"slashing_protection"`
+}
+
+// RemoteKeyData describes a remote key
+type RemoteKeyData struct {
+	PubKey   string `json:"pubkey"`
+	URL      string `json:"url"`
+	ReadOnly bool   `json:"readonly,omitempty"`
+}
+
+// ListRemoteKeysResponse is the response of GET /eth/v1/remotekeys
+type ListRemoteKeysResponse struct {
+	Data []*RemoteKeyData `json:"data"`
+}
+
+// ImportRemoteKeysRequest is the body of POST /eth/v1/remotekeys
+type ImportRemoteKeysRequest struct {
+	RemoteKeys []*RemoteKeyData `json:"remote_keys"`
+}
+
+// ErrorResponse is the body of failed requests
+type ErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,505 @@
+package web3signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/signer"
+)
+
+// Endpoint paths
+const (
+	SignPath       = "/api/v1/eth2/sign/"
+	PublicKeysPath = "/api/v1/eth2/publicKeys"
+	UpcheckPath    = "/upcheck"
+)
+
+// Signer represents the signer behavior needed by the server.
+// Blocks of later forks are only sent as headers, so signing them needs SignBlock on top of ValidatorSigner.
+type Signer interface {
+	signer.ValidatorSigner
+	SignBlock(block ssz.HashRoot, slot phase0.Slot, domain phase0.Domain, pubKey []byte) ([]byte, []byte, error)
+}
+
+// Server implements the Web3Signer remote signing API
+// https://consensys.github.io/web3signer/web3signer-eth2.html
+type Server struct {
+	wallet  core.Wallet
+	signer  Signer
+	network core.Network
+	mux     *http.ServeMux
+}
+
+// signOperation holds a prepared sign request
+type signOperation struct {
+	obj    ssz.HashRoot
+	domain phase0.Domain
+	sign   func() ([]byte, []byte, error)
+}
+
+// requestError is returned for malformed requests
+type requestError struct {
+	error
+}
+
+func badRequest(err error) error {
+	return &requestError{err}
+}
+
+// New is the constructor of Server
+func New(wallet core.Wallet, signer Signer, network core.Network) *Server {
+	s := &Server{
+		wallet:  wallet,
+		signer:  signer,
+		network: network,
+		mux:     http.NewServeMux(),
+	}
+	s.mux.HandleFunc(SignPath, s.handleSign)
+	s.mux.HandleFunc(PublicKeysPath, s.handlePublicKeys)
+	s.mux.HandleFunc(UpcheckPath, s.handleUpcheck)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleUpcheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+func (s *Server) handlePublicKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ret := make([]string, 0)
+	for _, account := range s.wallet.Accounts() {
+		ret = append(ret, "0x"+hex.EncodeToString(account.ValidatorPublicKey()))
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	identifier := strings.TrimPrefix(r.URL.Path, SignPath)
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(identifier, "0x"))
+	if err != nil || len(pubKey) != phase0.PublicKeyLength {
+		http.Error(w, "invalid identifier", http.StatusBadRequest)
+		return
+	}
+	if _, err := s.wallet.AccountByPublicKey(hex.EncodeToString(pubKey)); err != nil {
+		http.Error(w, "public key not found", http.StatusNotFound)
+		return
+	}
+
+	req := &SignRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, errors.Wrap(err, "invalid request body").Error(), http.StatusBadRequest)
+		return
+	}
+
+	sig, err := s.sign(req, pubKey)
+	if err != nil {
+		var reqErr *requestError
+		var slashingErr *signer.SlashingError
+		switch {
+		case errors.As(err, &reqErr):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.As(err, &slashingErr):
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		default:
+			logrus.WithError(err).WithField("type", req.Type).Error("failed to sign")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	signature := "0x" + hex.EncodeToString(sig)
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		writeJSON(w, http.StatusOK, &SignResponse{Signature: signature})
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(signature))
+}
+
+// sign prepares, verifies and signs the given request
+func (s *Server) sign(req *SignRequest, pubKey []byte) ([]byte, error) {
+	op, err := s.prepare(req, pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SigningRoot != nil {
+		root, err := signer.ComputeETHSigningRoot(op.obj, op.domain)
+		if err != nil {
+			return nil, badRequest(errors.Wrap(err, "failed to compute signing root"))
+		}
+		if !bytes.Equal(root[:], req.SigningRoot[:]) {
+			return nil, badRequest(errors.Errorf("signing root mismatch, expected %#x", root))
+		}
+	}
+
+	sig, _, err := op.sign()
+	if err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// prepare maps the request type to the matching signer method and computes its domain
+func (s *Server) prepare(req *SignRequest, pubKey []byte) (*signOperation, error) {
+	switch req.Type {
+	case Attestation:
+		data := req.Attestation
+		if data == nil || data.Source == nil || data.Target == nil {
+			return nil, badRequest(errors.New("attestation is required"))
+		}
+		domain, err := s.domain(req.ForkInfo, types.DomainBeaconAttester, data.Target.Epoch)
+		if err != nil {
+			return nil, err
+		}
+		return &signOperation{obj: data, domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignBeaconAttestation(data, domain, pubKey)
+		}}, nil
+
+	case AggregationSlot:
+		if req.AggregationSlot == nil {
+			return nil, badRequest(errors.New("aggregation_slot is required"))
+		}
+		slot := req.AggregationSlot.Slot
+		domain, err := s.domain(req.ForkInfo, types.DomainSelectionProof, s.network.EstimatedEpochAtSlot(slot))
+		if err != nil {
+			return nil, err
+		}
+		return &signOperation{obj: signer.SSZUint64(slot), domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignSlot(slot, domain, pubKey)
+		}}, nil
+
+	case AggregateAndProof, AggregateAndProofV2:
+		versioned, agg, slot, err := parseAggregateAndProof(req)
+		if err != nil {
+			return nil, badRequest(err)
+		}
+		domain, err := s.domain(req.ForkInfo, types.DomainAggregateAndProof, s.network.EstimatedEpochAtSlot(slot))
+		if err != nil {
+			return nil, err
+		}
+		return &signOperation{obj: agg, domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignVersionedAggregateAndProof(versioned, domain, pubKey)
+		}}, nil
+
+	case Block, BlockV2:
+		block, slot, err := parseBlock(req)
+		if err != nil {
+			return nil, badRequest(err)
+		}
+		domain, err := s.domain(req.ForkInfo, types.DomainBeaconProposer, s.network.EstimatedEpochAtSlot(slot))
+		if err != nil {
+			return nil, err
+		}
+		return &signOperation{obj: block, domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignBlock(block, slot, domain, pubKey)
+		}}, nil
+
+	case RandaoReveal:
+		if req.RandaoReveal == nil {
+			return nil, badRequest(errors.New("randao_reveal is required"))
+		}
+		epoch := req.RandaoReveal.Epoch
+		domain, err := s.domain(req.ForkInfo, types.DomainRANDAO, epoch)
+		if err != nil {
+			return nil, err
+		}
+		return &signOperation{obj: signer.SSZUint64(epoch), domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignEpoch(epoch, domain, pubKey)
+		}}, nil
+
+	case SyncCommitteeMessage:
+		if req.SyncCommitteeMessage == nil {
+			return nil, badRequest(errors.New("sync_committee_message is required"))
+		}
+		msg := req.SyncCommitteeMessage
+		domain, err := s.domain(req.ForkInfo, types.DomainSyncCommittee, s.network.EstimatedEpochAtSlot(msg.Slot))
+		if err != nil {
+			return nil, err
+		}
+		blockRoot := signer.SSZBytes(msg.BeaconBlockRoot[:])
+		return &signOperation{obj: &blockRoot, domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignSyncCommittee(msg.BeaconBlockRoot[:], domain, pubKey)
+		}}, nil
+
+	case SyncCommitteeSelectionProof:
+		if req.SyncAggregatorSelectionData == nil {
+			return nil, badRequest(errors.New("sync_aggregator_selection_data is required"))
+		}
+		subcommitteeIndex, err := strconv.ParseUint(req.SyncAggregatorSelectionData.SubcommitteeIndex, 10, 64)
+		if err != nil {
+			return nil, badRequest(errors.Wrap(err, "invalid subcommittee index"))
+		}
+		data := &altair.SyncAggregatorSelectionData{
+			Slot:              req.SyncAggregatorSelectionData.Slot,
+			SubcommitteeIndex: subcommitteeIndex,
+		}
+		domain, err := s.domain(req.ForkInfo, types.DomainSyncCommitteeSelectionProof, s.network.EstimatedEpochAtSlot(data.Slot))
+		if err != nil {
+			return nil, err
+		}
+		return &signOperation{obj: data, domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignSyncCommitteeSelectionData(data, domain, pubKey)
+		}}, nil
+
+	case SyncCommitteeContributionAndProof:
+		if req.ContributionAndProof == nil {
+			return nil, badRequest(errors.New("contribution_and_proof is required"))
+		}
+		data := &altair.ContributionAndProof{}
+		if err := json.Unmarshal(req.ContributionAndProof, data); err != nil {
+			return nil, badRequest(errors.Wrap(err, "invalid contribution_and_proof"))
+		}
+		domain, err := s.domain(req.ForkInfo, types.DomainContributionAndProof, s.network.EstimatedEpochAtSlot(data.Contribution.Slot))
+		if err != nil {
+			return nil, err
+		}
+		return &signOperation{obj: data, domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignSyncCommitteeContributionAndProof(data, domain, pubKey)
+		}}, nil
+
+	case ValidatorRegistration:
+		if req.ValidatorRegistration == nil {
+			return nil, badRequest(errors.New("validator_registration is required"))
+		}
+		data := &apiv1.ValidatorRegistration{}
+		if err := json.Unmarshal(req.ValidatorRegistration, data); err != nil {
+			return nil, badRequest(errors.Wrap(err, "invalid validator_registration"))
+		}
+		// builder domain is always computed with the genesis fork version and an empty genesis validators root
+		domain, err := signer.DomainFor(s.network, DomainApplicationBuilder, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &signOperation{obj: data, domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignRegistration(&api.VersionedValidatorRegistration{
+				Version: spec.BuilderVersionV1,
+				V1:      data,
+			}, domain, pubKey)
+		}}, nil
+
+	case VoluntaryExit:
+		data := req.VoluntaryExit
+		if data == nil {
+			return nil, badRequest(errors.New("voluntary_exit is required"))
+		}
+		domain, err := s.domain(req.ForkInfo, types.DomainVoluntaryExit, data.Epoch)
+		if err != nil {
+			return nil, err
+		}
+		return &signOperation{obj: data, domain: domain, sign: func() ([]byte, []byte, error) {
+			return s.signer.SignVoluntaryExit(data, domain, pubKey)
+		}}, nil
+
+	default:
+		return nil, badRequest(errors.Errorf("unsupported sign request type %q", req.Type))
+	}
+}
+
+// domain computes the domain of the given type and epoch with the fork schedule of the server network,
+// the fork info of the request must be of the same network and agree with its fork schedule
+func (s *Server) domain(forkInfo *ForkInfo, domainType types.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	if forkInfo == nil || forkInfo.Fork == nil {
+		return phase0.Domain{}, badRequest(errors.New("fork info is required"))
+	}
+	if _, err := s.network.Config(); err != nil {
+		return phase0.Domain{}, err
+	}
+	if genesisValidatorsRoot := s.network.GenesisValidatorsRoot(); forkInfo.GenesisValidatorsRoot != genesisValidatorsRoot {
+		return phase0.Domain{}, badRequest(errors.Errorf("genesis validators root %#x doesn't match the one of network %s", forkInfo.GenesisValidatorsRoot, s.network))
+	}
+	if err := s.checkFork(forkInfo.Fork, epoch); err != nil {
+		return phase0.Domain{}, err
+	}
+	return signer.DomainFor(s.network, domainType, epoch)
+}
+
+// checkFork makes sure the fork of the request is the one of the server schedule at its epoch,
+// and that the version it selects for the message epoch is the one of the schedule.
+// Messages of epochs before the fork, like voluntary exits of a past epoch, select its previous version.
+func (s *Server) checkFork(fork *phase0.Fork, epoch phase0.Epoch) error {
+	scheduled, err := s.network.ForkAtEpoch(fork.Epoch)
+	if err != nil {
+		return err
+	}
+	if fork.CurrentVersion != scheduled.Version {
+		return badRequest(errors.Errorf("fork current version %#x doesn't match the version %#x of network %s at epoch %d", fork.CurrentVersion, scheduled.Version, s.network, fork.Epoch))
+	}
+
+	version, versionEpoch := fork.CurrentVersion, epoch
+	if epoch < fork.Epoch {
+		version, versionEpoch = fork.PreviousVersion, fork.Epoch-1
+	}
+	if scheduled, err = s.network.ForkAtEpoch(versionEpoch); err != nil {
+		return err
+	}
+	if version != scheduled.Version {
+		return badRequest(errors.Errorf("fork version %#x of epoch %d doesn't match the version %#x of network %s", version, epoch, scheduled.Version, s.network))
+	}
+	return nil
+}
+
+// parseAggregateAndProof returns the versioned aggregate and proof of the request, with the aggregate of its version and its slot.
+// AGGREGATE_AND_PROOF requests hold a phase0 aggregate.
+func parseAggregateAndProof(req *SignRequest) (*spec.VersionedAggregateAndProof, ssz.HashRoot, phase0.Slot, error) {
+	if req.AggregateAndProof == nil {
+		return nil, nil, 0, errors.New("aggregate_and_proof is required")
+	}
+
+	version := spec.DataVersionPhase0
+	raw := req.AggregateAndProof
+	if req.Type == AggregateAndProofV2 {
+		versioned := &VersionedData{}
+		if err := json.Unmarshal(raw, versioned); err != nil {
+			return nil, nil, 0, errors.Wrap(err, "invalid aggregate_and_proof")
+		}
+		version = versioned.Version
+		raw = versioned.Data
+	}
+
+	ret := &spec.VersionedAggregateAndProof{Version: version}
+	if version >= spec.DataVersionElectra {
+		agg := &electra.AggregateAndProof{}
+		if err := json.Unmarshal(raw, agg); err != nil {
+			return nil, nil, 0, errors.Wrap(err, "invalid aggregate_and_proof")
+		}
+		if agg.Aggregate == nil || agg.Aggregate.Data == nil {
+			return nil, nil, 0, errors.New("aggregate_and_proof aggregate is required")
+		}
+		ret.Electra = agg
+		return ret, agg, agg.Aggregate.Data.Slot, nil
+	}
+
+	agg := &phase0.AggregateAndProof{}
+	if err := json.Unmarshal(raw, agg); err != nil {
+		return nil, nil, 0, errors.Wrap(err, "invalid aggregate_and_proof")
+	}
+	if agg.Aggregate == nil || agg.Aggregate.Data == nil {
+		return nil, nil, 0, errors.New("aggregate_and_proof aggregate is required")
+	}
+	switch version {
+	case spec.DataVersionPhase0:
+		ret.Phase0 = agg
+	case spec.DataVersionAltair:
+		ret.Altair = agg
+	case spec.DataVersionBellatrix:
+		ret.Bellatrix = agg
+	case spec.DataVersionCapella:
+		ret.Capella = agg
+	case spec.DataVersionDeneb:
+		ret.Deneb = agg
+	default:
+		return nil, nil, 0, errors.Errorf("unsupported aggregate_and_proof version %s", version)
+	}
+	return ret, agg, agg.Aggregate.Data.Slot, nil
+}
+
+func parseBlock(req *SignRequest) (ssz.HashRoot, phase0.Slot, error) {
+	if req.Type == Block {
+		if req.Block == nil {
+			return nil, 0, errors.New("block is required")
+		}
+		return req.Block, req.Block.Slot, nil
+	}
+
+	if req.BeaconBlock == nil {
+		return nil, 0, errors.New("beacon_block is required")
+	}
+	// the header's hash tree root is equal to the block's one
+	if header := req.BeaconBlock.BlockHeader; header != nil {
+		return header, header.Slot, nil
+	}
+	if req.BeaconBlock.Block == nil {
+		return nil, 0, errors.New("block or block_header is required")
+	}
+
+	raw := req.BeaconBlock.Block
+	switch req.BeaconBlock.Version {
+	case spec.DataVersionPhase0:
+		block := &phase0.BeaconBlock{}
+		if err := json.Unmarshal(raw, block); err != nil {
+			return nil, 0, errors.Wrap(err, "invalid block")
+		}
+		return block, block.Slot, nil
+	case spec.DataVersionAltair:
+		block := &altair.BeaconBlock{}
+		if err := json.Unmarshal(raw, block); err != nil {
+			return nil, 0, errors.Wrap(err, "invalid block")
+		}
+		return block, block.Slot, nil
+	case spec.DataVersionBellatrix:
+		block := &bellatrix.BeaconBlock{}
+		if err := json.Unmarshal(raw, block); err != nil {
+			return nil, 0, errors.Wrap(err, "invalid block")
+		}
+		return block, block.Slot, nil
+	case spec.DataVersionCapella:
+		block := &capella.BeaconBlock{}
+		if err := json.Unmarshal(raw, block); err != nil {
+			return nil, 0, errors.Wrap(err, "invalid block")
+		}
+		return block, block.Slot, nil
+	case spec.DataVersionDeneb:
+		block := &deneb.BeaconBlock{}
+		if err := json.Unmarshal(raw, block); err != nil {
+			return nil, 0, errors.Wrap(err, "invalid block")
+		}
+		return block, block.Slot, nil
+	case spec.DataVersionElectra:
+		block := &electra.BeaconBlock{}
+		if err := json.Unmarshal(raw, block); err != nil {
+			return nil, 0, errors.Wrap(err, "invalid block")
+		}
+		return block, block.Slot, nil
+	default:
+		return nil, 0, errors.Errorf("unsupported block version %s", req.BeaconBlock.Version)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		logrus.WithError(err).Error("failed to write response")
+	}
+}
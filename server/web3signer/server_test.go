@@ -0,0 +1,340 @@
+package web3signer
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	types "github.com/wealdtech/go-eth2-types/v2"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/signer"
+	prot "github.com/ssvlabs/eth2-key-manager/slashing_protection"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
+)
+
+const (
+	testSK     = "2c083f2c8fc923fa2bd32a70ab72b4b46247e8c1f347adc30b2f8036a355086c"
+	testPubKey = "a9cf360aa15fb1d1d30ee2b578dc5884823c19661886ae8b892775ccb3bd96b7d7345569a2aa0b14e4d015c54a6a0c54"
+)
+
+func _byteArray(input string) []byte {
+	res, _ := hex.DecodeString(input)
+	return res
+}
+
+func setupServer(t *testing.T) *Server {
+	network := core.MainNetwork
+	store := inmemory.NewInMemStore(network)
+	options := &eth2keymanager.KeyVaultOptions{}
+	options.SetStorage(store)
+	options.SetWalletType(core.NDWallet)
+	vault, err := eth2keymanager.NewKeyVault(options)
+	require.NoError(t, err)
+	wallet, err := vault.Wallet()
+	require.NoError(t, err)
+	k, err := core.NewHDKeyFromPrivateKey(_byteArray(testSK), "")
+	require.NoError(t, err)
+	require.NoError(t, wallet.AddValidatorAccount(wallets.NewValidatorAccount("1", k, nil, "", vault.Context)))
+
+	// seed minimal slashing data, normal protection refuses to sign without it
+	protector := prot.NewNormalProtection(store)
+	require.NoError(t, protector.UpdateHighestAttestation(_byteArray(testPubKey), testAttestation(0, 0)))
+	require.NoError(t, protector.UpdateHighestProposal(_byteArray(testPubKey), 1))
+
+	return New(wallet, signer.NewSimpleSigner(wallet, protector, network), network)
+}
+
+func testForkInfo() *ForkInfo {
+	return &ForkInfo{
+		Fork: &phase0.Fork{
+			PreviousVersion: core.MainNetwork.GenesisForkVersion(),
+			CurrentVersion:  core.MainNetwork.GenesisForkVersion(),
+		},
+		GenesisValidatorsRoot: core.MainNetwork.GenesisValidatorsRoot(),
+	}
+}
+
+func testAttestation(source, target phase0.Epoch) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:            phase0.Slot(target * 32),
+		BeaconBlockRoot: phase0.Root{0x01},
+		Source:          &phase0.Checkpoint{Epoch: source},
+		Target:          &phase0.Checkpoint{Epoch: target, Root: phase0.Root{0x02}},
+	}
+}
+
+func doSign(t *testing.T, s *Server, pubKey string, req interface{}, accept string) *httptest.ResponseRecorder {
+	byts, err := json.Marshal(req)
+	require.NoError(t, err)
+	r := httptest.NewRequest(http.MethodPost, SignPath+pubKey, bytes.NewReader(byts))
+	if accept != "" {
+		r.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	return w
+}
+
+func TestUpcheck(t *testing.T) {
+	s := setupServer(t)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, UpcheckPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "OK", w.Body.String())
+}
+
+func TestPublicKeys(t *testing.T) {
+	s := setupServer(t)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, PublicKeysPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var keys []string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &keys))
+	require.Equal(t, []string{"0x" + testPubKey}, keys)
+}
+
+func TestSignAttestation(t *testing.T) {
+	s := setupServer(t)
+	attestation := testAttestation(1, 2)
+
+	w := doSign(t, s, "0x"+testPubKey, &SignRequest{
+		Type:        Attestation,
+		ForkInfo:    testForkInfo(),
+		Attestation: attestation,
+	}, "application/json")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	resp := &SignResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+
+	// signing the same attestation data directly (no protection involved for the verification) must match
+	domain, err := signer.DomainFor(core.MainNetwork, types.DomainBeaconAttester, 2)
+	require.NoError(t, err)
+	expected, _, err := signer.NewSimpleSigner(s.wallet, &prot.NoProtection{}, core.MainNetwork).SignBeaconAttestation(attestation, domain, _byteArray(testPubKey))
+	require.NoError(t, err)
+	require.Equal(t, "0x"+hex.EncodeToString(expected), resp.Signature)
+
+	t.Run("slashable", func(t *testing.T) {
+		double := testAttestation(1, 2)
+		double.BeaconBlockRoot = phase0.Root{0x03}
+		w := doSign(t, s, "0x"+testPubKey, &SignRequest{
+			Type:        Attestation,
+			ForkInfo:    testForkInfo(),
+			Attestation: double,
+		}, "")
+		require.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+	})
+
+	t.Run("signing root mismatch", func(t *testing.T) {
+		w := doSign(t, s, "0x"+testPubKey, &SignRequest{
+			Type:        Attestation,
+			ForkInfo:    testForkInfo(),
+			SigningRoot: &phase0.Root{0x01},
+			Attestation: testAttestation(2, 3),
+		}, "")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+	})
+
+	t.Run("matching signing root", func(t *testing.T) {
+		att := testAttestation(2, 3)
+		domain, err := signer.DomainFor(core.MainNetwork, types.DomainBeaconAttester, 3)
+		require.NoError(t, err)
+		root, err := signer.ComputeETHSigningRoot(att, domain)
+		require.NoError(t, err)
+
+		w := doSign(t, s, "0x"+testPubKey, &SignRequest{
+			Type:        Attestation,
+			ForkInfo:    testForkInfo(),
+			SigningRoot: &root,
+			Attestation: att,
+		}, "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+		require.Len(t, w.Body.String(), 2+2*phase0.SignatureLength)
+	})
+}
+
+func TestSignRandaoReveal(t *testing.T) {
+	s := setupServer(t)
+	w := doSign(t, s, testPubKey, &SignRequest{
+		Type:         RandaoReveal,
+		ForkInfo:     testForkInfo(),
+		RandaoReveal: &RandaoRevealData{Epoch: 10},
+	}, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestSignBlockHeader(t *testing.T) {
+	s := setupServer(t)
+	body := fmt.Sprintf(`{
+		"type": "BLOCK_V2",
+		"fork_info": {
+			"fork": {"previous_version": "0x00000000", "current_version": "0x00000000", "epoch": "0"},
+			"genesis_validators_root": "%#x"
+		},
+		"beacon_block": {
+			"version": "DENEB",
+			"block_header": {
+				"slot": "100",
+				"proposer_index": "5",
+				"parent_root": "0x0000000000000000000000000000000000000000000000000000000000000001",
+				"state_root": "0x0000000000000000000000000000000000000000000000000000000000000002",
+				"body_root": "0x0000000000000000000000000000000000000000000000000000000000000003"
+			}
+		}
+	}`, core.MainNetwork.GenesisValidatorsRoot())
+	r := httptest.NewRequest(http.MethodPost, SignPath+"0x"+testPubKey, bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// re-signing the same block returns the same signature
+	sig := w.Body.String()
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, SignPath+"0x"+testPubKey, bytes.NewReader([]byte(body))))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, sig, w.Body.String())
+
+	// proposing a different block for the same slot is slashable
+	body = strings.Replace(body, "0x0000000000000000000000000000000000000000000000000000000000000003", "0x0000000000000000000000000000000000000000000000000000000000000004", 1)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(http.MethodPost, SignPath+"0x"+testPubKey, bytes.NewReader([]byte(body))))
+	require.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+}
+
+func TestSignAggregateAndProof(t *testing.T) {
+	s := setupServer(t)
+	attestation := testAttestation(1, 2)
+	w := doSign(t, s, "0x"+testPubKey, &SignRequest{Type: Attestation, ForkInfo: testForkInfo(), Attestation: attestation}, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	aggregateRequest := func(t *testing.T, data *phase0.AttestationData) *SignRequest {
+		byts, err := json.Marshal(&phase0.AggregateAndProof{
+			AggregatorIndex: 1,
+			Aggregate:       &phase0.Attestation{AggregationBits: bitfield.NewBitlist(8), Data: data},
+		})
+		require.NoError(t, err)
+		byts, err = json.Marshal(&VersionedData{Version: spec.DataVersionPhase0, Data: byts})
+		require.NoError(t, err)
+		return &SignRequest{Type: AggregateAndProofV2, ForkInfo: testForkInfo(), AggregateAndProof: byts}
+	}
+
+	t.Run("aggregate of the signed attestation data", func(t *testing.T) {
+		w := doSign(t, s, "0x"+testPubKey, aggregateRequest(t, attestation), "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	})
+
+	t.Run("slashable aggregate", func(t *testing.T) {
+		data := testAttestation(1, 2)
+		data.BeaconBlockRoot = phase0.Root{0x03}
+		w := doSign(t, s, "0x"+testPubKey, aggregateRequest(t, data), "")
+		require.Equal(t, http.StatusPreconditionFailed, w.Code, w.Body.String())
+		require.Contains(t, w.Body.String(), "slashable aggregate attestation")
+	})
+}
+
+func TestSignVoluntaryExit(t *testing.T) {
+	s := setupServer(t)
+
+	// exits are signed with the Capella fork version from Deneb on (EIP-7044), whatever the request fork info
+	exit := &phase0.VoluntaryExit{Epoch: 300000, ValidatorIndex: 1}
+	deneb, err := core.MainNetwork.ForkByDataVersion(spec.DataVersionDeneb)
+	require.NoError(t, err)
+	electra, err := core.MainNetwork.ForkByDataVersion(spec.DataVersionElectra)
+	require.NoError(t, err)
+	forkInfo := testForkInfo()
+	forkInfo.Fork = &phase0.Fork{PreviousVersion: deneb.Version, CurrentVersion: electra.Version, Epoch: electra.Epoch}
+	w := doSign(t, s, "0x"+testPubKey, &SignRequest{
+		Type:          VoluntaryExit,
+		ForkInfo:      forkInfo,
+		VoluntaryExit: exit,
+	}, "application/json")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	resp := &SignResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	capella, err := core.MainNetwork.ForkByDataVersion(spec.DataVersionCapella)
+	require.NoError(t, err)
+	genesisValidatorsRoot := core.MainNetwork.GenesisValidatorsRoot()
+	domainBytes, err := types.ComputeDomain(types.DomainVoluntaryExit, capella.Version[:], genesisValidatorsRoot[:])
+	require.NoError(t, err)
+	var domain phase0.Domain
+	copy(domain[:], domainBytes)
+	expected, _, err := signer.NewSimpleSigner(s.wallet, &prot.NoProtection{}, core.MainNetwork).SignVoluntaryExit(exit, domain, _byteArray(testPubKey))
+	require.NoError(t, err)
+	require.Equal(t, "0x"+hex.EncodeToString(expected), resp.Signature)
+}
+
+func TestSignErrors(t *testing.T) {
+	s := setupServer(t)
+
+	t.Run("unknown public key", func(t *testing.T) {
+		unknown := "b845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+		w := doSign(t, s, "0x"+unknown, &SignRequest{Type: RandaoReveal, ForkInfo: testForkInfo(), RandaoReveal: &RandaoRevealData{Epoch: 1}}, "")
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid identifier", func(t *testing.T) {
+		w := doSign(t, s, "0x1234", &SignRequest{Type: RandaoReveal}, "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		w := doSign(t, s, "0x"+testPubKey, &SignRequest{Type: "DEPOSIT"}, "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), `unsupported sign request type "DEPOSIT"`)
+	})
+
+	t.Run("missing fork info", func(t *testing.T) {
+		w := doSign(t, s, "0x"+testPubKey, &SignRequest{Type: RandaoReveal, RandaoReveal: &RandaoRevealData{Epoch: 1}}, "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "fork info is required")
+	})
+
+	t.Run("fork info of another network", func(t *testing.T) {
+		forkInfo := testForkInfo()
+		forkInfo.GenesisValidatorsRoot = core.HoodiNetwork.GenesisValidatorsRoot()
+		w := doSign(t, s, "0x"+testPubKey, &SignRequest{Type: RandaoReveal, ForkInfo: forkInfo, RandaoReveal: &RandaoRevealData{Epoch: 1}}, "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "doesn't match the one of network mainnet")
+	})
+
+	t.Run("fork info disagreeing with the fork schedule", func(t *testing.T) {
+		altair, err := core.MainNetwork.ForkByDataVersion(spec.DataVersionAltair)
+		require.NoError(t, err)
+		forkInfo := testForkInfo()
+		forkInfo.Fork.CurrentVersion = altair.Version
+		w := doSign(t, s, "0x"+testPubKey, &SignRequest{Type: RandaoReveal, ForkInfo: forkInfo, RandaoReveal: &RandaoRevealData{Epoch: 1}}, "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "fork current version 0x01000000 doesn't match the version 0x00000000 of network mainnet at epoch 0")
+
+		// a client unaware of the later forks of the schedule
+		w = doSign(t, s, "0x"+testPubKey, &SignRequest{Type: RandaoReveal, ForkInfo: testForkInfo(), RandaoReveal: &RandaoRevealData{Epoch: 400000}}, "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "fork version 0x00000000 of epoch 400000 doesn't match the version 0x05000000 of network mainnet")
+	})
+
+	t.Run("missing payload", func(t *testing.T) {
+		w := doSign(t, s, "0x"+testPubKey, &SignRequest{Type: Attestation, ForkInfo: testForkInfo()}, "")
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("wrong method", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest(http.MethodGet, SignPath+"0x"+testPubKey, nil))
+		require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
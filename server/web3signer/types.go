@@ -0,0 +1,99 @@
+package web3signer
+
+import (
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ssvlabs/eth2-key-manager/signer"
+)
+
+// SignType represents the type of the sign request
+type SignType string
+
+// Supported sign request types
+const (
+	AggregationSlot                   SignType = "AGGREGATION_SLOT"
+	AggregateAndProof                 SignType = "AGGREGATE_AND_PROOF"
+	AggregateAndProofV2               SignType = "AGGREGATE_AND_PROOF_V2"
+	Attestation                       SignType = "ATTESTATION"
+	Block                             SignType = "BLOCK"
+	BlockV2                           SignType = "BLOCK_V2"
+	RandaoReveal                      SignType = "RANDAO_REVEAL"
+	SyncCommitteeMessage              SignType = "SYNC_COMMITTEE_MESSAGE"
+	SyncCommitteeSelectionProof       SignType = "SYNC_COMMITTEE_SELECTION_PROOF"
+	SyncCommitteeContributionAndProof SignType = "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+	ValidatorRegistration             SignType = "VALIDATOR_REGISTRATION"
+	VoluntaryExit                     SignType = "VOLUNTARY_EXIT"
+)
+
+// DomainApplicationBuilder is the builder API domain type, it is not part of the consensus spec
+var DomainApplicationBuilder = signer.DomainApplicationBuilder
+
+// ForkInfo is the fork information sent along with sign requests
+type ForkInfo struct {
+	Fork                  *phase0.Fork `json:"fork"`
+	GenesisValidatorsRoot phase0.Root  `json:"genesis_validators_root"`
+}
+
+// SignRequest is the body of the sign endpoint.
+// Only the field matching the request type is expected to be set.
+type SignRequest struct {
+	Type        SignType     `json:"type"`
+	ForkInfo    *ForkInfo    `json:"fork_info,omitempty"`
+	SigningRoot *phase0.Root `json:"signingRoot,omitempty"`
+
+	AggregationSlot             *AggregationSlotData         `json:"aggregation_slot,omitempty"`
+	AggregateAndProof           json.RawMessage              `json:"aggregate_and_proof,omitempty"`
+	Attestation                 *phase0.AttestationData      `json:"attestation,omitempty"`
+	Block                       *phase0.BeaconBlock          `json:"block,omitempty"`
+	BeaconBlock                 *BeaconBlockData             `json:"beacon_block,omitempty"`
+	RandaoReveal                *RandaoRevealData            `json:"randao_reveal,omitempty"`
+	SyncCommitteeMessage        *SyncCommitteeMessageData    `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *SyncAggregatorSelectionData `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        json.RawMessage              `json:"contribution_and_proof,omitempty"`
+	ValidatorRegistration       json.RawMessage              `json:"validator_registration,omitempty"`
+	VoluntaryExit               *phase0.VoluntaryExit        `json:"voluntary_exit,omitempty"`
+}
+
+// AggregationSlotData is the payload of AGGREGATION_SLOT requests
+type AggregationSlotData struct {
+	Slot phase0.Slot `json:"slot"`
+}
+
+// RandaoRevealData is the payload of RANDAO_REVEAL requests
+type RandaoRevealData struct {
+	Epoch phase0.Epoch `json:"epoch"`
+}
+
+// SyncCommitteeMessageData is the payload of SYNC_COMMITTEE_MESSAGE requests
+type SyncCommitteeMessageData struct {
+	BeaconBlockRoot phase0.Root `json:"beacon_block_root"`
+	Slot            phase0.Slot `json:"slot"`
+}
+
+// SyncAggregatorSelectionData is the payload of SYNC_COMMITTEE_SELECTION_PROOF requests
+type SyncAggregatorSelectionData struct {
+	Slot              phase0.Slot `json:"slot"`
+	SubcommitteeIndex string      `json:"subcommittee_index"`
+}
+
+// BeaconBlockData is the payload of BLOCK_V2 requests.
+// Phase0 and Altair requests carry the full block, later forks carry only the block header.
+type BeaconBlockData struct {
+	Version     spec.DataVersion          `json:"version"`
+	Block       json.RawMessage           `json:"block,omitempty"`
+	BlockHeader *phase0.BeaconBlockHeader `json:"block_header,omitempty"`
+}
+
+// VersionedData is the payload of versioned requests such as AGGREGATE_AND_PROOF_V2
+type VersionedData struct {
+	Version spec.DataVersion `json:"version"`
+	Data    json.RawMessage  `json:"data"`
+}
+
+// SignResponse is the JSON response of the sign endpoint
+type SignResponse struct {
+	Signature string `json:"signature"`
+}
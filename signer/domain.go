@@ -0,0 +1,162 @@
+package signer
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// Domain types which are not part of go-eth2-types
+var (
+	// DomainBLSToExecutionChange is the domain type of BLS to execution changes (Capella)
+	DomainBLSToExecutionChange = types.DomainType{0x0a, 0x00, 0x00, 0x00}
+	// DomainApplicationBuilder is the builder API domain type of validator registrations, it is not part of the consensus spec
+	DomainApplicationBuilder = types.DomainType{0x00, 0x00, 0x00, 0x01}
+)
+
+// ForkSchedule is implemented by networks knowing their fork versions, like core.Network
+type ForkSchedule interface {
+	Config() (core.NetworkConfig, error)
+	GenesisForkVersion() phase0.Version
+	GenesisValidatorsRoot() phase0.Root
+	ForkAtEpoch(epoch phase0.Epoch) (core.Fork, error)
+	ForkByDataVersion(dataVersion spec.DataVersion) (core.Fork, error)
+}
+
+// DomainFor computes the domain of the given type for a message of the given epoch:
+//   - deposits and builder registrations use the genesis fork version and a zero genesis validators root
+//   - BLS to execution changes use the genesis fork version
+//   - voluntary exits use the Capella fork version from Deneb on (EIP-7044)
+//   - everything else uses the fork version active at the epoch
+//
+// Unknown networks return an error, whatever the domain type.
+func DomainFor(network ForkSchedule, domainType types.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	cfg, err := network.Config()
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+	genesisValidatorsRoot := network.GenesisValidatorsRoot()
+
+	var forkVersion phase0.Version
+	switch domainType {
+	case types.DomainDeposit, DomainApplicationBuilder:
+		forkVersion = cfg.GenesisForkVersion
+		genesisValidatorsRoot = phase0.Root{}
+	case DomainBLSToExecutionChange:
+		forkVersion = cfg.GenesisForkVersion
+	case types.DomainVoluntaryExit:
+		fork, err := network.ForkAtEpoch(epoch)
+		if err != nil {
+			return phase0.Domain{}, err
+		}
+		if fork.DataVersion >= spec.DataVersionDeneb {
+			if fork, err = network.ForkByDataVersion(spec.DataVersionCapella); err != nil {
+				return phase0.Domain{}, err
+			}
+		}
+		forkVersion = fork.Version
+	default:
+		fork, err := network.ForkAtEpoch(epoch)
+		if err != nil {
+			return phase0.Domain{}, err
+		}
+		forkVersion = fork.Version
+	}
+
+	domainBytes, err := types.ComputeDomain(domainType, forkVersion[:], genesisValidatorsRoot[:])
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to calculate domain")
+	}
+	var domain phase0.Domain
+	copy(domain[:], domainBytes)
+	return domain, nil
+}
+
+// DomainFor computes the domain of the given type and epoch on the signer network, see DomainFor
+func (signer *SimpleSigner) DomainFor(domainType types.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	network, ok := signer.network.(ForkSchedule)
+	if !ok {
+		return phase0.Domain{}, errors.New("signer network has no fork schedule, the domain must be given")
+	}
+	return DomainFor(network, domainType, epoch)
+}
+
+// DomainMismatchError is returned by a strict signer when a domain doesn't match the signed message or the signer network
+type DomainMismatchError struct {
+	Domain             phase0.Domain
+	ExpectedDomainType types.DomainType
+	Reason             string
+}
+
+// Error implements error
+func (e *DomainMismatchError) Error() string {
+	return fmt.Sprintf("domain %#x mismatch, expected domain type %#x: %s", e.Domain, e.ExpectedDomainType, e.Reason)
+}
+
+// checkDomain makes sure, in strict mode, the given domain is of the expected type and
+// was built with a fork version and genesis validators root of the signer network
+func (signer *SimpleSigner) checkDomain(domainType types.DomainType, domain phase0.Domain) error {
+	if !signer.strictDomains {
+		return nil
+	}
+
+	if !bytes.Equal(domain[:len(domainType)], domainType[:]) {
+		return &DomainMismatchError{Domain: domain, ExpectedDomainType: domainType, Reason: "wrong domain type"}
+	}
+
+	network, ok := signer.network.(ForkSchedule)
+	if !ok {
+		return &DomainMismatchError{Domain: domain, ExpectedDomainType: domainType, Reason: "signer network has no fork schedule"}
+	}
+
+	// deposits, registrations and BLS to execution changes are only valid with the genesis fork
+	candidates := []phase0.Domain{}
+	switch domainType {
+	case types.DomainDeposit, DomainApplicationBuilder, DomainBLSToExecutionChange:
+		expected, err := DomainFor(network, domainType, 0)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, expected)
+	default:
+		forks, err := forkSchedule(network)
+		if err != nil {
+			return err
+		}
+		gvr := network.GenesisValidatorsRoot()
+		for _, fork := range forks {
+			domainBytes, err := types.ComputeDomain(domainType, fork.Version[:], gvr[:])
+			if err != nil {
+				return errors.Wrap(err, "failed to calculate domain")
+			}
+			var candidate phase0.Domain
+			copy(candidate[:], domainBytes)
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	for _, candidate := range candidates {
+		if candidate == domain {
+			return nil
+		}
+	}
+	return &DomainMismatchError{Domain: domain, ExpectedDomainType: domainType, Reason: "unknown fork version or genesis validators root"}
+}
+
+// forkSchedule returns every fork of the network, from genesis on
+func forkSchedule(network ForkSchedule) ([]core.Fork, error) {
+	if n, ok := network.(interface{ ForkSchedule() ([]core.Fork, error) }); ok {
+		return n.ForkSchedule()
+	}
+	genesis, err := network.ForkAtEpoch(0)
+	if err != nil {
+		return nil, err
+	}
+	return []core.Fork{genesis}, nil
+}
@@ -0,0 +1,209 @@
+package signer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	prot "github.com/ssvlabs/eth2-key-manager/slashing_protection"
+)
+
+// slotsOnlyNetwork implements Network without a fork schedule
+type slotsOnlyNetwork struct{}
+
+func (slotsOnlyNetwork) EstimatedEpochAtSlot(slot phase0.Slot) phase0.Epoch {
+	return phase0.Epoch(slot / 32)
+}
+func (slotsOnlyNetwork) EstimatedSlotAtTime(time.Time) phase0.Slot { return 0 }
+
+func computeTestDomain(t *testing.T, domainType types.DomainType, forkVersion phase0.Version, genesisValidatorsRoot phase0.Root) phase0.Domain {
+	byts, err := types.ComputeDomain(domainType, forkVersion[:], genesisValidatorsRoot[:])
+	require.NoError(t, err)
+	var ret phase0.Domain
+	copy(ret[:], byts)
+	return ret
+}
+
+func TestDomainFor(t *testing.T) {
+	gvr := core.MainNetwork.GenesisValidatorsRoot()
+	tests := []struct {
+		name       string
+		domainType types.DomainType
+		epoch      phase0.Epoch
+		expected   phase0.Domain
+	}{
+		{
+			name:       "deposit uses genesis fork and zero root",
+			domainType: types.DomainDeposit,
+			epoch:      400000,
+			expected:   _byteArray32("03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9"),
+		},
+		{
+			name:       "builder registration uses genesis fork and zero root",
+			domainType: DomainApplicationBuilder,
+			epoch:      400000,
+			expected:   _byteArray32("00000001f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9"),
+		},
+		{
+			name:       "bls to execution change uses genesis fork",
+			domainType: DomainBLSToExecutionChange,
+			epoch:      400000,
+			expected:   computeTestDomain(t, DomainBLSToExecutionChange, phase0.Version{0x00, 0x00, 0x00, 0x00}, gvr),
+		},
+		{
+			name:       "voluntary exit before deneb uses the fork at its epoch",
+			domainType: types.DomainVoluntaryExit,
+			epoch:      150000,
+			expected:   computeTestDomain(t, types.DomainVoluntaryExit, phase0.Version{0x02, 0x00, 0x00, 0x00}, gvr),
+		},
+		{
+			name:       "voluntary exit from deneb on uses capella (EIP-7044)",
+			domainType: types.DomainVoluntaryExit,
+			epoch:      400000,
+			expected:   computeTestDomain(t, types.DomainVoluntaryExit, phase0.Version{0x03, 0x00, 0x00, 0x00}, gvr),
+		},
+		{
+			name:       "attestation uses the fork at its epoch",
+			domainType: types.DomainBeaconAttester,
+			epoch:      400000,
+			expected:   computeTestDomain(t, types.DomainBeaconAttester, phase0.Version{0x05, 0x00, 0x00, 0x00}, gvr),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			domain, err := DomainFor(core.MainNetwork, test.domainType, test.epoch)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, domain)
+		})
+	}
+
+	t.Run("network without fork schedule", func(t *testing.T) {
+		require.NoError(t, core.RegisterNetwork("test-signer-no-forks", core.NetworkConfig{}))
+		_, err := DomainFor(core.Network("test-signer-no-forks"), types.DomainBeaconAttester, 0)
+		require.EqualError(t, err, "network test-signer-no-forks has no fork schedule")
+	})
+
+	t.Run("unknown network", func(t *testing.T) {
+		for _, domainType := range []types.DomainType{types.DomainDeposit, DomainApplicationBuilder, DomainBLSToExecutionChange, types.DomainVoluntaryExit, types.DomainBeaconAttester} {
+			_, err := DomainFor(core.Network("unknown"), domainType, 0)
+			require.EqualError(t, err, "unknown network unknown", "domain type %#x", domainType)
+		}
+	})
+}
+
+func TestSignAutoDomain(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")
+	pubKey := _byteArray("95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf")
+	wallet, err := walletWithSeed(seed, inmemStorage())
+	require.NoError(t, err)
+	signer := NewSimpleSigner(wallet, &prot.NoProtection{}, core.MainNetwork)
+
+	t.Run("voluntary exit", func(t *testing.T) {
+		exit := &phase0.VoluntaryExit{Epoch: 400000, ValidatorIndex: 1}
+		sig, root, err := signer.SignVoluntaryExitAutoDomain(exit, pubKey)
+		require.NoError(t, err)
+
+		domain := computeTestDomain(t, types.DomainVoluntaryExit, phase0.Version{0x03, 0x00, 0x00, 0x00}, core.MainNetwork.GenesisValidatorsRoot())
+		expectedSig, expectedRoot, err := signer.SignVoluntaryExit(exit, domain, pubKey)
+		require.NoError(t, err)
+		require.Equal(t, expectedSig, sig)
+		require.Equal(t, expectedRoot, root)
+	})
+
+	t.Run("block of the wrong fork is rejected", func(t *testing.T) {
+		block := &spec.VersionedBeaconBlock{
+			Version: spec.DataVersionPhase0,
+			Phase0:  &phase0.BeaconBlock{Slot: 400000 * 32, Body: &phase0.BeaconBlockBody{ETH1Data: &phase0.ETH1Data{}}},
+		}
+		_, _, err := signer.SignBeaconBlockAutoDomain(block, pubKey)
+		require.EqualError(t, err, "phase0 data at epoch 400000, expected electra")
+	})
+
+	t.Run("network without fork schedule", func(t *testing.T) {
+		signer := NewSimpleSigner(wallet, &prot.NoProtection{}, slotsOnlyNetwork{})
+		_, _, err := signer.SignBeaconAttestationAutoDomain(&phase0.AttestationData{Target: &phase0.Checkpoint{}}, pubKey)
+		require.EqualError(t, err, "signer network has no fork schedule, the domain must be given")
+	})
+}
+
+func TestStrictDomains(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")
+	pubKey := _byteArray("95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf")
+	wallet, err := walletWithSeed(seed, inmemStorage())
+	require.NoError(t, err)
+	signer := NewSimpleSigner(wallet, &prot.NoProtection{}, core.MainNetwork, WithStrictDomains())
+	gvr := core.MainNetwork.GenesisValidatorsRoot()
+
+	t.Run("domain of the network", func(t *testing.T) {
+		domain, err := signer.DomainFor(types.DomainRANDAO, 400000)
+		require.NoError(t, err)
+		_, _, err = signer.SignEpoch(400000, domain, pubKey)
+		require.NoError(t, err)
+	})
+
+	t.Run("domain of a previous fork", func(t *testing.T) {
+		domain := computeTestDomain(t, types.DomainVoluntaryExit, phase0.Version{0x03, 0x00, 0x00, 0x00}, gvr)
+		_, _, err := signer.SignVoluntaryExit(&phase0.VoluntaryExit{Epoch: 400000, ValidatorIndex: 1}, domain, pubKey)
+		require.NoError(t, err)
+	})
+
+	t.Run("builder domain", func(t *testing.T) {
+		domain, err := signer.DomainFor(DomainApplicationBuilder, 0)
+		require.NoError(t, err)
+		require.NoError(t, signer.checkDomain(DomainApplicationBuilder, domain))
+
+		domain = computeTestDomain(t, DomainApplicationBuilder, phase0.Version{0x05, 0x00, 0x00, 0x00}, phase0.Root{})
+		require.Error(t, signer.checkDomain(DomainApplicationBuilder, domain))
+	})
+
+	tests := []struct {
+		name   string
+		domain phase0.Domain
+		reason string
+	}{
+		{
+			name:   "wrong domain type",
+			domain: computeTestDomain(t, types.DomainBeaconAttester, phase0.Version{0x05, 0x00, 0x00, 0x00}, gvr),
+			reason: "wrong domain type",
+		},
+		{
+			name:   "other network",
+			domain: computeTestDomain(t, types.DomainRANDAO, phase0.Version{0x10, 0x00, 0x09, 0x10}, core.HoodiNetwork.GenesisValidatorsRoot()),
+			reason: "unknown fork version or genesis validators root",
+		},
+		{
+			name:   "unknown fork version",
+			domain: computeTestDomain(t, types.DomainRANDAO, phase0.Version{0x06, 0x00, 0x00, 0x00}, gvr),
+			reason: "unknown fork version or genesis validators root",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := signer.SignEpoch(400000, test.domain, pubKey)
+			var mismatch *DomainMismatchError
+			require.ErrorAs(t, err, &mismatch)
+			require.Equal(t, test.reason, mismatch.Reason)
+			require.Equal(t, test.domain, mismatch.Domain)
+			require.Equal(t, types.DomainRANDAO, mismatch.ExpectedDomainType)
+		})
+	}
+
+	t.Run("network without fork schedule", func(t *testing.T) {
+		signer := NewSimpleSigner(wallet, &prot.NoProtection{}, slotsOnlyNetwork{}, WithStrictDomains())
+		_, _, err := signer.SignEpoch(1, computeTestDomain(t, types.DomainRANDAO, phase0.Version{}, gvr), pubKey)
+		var mismatch *DomainMismatchError
+		require.ErrorAs(t, err, &mismatch)
+	})
+
+	t.Run("not strict", func(t *testing.T) {
+		signer := NewSimpleSigner(wallet, &prot.NoProtection{}, core.MainNetwork)
+		_, _, err := signer.SignEpoch(400000, tests[0].domain, pubKey)
+		require.NoError(t, err)
+	})
+}
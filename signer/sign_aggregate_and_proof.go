@@ -3,9 +3,12 @@ package signer
 import (
 	"encoding/hex"
 
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 // SignAggregateAndProof signs aggregate and proof.
@@ -25,6 +28,9 @@ func (signer *SimpleSigner) SignAggregateAndProof(agg ssz.HashRoot, domain phase
 		return nil, nil, err
 	}
 
+	if err := signer.checkDomain(types.DomainAggregateAndProof, domain); err != nil {
+		return nil, nil, err
+	}
 	root, err := ComputeETHSigningRoot(agg, domain)
 	if err != nil {
 		return nil, nil, err
@@ -37,3 +43,98 @@ func (signer *SimpleSigner) SignAggregateAndProof(agg ssz.HashRoot, domain phase
 
 	return sig, root[:], nil
 }
+
+// SignVersionedAggregateAndProof signs the aggregate and proof of the given version.
+// The aggregate attestation data is checked by the slashing protection as if the validator attested it,
+// so an aggregate of the attestation data the validator signed passes, but it isn't recorded.
+// Protectors which aren't a core.SigningRootProtector can't tell the signed data apart from other data of its target,
+// so they refuse aggregates of targets up to the highest attested one.
+func (signer *SimpleSigner) SignVersionedAggregateAndProof(agg *spec.VersionedAggregateAndProof, domain phase0.Domain, pubKey []byte) ([]byte, []byte, error) {
+	if agg == nil {
+		return nil, nil, errors.New("aggregate and proof data could not be nil")
+	}
+
+	var aggregate ssz.HashRoot
+	var data *phase0.AttestationData
+	var err error
+	switch agg.Version {
+	case spec.DataVersionPhase0:
+		aggregate, data, err = phase0Aggregate(agg.Phase0)
+	case spec.DataVersionAltair:
+		aggregate, data, err = phase0Aggregate(agg.Altair)
+	case spec.DataVersionBellatrix:
+		aggregate, data, err = phase0Aggregate(agg.Bellatrix)
+	case spec.DataVersionCapella:
+		aggregate, data, err = phase0Aggregate(agg.Capella)
+	case spec.DataVersionDeneb:
+		aggregate, data, err = phase0Aggregate(agg.Deneb)
+	case spec.DataVersionElectra:
+		aggregate, data, err = electraAggregate(agg.Electra)
+	default:
+		err = errors.Errorf("unsupported aggregate and proof version %d", agg.Version)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if data == nil || data.Source == nil || data.Target == nil {
+		return nil, nil, errors.New("aggregate attestation data could not be nil")
+	}
+
+	if pubKey == nil {
+		return nil, nil, errors.New("account was not supplied")
+	}
+	account, err := signer.wallet.AccountByPublicKey(hex.EncodeToString(pubKey))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the slashing protection is read under the lock of attestations
+	val := signer.lock(account.ID(), "attestation")
+	val.Lock()
+	defer val.Unlock()
+
+	if !IsValidFarFutureEpoch(signer.network, data.Target.Epoch) {
+		return nil, nil, slashingError("target epoch too far into the future")
+	}
+	if !IsValidFarFutureEpoch(signer.network, data.Source.Epoch) {
+		return nil, nil, slashingError("source epoch too far into the future")
+	}
+	if err := signer.checkDomain(types.DomainAggregateAndProof, domain); err != nil {
+		return nil, nil, err
+	}
+
+	// aggregates and attestations of a slot share their fork, so the attester domain only differs by its type
+	attesterDomain := domain
+	copy(attesterDomain[:4], types.DomainBeaconAttester[:])
+	attestationRoot, err := ComputeETHSigningRoot(data, attesterDomain)
+	if err != nil {
+		return nil, nil, err
+	}
+	if val, err := signer.isSlashableAttestation(pubKey, data, attestationRoot); err != nil || val != nil {
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, slashingError("slashable aggregate attestation (%s), not signing", val.Status)
+	}
+
+	return signer.SignAggregateAndProof(aggregate, domain, pubKey)
+}
+
+// phase0Aggregate returns the aggregate and proof of the phase0 to deneb versions and its attestation data
+func phase0Aggregate(agg *phase0.AggregateAndProof) (ssz.HashRoot, *phase0.AttestationData, error) {
+	if agg == nil || agg.Aggregate == nil {
+		return nil, nil, errors.New("no aggregate and proof")
+	}
+	return agg, agg.Aggregate.Data, nil
+}
+
+// electraAggregate returns the electra aggregate and proof and its attestation data, an electra aggregate holds the attestations of a single committee
+func electraAggregate(agg *electra.AggregateAndProof) (ssz.HashRoot, *phase0.AttestationData, error) {
+	if agg == nil || agg.Aggregate == nil {
+		return nil, nil, errors.New("no aggregate and proof")
+	}
+	if count := agg.Aggregate.CommitteeBits.Count(); count != 1 {
+		return nil, nil, errors.Errorf("electra aggregate must have exactly one committee bit set, got %d", count)
+	}
+	return agg, agg.Aggregate.Data, nil
+}
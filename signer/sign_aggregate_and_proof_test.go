@@ -3,7 +3,10 @@ package signer
 import (
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stretchr/testify/require"
 
 	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
@@ -12,7 +15,9 @@ import (
 	"github.com/ssvlabs/eth2-key-manager/wallets"
 )
 
-func TestReferenceAttestationAggregation(t *testing.T) {
+// setupReferenceAggregate returns a wallet holding the key of the reference aggregate and proof,
+// with the aggregate, its domain, the account public key and the expected signature
+func setupReferenceAggregate(t *testing.T) (core.Wallet, *phase0.AggregateAndProof, phase0.Domain, []byte, []byte) {
 	sk := _byteArray("2c083f2c8fc923fa2bd32a70ab72b4b46247e8c1f347adc30b2f8036a355086c")
 	pk := _byteArray("a9cf360aa15fb1d1d30ee2b578dc5884823c19661886ae8b892775ccb3bd96b7d7345569a2aa0b14e4d015c54a6a0c54")
 	aggByts := _byteArray("01000000000000006c000000b4fa352d2d6dbdf884266af7ea0914451929b343527ea6c1737ac93b3dde8b7c98e6ce61d68b7a2e7b7af8f8d0fd429d0bdd5f930b83e6842bf4342d3d1d3d10fc0d15bab7649bb8aa8287ca104a1f79d396ce0217bb5cd3e6503a3bce4c9776e4000000000000000000000000000000000000003a43a4bf26fb5947e809c1f24f7dc6857c8ac007e535d48e6e4eca2122fd776b0000000000000000000000000000000000000000000000000000000000000000000000000000000002000000000000003a43a4bf26fb5947e809c1f24f7dc6857c8ac007e535d48e6e4eca2122fd776bb4fa352d2d6dbdf884266af7ea0914451929b343527ea6c1737ac93b3dde8b7c98e6ce61d68b7a2e7b7af8f8d0fd429d0bdd5f930b83e6842bf4342d3d1d3d10fc0d15bab7649bb8aa8287ca104a1f79d396ce0217bb5cd3e6503a3bce4c97760010")
@@ -31,17 +36,147 @@ func TestReferenceAttestationAggregation(t *testing.T) {
 	k, err := core.NewHDKeyFromPrivateKey(sk, "")
 	require.NoError(t, err)
 	acc := wallets.NewValidatorAccount("1", k, nil, "", vault.Context)
-	require.NoError(t, err)
 	require.NoError(t, wallet.AddValidatorAccount(acc))
 
-	// setup signer
-	signer := NewSimpleSigner(wallet, &prot.NoProtection{}, core.PraterNetwork)
-
 	// decode attestation
 	agg := &phase0.AggregateAndProof{}
 	require.NoError(t, agg.UnmarshalSSZ(aggByts))
+	return wallet, agg, domain, pk, sig
+}
+
+func TestReferenceAttestationAggregation(t *testing.T) {
+	wallet, agg, domain, pk, sig := setupReferenceAggregate(t)
+	signer := NewSimpleSigner(wallet, &prot.NoProtection{}, core.PraterNetwork)
 
 	actualSig, _, err := signer.SignAggregateAndProof(agg, domain, pk)
 	require.NoError(t, err)
 	require.EqualValues(t, sig, actualSig)
 }
+
+func TestVersionedAggregateAndProof(t *testing.T) {
+	wallet, agg, domain, pk, sig := setupReferenceAggregate(t)
+	signer := NewSimpleSigner(wallet, &prot.NoProtection{}, core.PraterNetwork)
+
+	t.Run("phase0 aggregate", func(t *testing.T) {
+		actualSig, _, err := signer.SignVersionedAggregateAndProof(&spec.VersionedAggregateAndProof{Version: spec.DataVersionDeneb, Deneb: agg}, domain, pk)
+		require.NoError(t, err)
+		require.EqualValues(t, sig, actualSig)
+	})
+
+	t.Run("electra aggregate", func(t *testing.T) {
+		electraAgg := &electra.AggregateAndProof{
+			AggregatorIndex: agg.AggregatorIndex,
+			Aggregate: &electra.Attestation{
+				AggregationBits: agg.Aggregate.AggregationBits,
+				Data:            agg.Aggregate.Data,
+				Signature:       agg.Aggregate.Signature,
+				CommitteeBits:   bitfield.NewBitvector64(),
+			},
+			SelectionProof: agg.SelectionProof,
+		}
+		versioned := &spec.VersionedAggregateAndProof{Version: spec.DataVersionElectra, Electra: electraAgg}
+		_, _, err := signer.SignVersionedAggregateAndProof(versioned, domain, pk)
+		require.EqualError(t, err, "electra aggregate must have exactly one committee bit set, got 0")
+
+		electraAgg.Aggregate.CommitteeBits.SetBitAt(3, true)
+		actualSig, root, err := signer.SignVersionedAggregateAndProof(versioned, domain, pk)
+		require.NoError(t, err)
+		expectedSig, expectedRoot, err := signer.SignAggregateAndProof(electraAgg, domain, pk)
+		require.NoError(t, err)
+		require.EqualValues(t, expectedSig, actualSig)
+		require.EqualValues(t, expectedRoot, root)
+		require.NotEqualValues(t, sig, actualSig)
+	})
+
+	t.Run("missing aggregate", func(t *testing.T) {
+		_, _, err := signer.SignVersionedAggregateAndProof(&spec.VersionedAggregateAndProof{Version: spec.DataVersionElectra, Deneb: agg}, domain, pk)
+		require.EqualError(t, err, "no aggregate and proof")
+	})
+}
+
+// aggregateAttestationData returns attestation data of the given target epoch, voting for the given block root
+func aggregateAttestationData(epoch phase0.Epoch, blockRoot byte) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:            phase0.Slot(epoch) * 32,
+		BeaconBlockRoot: phase0.Root{blockRoot},
+		Source:          &phase0.Checkpoint{Epoch: epoch - 1},
+		Target:          &phase0.Checkpoint{Epoch: epoch, Root: phase0.Root{blockRoot}},
+	}
+}
+
+// phase0VersionedAggregate returns a phase0 aggregate and proof of the given attestation data
+func phase0VersionedAggregate(data *phase0.AttestationData) *spec.VersionedAggregateAndProof {
+	return &spec.VersionedAggregateAndProof{
+		Version: spec.DataVersionPhase0,
+		Phase0: &phase0.AggregateAndProof{
+			Aggregate: &phase0.Attestation{
+				AggregationBits: bitfield.NewBitlist(8),
+				Data:            data,
+			},
+		},
+	}
+}
+
+func TestVersionedAggregateAndProofSlashing(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")
+	pubKey := _byteArray("95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf")
+	signer, err := setupWithSlashingProtection(t, seed, true, false)
+	require.NoError(t, err)
+
+	attesterDomain := _byteArray32("0100000081509579e35e84020ad8751eca180b44df470332d3ad17fc6fd52459")
+	aggregateDomain := _byteArray32("0600000081509579e35e84020ad8751eca180b44df470332d3ad17fc6fd52459")
+
+	_, _, err = signer.SignBeaconAttestation(aggregateAttestationData(1, 1), attesterDomain, pubKey)
+	require.NoError(t, err)
+
+	t.Run("aggregate of the signed attestation data", func(t *testing.T) {
+		_, _, err := signer.SignVersionedAggregateAndProof(phase0VersionedAggregate(aggregateAttestationData(1, 1)), aggregateDomain, pubKey)
+		require.NoError(t, err)
+	})
+
+	t.Run("aggregate of other attestation data of the signed target", func(t *testing.T) {
+		_, _, err := signer.SignVersionedAggregateAndProof(phase0VersionedAggregate(aggregateAttestationData(1, 2)), aggregateDomain, pubKey)
+		require.EqualError(t, err, "slashable aggregate attestation (HighestAttestationVote), not signing")
+	})
+
+	t.Run("aggregates aren't recorded", func(t *testing.T) {
+		_, _, err := signer.SignVersionedAggregateAndProof(phase0VersionedAggregate(aggregateAttestationData(2, 1)), aggregateDomain, pubKey)
+		require.NoError(t, err)
+		_, _, err = signer.SignBeaconAttestation(aggregateAttestationData(2, 2), attesterDomain, pubKey)
+		require.NoError(t, err)
+	})
+}
+
+// highestAttestationProtector only exposes the core.SlashingProtector methods of its protector,
+// so the signer can't check signing roots with it
+type highestAttestationProtector struct {
+	core.SlashingProtector
+}
+
+func TestVersionedAggregateAndProofWithoutSigningRoots(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")
+	pubKey := _byteArray("95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf")
+	store := inmemStorage()
+	wallet, err := walletWithSeed(seed, store)
+	require.NoError(t, err)
+	protector := &highestAttestationProtector{SlashingProtector: prot.NewNormalProtection(store)}
+	require.NoError(t, protector.UpdateHighestAttestation(pubKey, aggregateAttestationData(1, 0)))
+	signer := NewSimpleSigner(wallet, protector, core.PraterNetwork)
+
+	attesterDomain := _byteArray32("0100000081509579e35e84020ad8751eca180b44df470332d3ad17fc6fd52459")
+	aggregateDomain := _byteArray32("0600000081509579e35e84020ad8751eca180b44df470332d3ad17fc6fd52459")
+
+	_, _, err = signer.SignBeaconAttestation(aggregateAttestationData(2, 1), attesterDomain, pubKey)
+	require.NoError(t, err)
+
+	t.Run("aggregate of the signed attestation data is refused", func(t *testing.T) {
+		// without signing roots the highest attestation can't tell the signed data apart from other data of its target
+		_, _, err := signer.SignVersionedAggregateAndProof(phase0VersionedAggregate(aggregateAttestationData(2, 1)), aggregateDomain, pubKey)
+		require.EqualError(t, err, "slashable aggregate attestation (HighestAttestationVote), not signing")
+	})
+
+	t.Run("aggregate of a higher target", func(t *testing.T) {
+		_, _, err := signer.SignVersionedAggregateAndProof(phase0VersionedAggregate(aggregateAttestationData(3, 1)), aggregateDomain, pubKey)
+		require.NoError(t, err)
+	})
+}
@@ -5,6 +5,9 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
 )
 
 // SignBeaconAttestation signs beacon attestation data
@@ -25,30 +28,36 @@ func (signer *SimpleSigner) SignBeaconAttestation(attestation *phase0.Attestatio
 
 	// 3. far future check
 	if !IsValidFarFutureEpoch(signer.network, attestation.Target.Epoch) {
-		return nil, nil, errors.Errorf("target epoch too far into the future")
+		return nil, nil, slashingError("target epoch too far into the future")
 	}
 	if !IsValidFarFutureEpoch(signer.network, attestation.Source.Epoch) {
-		return nil, nil, errors.Errorf("source epoch too far into the future")
+		return nil, nil, slashingError("source epoch too far into the future")
+	}
+
+	if err := signer.checkDomain(types.DomainBeaconAttester, domain); err != nil {
+		return nil, nil, err
+	}
+
+	// 4. prepare the signing root, identical signing roots can be re-signed
+	root, err := ComputeETHSigningRoot(attestation, domain)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// 4. check we can even sign this
-	if val, err := signer.slashingProtector.IsSlashableAttestation(pubKey, attestation); err != nil || val != nil {
+	// 5. check we can even sign this
+	if val, err := signer.isSlashableAttestation(pubKey, attestation, root); err != nil || val != nil {
 		if err != nil {
 			return nil, nil, err
 		}
-		return nil, nil, errors.Errorf("slashable attestation (%s), not signing", val.Status)
+		return nil, nil, slashingError("slashable attestation (%s), not signing", val.Status)
 	}
 
-	// 5. add to protection storage
-	if err := signer.slashingProtector.UpdateHighestAttestation(pubKey, attestation); err != nil {
+	// 6. add to protection storage
+	if err := signer.recordAttestation(pubKey, attestation, root); err != nil {
 		return nil, nil, err
 	}
 
-	// 6. Prepare and sign data
-	root, err := ComputeETHSigningRoot(attestation, domain)
-	if err != nil {
-		return nil, nil, err
-	}
+	// 7. sign data
 	sig, err := account.ValidationKeySign(root[:])
 	if err != nil {
 		return nil, nil, err
@@ -56,3 +65,17 @@ func (signer *SimpleSigner) SignBeaconAttestation(attestation *phase0.Attestatio
 
 	return sig, root[:], nil
 }
+
+func (signer *SimpleSigner) isSlashableAttestation(pubKey []byte, attestation *phase0.AttestationData, root phase0.Root) (*core.AttestationSlashStatus, error) {
+	if protector, ok := signer.slashingProtector.(core.SigningRootProtector); ok {
+		return protector.IsSlashableAttestationWithRoot(pubKey, attestation, root)
+	}
+	return signer.slashingProtector.IsSlashableAttestation(pubKey, attestation)
+}
+
+func (signer *SimpleSigner) recordAttestation(pubKey []byte, attestation *phase0.AttestationData, root phase0.Root) error {
+	if protector, ok := signer.slashingProtector.(core.SigningRootProtector); ok {
+		return protector.RecordAttestation(pubKey, attestation, root)
+	}
+	return signer.slashingProtector.UpdateHighestAttestation(pubKey, attestation)
+}
@@ -210,6 +210,7 @@ func TestManyValidatorsParallel(t *testing.T) {
 	for _, v := range testValidators {
 		v := v
 		for i := 0; i < goroutinesPerValidator; i++ {
+			i := i
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
@@ -220,6 +221,8 @@ func TestManyValidatorsParallel(t *testing.T) {
 				attData.Slot += phase0.Slot(core.PraterNetwork.SlotsPerEpoch())
 				attData.Source.Epoch++
 				attData.Target.Epoch++
+				// different signing roots, identical ones could all be signed
+				attData.BeaconBlockRoot[0] = byte(i)
 
 				_, _, err := signer.SignBeaconAttestation(attData, domain, v.pk)
 				// require.EqualValues(t, sig, actualSig)
@@ -330,6 +333,8 @@ func TestAttestationSlashingSignatures(t *testing.T) {
 			_byteArray32("A"),
 			_byteArray("95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf"))
 		require.EqualError(t, err, "slashable attestation (HighestAttestationVote), not signing")
+		var slashingErr *SlashingError
+		require.ErrorAs(t, err, &slashingErr)
 	})
 
 	t.Run("same vote with different domain, should not sign", func(t *testing.T) {
@@ -529,6 +534,45 @@ func TestAttestationSignaturesNoSlashingData(t *testing.T) {
 	require.EqualError(t, err, "highest attestation data is not found, can't determine if attestation is slashable")
 }
 
+func TestAttestationResigning(t *testing.T) {
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")
+	signer, err := setupWithSlashingProtection(t, seed, true, true)
+	require.NoError(t, err)
+
+	pubKey := _byteArray("95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf")
+	domain := _byteArray32("01000000f071c66c6561d0b939feb15f513a019d99a84bd85635221e3ad42dac")
+	attestation := func(blockRoot string) *phase0.AttestationData {
+		return &phase0.AttestationData{
+			Slot:            284115,
+			Index:           2,
+			BeaconBlockRoot: _byteArray32(blockRoot),
+			Source: &phase0.Checkpoint{
+				Epoch: 77,
+				Root:  _byteArray32("7402fdc1ce16d449d637c34a172b349a12b2bae8d6d77e401006594d8057c33d"),
+			},
+			Target: &phase0.Checkpoint{
+				Epoch: 78,
+				Root:  _byteArray32("17959acc370274756fa5e9fdd7e7adf17204f49cc8457e49438c42c4883cbfb0"),
+			},
+		}
+	}
+
+	sig, root, err := signer.SignBeaconAttestation(attestation("7b5679277ca45ea74e1deebc9d3e8c0e7d6c570b3cfaf6884be144a81dac9a0e"), domain, pubKey)
+	require.NoError(t, err)
+
+	t.Run("same signing root returns the same signature", func(t *testing.T) {
+		resig, reroot, err := signer.SignBeaconAttestation(attestation("7b5679277ca45ea74e1deebc9d3e8c0e7d6c570b3cfaf6884be144a81dac9a0e"), domain, pubKey)
+		require.NoError(t, err)
+		require.EqualValues(t, sig, resig)
+		require.EqualValues(t, root, reroot)
+	})
+
+	t.Run("different signing root is rejected", func(t *testing.T) {
+		_, _, err := signer.SignBeaconAttestation(attestation("0000000000000000000000000000000000000000000000000000000000000001"), domain, pubKey)
+		require.EqualError(t, err, "slashable attestation (HighestAttestationVote), not signing")
+	})
+}
+
 func TestAttestationSignatures(t *testing.T) {
 	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")
 	signer, err := setupWithSlashingProtection(t, seed, true, true)
@@ -723,6 +767,8 @@ func TestFarFutureAttestationSignature(t *testing.T) {
 			_byteArray32("01000000f071c66c6561d0b939feb15f513a019d99a84bd85635221e3ad42dac"),
 			_byteArray("95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf"))
 		require.EqualError(t, err, "source epoch too far into the future")
+		var slashingErr *SlashingError
+		require.ErrorAs(t, err, &slashingErr)
 	})
 	t.Run("max valid target", func(tt *testing.T) {
 		signer, err := setupWithSlashingProtection(t, seed, true, true)
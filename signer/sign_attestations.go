@@ -0,0 +1,181 @@
+package signer
+
+import (
+	"encoding/hex"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// AttestationRequest is a single attestation of a batch
+type AttestationRequest struct {
+	Attestation *phase0.AttestationData
+	Domain      phase0.Domain
+	PubKey      []byte
+}
+
+// AttestationResult is the result of a single attestation of a batch, Err is set if it was not signed
+type AttestationResult struct {
+	Signature []byte
+	Root      []byte
+	Err       error
+}
+
+// batchAttestation is the state of a single attestation while signing a batch
+type batchAttestation struct {
+	req     *AttestationRequest
+	result  *AttestationResult
+	account core.ValidatorAccount
+	root    phase0.Root
+}
+
+// SignBeaconAttestations signs the beacon attestations of many validators.
+// Slashing protection of the whole batch is checked at once, in a single store transaction when the protector supports it,
+// and the allowed attestations are signed in parallel.
+// Results are returned in the order of the requests, a failing request doesn't fail the others.
+func (signer *SimpleSigner) SignBeaconAttestations(requests []*AttestationRequest) []*AttestationResult {
+	results := make([]*AttestationResult, len(requests))
+	batch := make([]*batchAttestation, 0, len(requests))
+
+	// 1. get the accounts, check far future and prepare the signing roots
+	for i, req := range requests {
+		results[i] = &AttestationResult{}
+		att, err := signer.prepareBatchAttestation(req)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		att.result = results[i]
+		batch = append(batch, att)
+	}
+
+	// 2. lock the accounts, in a fixed order so concurrent batches can't deadlock
+	for _, lock := range signer.batchLocks(batch) {
+		lock.Lock()
+		defer lock.Unlock()
+	}
+
+	// 3. check we can even sign those and add them to protection storage
+	batch = signer.protectAttestations(batch)
+
+	// 4. sign in parallel
+	jobs := make(chan *batchAttestation)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU() && w < len(batch); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for att := range jobs {
+				// a copy of the root, cgo doesn't accept pointers into a struct holding other pointers
+				root := make([]byte, len(att.root))
+				copy(root, att.root[:])
+				sig, err := att.account.ValidationKeySign(root)
+				if err != nil {
+					att.result.Err = err
+					continue
+				}
+				att.result.Signature = sig
+				att.result.Root = root
+			}
+		}()
+	}
+	for _, att := range batch {
+		jobs <- att
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func (signer *SimpleSigner) prepareBatchAttestation(req *AttestationRequest) (*batchAttestation, error) {
+	if req == nil || req.Attestation == nil || req.Attestation.Source == nil || req.Attestation.Target == nil {
+		return nil, errors.New("attestation data could not be nil")
+	}
+	if req.PubKey == nil {
+		return nil, errors.New("account was not supplied")
+	}
+	account, err := signer.wallet.AccountByPublicKey(hex.EncodeToString(req.PubKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsValidFarFutureEpoch(signer.network, req.Attestation.Target.Epoch) {
+		return nil, slashingError("target epoch too far into the future")
+	}
+	if !IsValidFarFutureEpoch(signer.network, req.Attestation.Source.Epoch) {
+		return nil, slashingError("source epoch too far into the future")
+	}
+
+	if err := signer.checkDomain(types.DomainBeaconAttester, req.Domain); err != nil {
+		return nil, err
+	}
+	root, err := ComputeETHSigningRoot(req.Attestation, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+	return &batchAttestation{req: req, account: account, root: root}, nil
+}
+
+// batchLocks returns the attestation locks of the batch accounts, sorted by account id
+func (signer *SimpleSigner) batchLocks(batch []*batchAttestation) []*sync.RWMutex {
+	ids := make([]string, 0, len(batch))
+	locks := make(map[string]*sync.RWMutex)
+	for _, att := range batch {
+		id := att.account.ID().String()
+		if _, found := locks[id]; !found {
+			locks[id] = signer.lock(att.account.ID(), "attestation")
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	ret := make([]*sync.RWMutex, len(ids))
+	for i, id := range ids {
+		ret[i] = locks[id]
+	}
+	return ret
+}
+
+// protectAttestations records the batch in the protection storage and returns the attestations which can be signed
+func (signer *SimpleSigner) protectAttestations(batch []*batchAttestation) []*batchAttestation {
+	statuses := make([]*core.AttestationSlashStatus, len(batch))
+	errs := make([]error, len(batch))
+	if protector, ok := signer.slashingProtector.(core.BatchSlashingProtector); ok {
+		requests := make([]*core.AttestationProtectionRequest, len(batch))
+		for i, att := range batch {
+			requests[i] = &core.AttestationProtectionRequest{
+				PubKey:      att.req.PubKey,
+				Attestation: att.req.Attestation,
+				SigningRoot: att.root,
+			}
+		}
+		statuses, errs = protector.ProtectAttestations(requests)
+	} else {
+		for i, att := range batch {
+			statuses[i], errs[i] = signer.isSlashableAttestation(att.req.PubKey, att.req.Attestation, att.root)
+			if errs[i] == nil && statuses[i] == nil {
+				errs[i] = signer.recordAttestation(att.req.PubKey, att.req.Attestation, att.root)
+			}
+		}
+	}
+
+	ret := make([]*batchAttestation, 0, len(batch))
+	for i, att := range batch {
+		switch {
+		case errs[i] != nil:
+			att.result.Err = errs[i]
+		case statuses[i] != nil:
+			att.result.Err = slashingError("slashable attestation (%s), not signing", statuses[i].Status)
+		default:
+			ret = append(ret, att)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,101 @@
+package signer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	prot "github.com/ssvlabs/eth2-key-manager/slashing_protection"
+	"github.com/ssvlabs/eth2-key-manager/stores/bolt"
+)
+
+func testAttestation(source, target phase0.Epoch, blockRoot byte) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Slot:            phase0.Slot(target) * 32,
+		BeaconBlockRoot: phase0.Root{blockRoot},
+		Source:          &phase0.Checkpoint{Epoch: source},
+		Target:          &phase0.Checkpoint{Epoch: target},
+	}
+}
+
+func TestSignBeaconAttestations(t *testing.T) {
+	require.NoError(t, core.InitBLS())
+	seed := _byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff")
+	domain := _byteArray32("01000000f071c66c6561d0b939feb15f513a019d99a84bd85635221e3ad42dac")
+
+	stores := map[string]func(t *testing.T) core.Storage{
+		"in memory": func(t *testing.T) core.Storage { return inmemStorage() },
+		"bolt": func(t *testing.T) core.Storage {
+			store, err := bolt.NewBoltStore(filepath.Join(t.TempDir(), "keys.db"), core.MainNetwork)
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, store.Close()) })
+			return store
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			options := &eth2keymanager.KeyVaultOptions{}
+			options.SetStorage(store)
+			vault, err := eth2keymanager.NewKeyVault(options)
+			require.NoError(t, err)
+			wallet, err := vault.Wallet()
+			require.NoError(t, err)
+			account1, err := wallet.CreateValidatorAccount(seed, nil)
+			require.NoError(t, err)
+			account2, err := wallet.CreateValidatorAccount(seed, nil)
+			require.NoError(t, err)
+
+			protector := prot.NewNormalProtection(store.(core.SlashingStore))
+			for _, account := range []core.ValidatorAccount{account1, account2} {
+				require.NoError(t, protector.UpdateHighestAttestation(account.ValidatorPublicKey(), testAttestation(0, 0, 0)))
+			}
+			signer := NewSimpleSigner(wallet, protector, core.PraterNetwork)
+
+			pk1, pk2 := account1.ValidatorPublicKey(), account2.ValidatorPublicKey()
+			results := signer.SignBeaconAttestations([]*AttestationRequest{
+				{Attestation: testAttestation(1, 2, 1), Domain: domain, PubKey: pk1},
+				{Attestation: testAttestation(1, 2, 1), Domain: domain, PubKey: pk2},
+				{Attestation: testAttestation(1, 2, 2), Domain: domain, PubKey: pk1},
+				{Attestation: testAttestation(1, 2, 1), Domain: domain, PubKey: _byteArray("95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dca")},
+				{Attestation: testAttestation(1, 100000000, 1), Domain: domain, PubKey: pk2},
+				{Domain: domain, PubKey: pk2},
+			})
+			require.Len(t, results, 6)
+
+			// signatures are the same as signing one by one
+			for i, pk := range [][]byte{pk1, pk2} {
+				require.NoError(t, results[i].Err)
+				expected, _, err := NewSimpleSigner(wallet, &prot.NoProtection{}, core.PraterNetwork).SignBeaconAttestation(testAttestation(1, 2, 1), domain, pk)
+				require.NoError(t, err)
+				require.EqualValues(t, expected, results[i].Signature)
+				require.Len(t, results[i].Root, 32)
+			}
+			require.EqualError(t, results[2].Err, "slashable attestation (HighestAttestationVote), not signing")
+			require.EqualError(t, results[3].Err, "account not found")
+			require.EqualError(t, results[4].Err, "target epoch too far into the future")
+			require.EqualError(t, results[5].Err, "attestation data could not be nil")
+
+			highest, found, err := protector.FetchHighestAttestation(pk2)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.EqualValues(t, 2, highest.Target.Epoch)
+
+			t.Run("re-signing the batch returns the same signatures", func(t *testing.T) {
+				resigned := signer.SignBeaconAttestations([]*AttestationRequest{
+					{Attestation: testAttestation(1, 2, 1), Domain: domain, PubKey: pk1},
+					{Attestation: testAttestation(1, 2, 1), Domain: domain, PubKey: pk2},
+				})
+				for i := range resigned {
+					require.NoError(t, resigned[i].Err)
+					require.EqualValues(t, results[i].Signature, resigned[i].Signature)
+				}
+			})
+		})
+	}
+}
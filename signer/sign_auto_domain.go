@@ -0,0 +1,92 @@
+package signer
+
+import (
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// The AutoDomain variants compute the domain from the signer network fork schedule instead of taking it as a parameter.
+// They require a network implementing ForkSchedule, like core.Network.
+
+// SignBeaconAttestationAutoDomain signs beacon attestation data with the attester domain of its target epoch
+func (signer *SimpleSigner) SignBeaconAttestationAutoDomain(attestation *phase0.AttestationData, pubKey []byte) ([]byte, []byte, error) {
+	if attestation == nil || attestation.Target == nil {
+		return nil, nil, errors.New("attestation data could not be nil")
+	}
+	domain, err := signer.DomainFor(types.DomainBeaconAttester, attestation.Target.Epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return signer.SignBeaconAttestation(attestation, domain, pubKey)
+}
+
+// SignBeaconBlockAutoDomain signs the given beacon block with the proposer domain of its slot.
+// Blocks of a different version than the fork active at their slot are rejected.
+func (signer *SimpleSigner) SignBeaconBlockAutoDomain(block *spec.VersionedBeaconBlock, pubKey []byte) ([]byte, []byte, error) {
+	if block == nil {
+		return nil, nil, errors.New("block data could not be nil")
+	}
+	slot, err := block.Slot()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get block slot")
+	}
+	epoch := signer.network.EstimatedEpochAtSlot(slot)
+	if err := signer.checkForkVersion(block.Version, epoch); err != nil {
+		return nil, nil, err
+	}
+	domain, err := signer.DomainFor(types.DomainBeaconProposer, epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return signer.SignBeaconBlock(block, domain, pubKey)
+}
+
+// SignVoluntaryExitAutoDomain signs the given VoluntaryExit, with the Capella fork version from Deneb on (EIP-7044)
+func (signer *SimpleSigner) SignVoluntaryExitAutoDomain(voluntaryExit *phase0.VoluntaryExit, pubKey []byte) ([]byte, []byte, error) {
+	if voluntaryExit == nil {
+		return nil, nil, errors.New("voluntary exit data is nil")
+	}
+	domain, err := signer.DomainFor(types.DomainVoluntaryExit, voluntaryExit.Epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	return signer.SignVoluntaryExit(voluntaryExit, domain, pubKey)
+}
+
+// SignBLSToExecutionChangeAutoDomain signs the given BLSToExecutionChange with the genesis fork version
+func (signer *SimpleSigner) SignBLSToExecutionChangeAutoDomain(blsToExecutionChange *capella.BLSToExecutionChange, pubKey []byte) ([]byte, []byte, error) {
+	domain, err := signer.DomainFor(DomainBLSToExecutionChange, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return signer.SignBLSToExecutionChange(blsToExecutionChange, domain, pubKey)
+}
+
+// SignRegistrationAutoDomain signs the given validator registration with the builder domain
+func (signer *SimpleSigner) SignRegistrationAutoDomain(registration *api.VersionedValidatorRegistration, pubKey []byte) ([]byte, []byte, error) {
+	domain, err := signer.DomainFor(DomainApplicationBuilder, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return signer.SignRegistration(registration, domain, pubKey)
+}
+
+// checkForkVersion rejects data of another version than the fork active at the given epoch
+func (signer *SimpleSigner) checkForkVersion(dataVersion spec.DataVersion, epoch phase0.Epoch) error {
+	network, ok := signer.network.(ForkSchedule)
+	if !ok {
+		return errors.New("signer network has no fork schedule, the domain must be given")
+	}
+	fork, err := network.ForkAtEpoch(epoch)
+	if err != nil {
+		return err
+	}
+	if fork.DataVersion != dataVersion {
+		return errors.Errorf("%s data at epoch %d, expected %s", dataVersion, epoch, fork.DataVersion)
+	}
+	return nil
+}
@@ -242,6 +242,12 @@ func TestDoubleProposalsSigning_Regular_Blinded(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, _byteArray(sigByts), sig)
 
+	// the blinded block has the same signing root, re-signing it returns the same signature
+	sig, _, err = signer.SignBlindedBeaconBlock(versionedBlindedBeaconBlock, _byteArray32(domain), _byteArray(pk))
+	require.NoError(t, err)
+	require.EqualValues(t, _byteArray(sigByts), sig)
+
+	blindedBlk.ProposerIndex++
 	_, _, err = signer.SignBlindedBeaconBlock(versionedBlindedBeaconBlock, _byteArray32(domain), _byteArray(pk))
 	require.Error(t, err)
 	require.EqualError(t, err, "slashable proposal (HighestProposalVote), not signing")
@@ -308,6 +314,12 @@ func TestDoubleProposalsSigning_Blinded_Regular(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, _byteArray(sigByts), sig)
 
+	// the beacon block has the same signing root, re-signing it returns the same signature
+	sig, _, err = signer.SignBeaconBlock(versionedBeaconBlock, _byteArray32(domain), _byteArray(pk))
+	require.NoError(t, err)
+	require.EqualValues(t, _byteArray(sigByts), sig)
+
+	blk.ProposerIndex++
 	_, _, err = signer.SignBeaconBlock(versionedBeaconBlock, _byteArray32(domain), _byteArray(pk))
 	require.Error(t, err)
 	require.EqualError(t, err, "slashable proposal (HighestProposalVote), not signing")
@@ -6,6 +6,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
 
 	"github.com/ssvlabs/eth2-key-manager/core"
 )
@@ -29,27 +30,34 @@ func (signer *SimpleSigner) SignBlock(block ssz.HashRoot, slot phase0.Slot, doma
 
 	// 3. far future check
 	if !IsValidFarFutureSlot(signer.network, slot) {
-		return nil, nil, errors.Errorf("proposed block slot too far into the future")
+		return nil, nil, slashingError("proposed block slot too far into the future")
 	}
 
-	// 4. check we can even sign this
-	status, err := signer.slashingProtector.IsSlashableProposal(pubKey, slot)
+	if err := signer.checkDomain(types.DomainBeaconProposer, domain); err != nil {
+		return nil, nil, err
+	}
+
+	// 4. prepare the signing root, identical signing roots can be re-signed
+	root, err := ComputeETHSigningRoot(block, domain)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get signing root")
+	}
+
+	// 5. check we can even sign this
+	status, err := signer.isSlashableProposal(pubKey, slot, root)
 	if err != nil {
 		return nil, nil, err
 	}
 	if status.Status != core.ValidProposal {
-		return nil, nil, errors.Errorf("slashable proposal (%s), not signing", status.Status)
+		return nil, nil, slashingError("slashable proposal (%s), not signing", status.Status)
 	}
 
-	// 5. add to protection storage
-	if err = signer.slashingProtector.UpdateHighestProposal(pubKey, slot); err != nil {
+	// 6. add to protection storage
+	if err = signer.recordProposal(pubKey, slot, root); err != nil {
 		return nil, nil, err
 	}
 
-	root, err := ComputeETHSigningRoot(block, domain)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "could not get signing root")
-	}
+	// 7. sign data
 	sig, err := account.ValidationKeySign(root[:])
 	if err != nil {
 		return nil, nil, err
@@ -57,3 +65,17 @@ func (signer *SimpleSigner) SignBlock(block ssz.HashRoot, slot phase0.Slot, doma
 
 	return sig, root[:], nil
 }
+
+func (signer *SimpleSigner) isSlashableProposal(pubKey []byte, slot phase0.Slot, root phase0.Root) (*core.ProposalSlashStatus, error) {
+	if protector, ok := signer.slashingProtector.(core.SigningRootProtector); ok {
+		return protector.IsSlashableProposalWithRoot(pubKey, slot, root)
+	}
+	return signer.slashingProtector.IsSlashableProposal(pubKey, slot)
+}
+
+func (signer *SimpleSigner) recordProposal(pubKey []byte, slot phase0.Slot, root phase0.Root) error {
+	if protector, ok := signer.slashingProtector.(core.SigningRootProtector); ok {
+		return protector.RecordProposal(pubKey, slot, root)
+	}
+	return signer.slashingProtector.UpdateHighestProposal(pubKey, slot)
+}
@@ -24,6 +24,10 @@ func (signer *SimpleSigner) SignBLSToExecutionChange(blsToExecutionChange *capel
 		return nil, nil, err
 	}
 
+	if err := signer.checkDomain(DomainBLSToExecutionChange, domain); err != nil {
+		return nil, nil, err
+	}
+
 	// Produce the signature.
 	root, err := ComputeETHSigningRoot(blsToExecutionChange, domain)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 // SignEpoch signs the given epoch
@@ -22,6 +23,9 @@ func (signer *SimpleSigner) SignEpoch(epoch phase0.Epoch, domain phase0.Domain,
 		return nil, nil, err
 	}
 
+	if err := signer.checkDomain(types.DomainRANDAO, domain); err != nil {
+		return nil, nil, err
+	}
 	root, err := ComputeETHSigningRoot(SSZUint64(epoch), domain)
 	if err != nil {
 		return nil, nil, err
@@ -37,6 +37,10 @@ func (signer *SimpleSigner) SignRegistration(registration *api.VersionedValidato
 		return nil, nil, errors.Errorf("unsupported registration version %d", registration.Version)
 	}
 
+	if err := signer.checkDomain(DomainApplicationBuilder, domain); err != nil {
+		return nil, nil, err
+	}
+
 	// Produce the signature.
 	root, err := ComputeETHSigningRoot(reg, domain)
 	if err != nil {
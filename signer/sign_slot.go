@@ -5,6 +5,7 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 // SignSlot signes the given slot
@@ -22,6 +23,9 @@ func (signer *SimpleSigner) SignSlot(slot phase0.Slot, domain phase0.Domain, pub
 		return nil, nil, err
 	}
 
+	if err := signer.checkDomain(types.DomainSelectionProof, domain); err != nil {
+		return nil, nil, err
+	}
 	root, err := ComputeETHSigningRoot(SSZUint64(slot), domain)
 	if err != nil {
 		return nil, nil, err
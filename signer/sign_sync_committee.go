@@ -6,6 +6,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 // SignSyncCommittee sign sync committee
@@ -27,6 +28,9 @@ func (signer *SimpleSigner) SignSyncCommittee(msgBlockRoot []byte, domain phase0
 
 	// 3. sign
 	sszRoot := SSZBytes(msgBlockRoot)
+	if err := signer.checkDomain(types.DomainSyncCommittee, domain); err != nil {
+		return nil, nil, err
+	}
 	root, err := ComputeETHSigningRoot(&sszRoot, domain)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not get signing root")
@@ -60,6 +64,9 @@ func (signer *SimpleSigner) SignSyncCommitteeSelectionData(data *altair.SyncAggr
 	if data == nil {
 		return nil, nil, errors.New("selection data nil")
 	}
+	if err := signer.checkDomain(types.DomainSyncCommitteeSelectionProof, domain); err != nil {
+		return nil, nil, err
+	}
 	root, err := ComputeETHSigningRoot(data, domain)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not get signing root")
@@ -93,6 +100,9 @@ func (signer *SimpleSigner) SignSyncCommitteeContributionAndProof(contribAndProo
 	if contribAndProof == nil {
 		return nil, nil, errors.New("contrib proof data nil")
 	}
+	if err := signer.checkDomain(types.DomainContributionAndProof, domain); err != nil {
+		return nil, nil, err
+	}
 	root, err := ComputeETHSigningRoot(contribAndProof, domain)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not get signing root")
@@ -5,6 +5,7 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 // SignVoluntaryExit signs the given VoluntaryExit.
@@ -23,6 +24,10 @@ func (signer *SimpleSigner) SignVoluntaryExit(voluntaryExit *phase0.VoluntaryExi
 		return nil, nil, err
 	}
 
+	if err := signer.checkDomain(types.DomainVoluntaryExit, domain); err != nil {
+		return nil, nil, err
+	}
+
 	// Produce the signature.
 	root, err := ComputeETHSigningRoot(voluntaryExit, domain)
 	if err != nil {
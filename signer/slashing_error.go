@@ -0,0 +1,21 @@
+package signer
+
+import (
+	"fmt"
+)
+
+// SlashingError is returned when the signer refuses to sign because of its protection rules,
+// either the message is slashable or it's too far into the future
+type SlashingError struct {
+	Reason string
+}
+
+// Error implements error
+func (e *SlashingError) Error() string {
+	return e.Reason
+}
+
+// slashingError returns a SlashingError of the formatted reason
+func slashingError(format string, args ...interface{}) error {
+	return &SlashingError{Reason: fmt.Sprintf(format, args...)}
+}
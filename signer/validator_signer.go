@@ -20,7 +20,9 @@ type ValidatorSigner interface {
 	SignBeaconBlock(block *spec.VersionedBeaconBlock, domain phase0.Domain, pubKey []byte) (sig []byte, root []byte, err error)
 	SignBlindedBeaconBlock(block *api.VersionedBlindedBeaconBlock, domain phase0.Domain, pubKey []byte) (sig []byte, root []byte, err error)
 	SignBeaconAttestation(attestation *phase0.AttestationData, domain phase0.Domain, pubKey []byte) (sig []byte, root []byte, err error)
+	SignBeaconAttestations(requests []*AttestationRequest) []*AttestationResult
 	SignAggregateAndProof(agg ssz.HashRoot, domain phase0.Domain, pubKey []byte) (sig []byte, root []byte, err error)
+	SignVersionedAggregateAndProof(agg *spec.VersionedAggregateAndProof, domain phase0.Domain, pubKey []byte) (sig []byte, root []byte, err error)
 	SignSlot(slot phase0.Slot, domain phase0.Domain, pubKey []byte) (sig []byte, root []byte, err error)
 	SignEpoch(epoch phase0.Epoch, domain phase0.Domain, pubKey []byte) (sig []byte, root []byte, err error)
 	SignSyncCommittee(msgBlockRoot []byte, domain phase0.Domain, pubKey []byte) (sig []byte, root []byte, err error)
@@ -43,16 +45,48 @@ type SimpleSigner struct {
 	network           Network
 	signLocks         map[string]*sync.RWMutex
 	mapLock           *sync.RWMutex
+	strictDomains     bool
 }
 
-// NewSimpleSigner is the constructor of SimpleSigner
-func NewSimpleSigner(wallet core.Wallet, slashingProtector core.SlashingProtector, network Network) *SimpleSigner {
+type options struct {
+	strictDomains bool
+}
+
+// Option gives options to NewSimpleSigner
+type Option interface {
+	apply(*options)
+}
+
+type optionFunc func(*options)
+
+func (f optionFunc) apply(o *options) {
+	f(o)
+}
+
+// WithStrictDomains makes the signer reject domains of another type than the signed message,
+// or built for another network than its own. The network must implement ForkSchedule, like core.Network.
+func WithStrictDomains() Option {
+	return optionFunc(func(o *options) {
+		o.strictDomains = true
+	})
+}
+
+// NewSimpleSigner is the constructor of SimpleSigner.
+// This takes the following options:
+// - strict domains: domains are checked against the signed message and the network, see WithStrictDomains
+func NewSimpleSigner(wallet core.Wallet, slashingProtector core.SlashingProtector, network Network, opts ...Option) *SimpleSigner {
+	options := options{}
+	for _, o := range opts {
+		o.apply(&options)
+	}
+
 	return &SimpleSigner{
 		wallet:            wallet,
 		slashingProtector: slashingProtector,
 		network:           network,
 		signLocks:         map[string]*sync.RWMutex{},
 		mapLock:           &sync.RWMutex{},
+		strictDomains:     options.strictDomains,
 	}
 }
 
@@ -479,3 +479,94 @@ func TestAttestationData(t *testing.T) {
 		require.EqualError(t, err, "attestation data could not be nil")
 	})
 }
+
+func TestSigningRootResigning(t *testing.T) {
+	protector := NewNormalProtection(store())
+	pubKey := fullProtectionPubKey
+	root := _byteArray32("a1")
+
+	require.NoError(t, protector.UpdateHighestAttestation(pubKey, attestation(0, 0)))
+	require.NoError(t, protector.RecordAttestation(pubKey, attestation(2, 3), root))
+
+	tests := []struct {
+		name        string
+		source      phase0.Epoch
+		target      phase0.Epoch
+		signingRoot phase0.Root
+		slashable   bool
+	}{
+		{name: "same signing root", source: 2, target: 3, signingRoot: root},
+		{name: "different signing root", source: 2, target: 3, signingRoot: _byteArray32("ff"), slashable: true},
+		{name: "unknown signing root", source: 2, target: 3, slashable: true},
+		{name: "same signing root, different source", source: 1, target: 3, signingRoot: root, slashable: true},
+		{name: "lower target", source: 2, target: 2, signingRoot: root, slashable: true},
+		{name: "new attestation", source: 3, target: 4, signingRoot: _byteArray32("ff")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, err := protector.IsSlashableAttestationWithRoot(pubKey, attestation(test.source, test.target), test.signingRoot)
+			require.NoError(t, err)
+			if !test.slashable {
+				require.Nil(t, status)
+				return
+			}
+			require.NotNil(t, status)
+			require.Equal(t, core.HighestAttestationVote, status.Status)
+		})
+	}
+
+	t.Run("root of an older target is not trusted", func(t *testing.T) {
+		require.NoError(t, protector.UpdateHighestAttestation(pubKey, attestation(3, 4)))
+		status, err := protector.IsSlashableAttestationWithRoot(pubKey, attestation(2, 3), root)
+		require.NoError(t, err)
+		require.Equal(t, core.HighestAttestationVote, status.Status)
+	})
+
+	t.Run("proposal", func(t *testing.T) {
+		require.NoError(t, protector.RecordProposal(pubKey, 10, root))
+
+		status, err := protector.IsSlashableProposalWithRoot(pubKey, 10, root)
+		require.NoError(t, err)
+		require.Equal(t, core.ValidProposal, status.Status)
+
+		status, err = protector.IsSlashableProposalWithRoot(pubKey, 10, _byteArray32("ff"))
+		require.NoError(t, err)
+		require.Equal(t, core.HighestProposalVote, status.Status)
+
+		require.NoError(t, protector.UpdateHighestProposal(pubKey, 11))
+		status, err = protector.IsSlashableProposalWithRoot(pubKey, 10, root)
+		require.NoError(t, err)
+		require.Equal(t, core.HighestProposalVote, status.Status)
+	})
+}
+
+func TestProtectAttestations(t *testing.T) {
+	protector := NewNormalProtection(store())
+	pubKey := fullProtectionPubKey
+	unknown := _byteArray("a9cf360aa15fb1d1d30ee2b578dc5884823c19661886ae8b892775ccb3bd96b7d7345569a2aa0b14e4d015c54a6a0c54")
+	require.NoError(t, protector.UpdateHighestAttestation(pubKey, attestation(0, 0)))
+
+	statuses, errs := protector.ProtectAttestations([]*core.AttestationProtectionRequest{
+		{PubKey: pubKey, Attestation: attestation(1, 2), SigningRoot: _byteArray32("a1")},
+		{PubKey: pubKey, Attestation: attestation(1, 2), SigningRoot: _byteArray32("a2")},
+		{PubKey: unknown, Attestation: attestation(1, 2), SigningRoot: _byteArray32("a1")},
+		{PubKey: pubKey, Attestation: attestation(2, 3), SigningRoot: _byteArray32("a3")},
+	})
+	require.Len(t, statuses, 4)
+	require.Len(t, errs, 4)
+
+	require.NoError(t, errs[0])
+	require.Nil(t, statuses[0])
+	// requests are checked in order, the second one conflicts with the first
+	require.NoError(t, errs[1])
+	require.Equal(t, core.HighestAttestationVote, statuses[1].Status)
+	require.EqualError(t, errs[2], "highest attestation data is not found, can't determine if attestation is slashable")
+	require.NoError(t, errs[3])
+	require.Nil(t, statuses[3])
+
+	highest, found, err := protector.FetchHighestAttestation(pubKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 3, highest.Target.Epoch)
+}
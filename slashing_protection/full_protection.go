@@ -0,0 +1,228 @@
+package slashingprotection
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// FullProtection implements slashing protection against the complete signing history.
+// Unlike NormalProtection it detects double and surround votes against every signed attestation
+// and allows re-signing the exact same signing root, which is needed when a validator client restarts mid-slot.
+// Zero signing roots are treated as unknown and never allow a re-sign.
+type FullProtection struct {
+	store core.FullSlashingStore
+}
+
+// NewFullProtection is the constructor of FullProtection
+func NewFullProtection(store core.FullSlashingStore) *FullProtection {
+	return &FullProtection{store: store}
+}
+
+// IsSlashableAttestation detects double, surround and surrounded slashable events, without signing root
+func (protector *FullProtection) IsSlashableAttestation(pubKey []byte, attestation *phase0.AttestationData) (*core.AttestationSlashStatus, error) {
+	return protector.IsSlashableAttestationWithRoot(pubKey, attestation, phase0.Root{})
+}
+
+// IsSlashableAttestationWithRoot detects double, surround and surrounded slashable events.
+// An attestation already signed with the same signing root is not slashable.
+func (protector *FullProtection) IsSlashableAttestationWithRoot(pubKey []byte, attestation *phase0.AttestationData, signingRoot phase0.Root) (*core.AttestationSlashStatus, error) {
+	if attestation == nil || attestation.Source == nil || attestation.Target == nil {
+		return nil, errors.New("attestation data could not be nil")
+	}
+	if attestation.Source.Epoch > attestation.Target.Epoch {
+		return nil, errors.New("source epoch can not be greater than target epoch")
+	}
+
+	history, err := protector.store.ListSignedAttestations(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list signed attestations")
+	}
+	if len(history) == 0 {
+		return nil, errors.New("attestation history is not found, can't determine if attestation is slashable")
+	}
+
+	source, target := attestation.Source.Epoch, attestation.Target.Epoch
+	minSource, minTarget := history[0].SourceEpoch, history[0].TargetEpoch
+	for _, record := range history {
+		if record.TargetEpoch == target {
+			if isKnownRoot(signingRoot) && record.SigningRoot == signingRoot {
+				return nil, nil
+			}
+			return slashableAttestation(attestation, core.DoubleVote), nil
+		}
+		if record.SourceEpoch < source && target < record.TargetEpoch {
+			return slashableAttestation(attestation, core.SurroundedVote), nil
+		}
+		if source < record.SourceEpoch && record.TargetEpoch < target {
+			return slashableAttestation(attestation, core.SurroundingVote), nil
+		}
+		if record.SourceEpoch < minSource {
+			minSource = record.SourceEpoch
+		}
+	}
+
+	// history below the lowest record is unknown (pruned or never imported), refuse anything there
+	if source < minSource || target <= minTarget {
+		return slashableAttestation(attestation, core.HighestAttestationVote), nil
+	}
+	return nil, nil
+}
+
+// IsSlashableProposal detects slashable proposal request, without signing root
+func (protector *FullProtection) IsSlashableProposal(pubKey []byte, slot phase0.Slot) (*core.ProposalSlashStatus, error) {
+	return protector.IsSlashableProposalWithRoot(pubKey, slot, phase0.Root{})
+}
+
+// IsSlashableProposalWithRoot detects slashable proposal request.
+// A proposal already signed with the same signing root is valid.
+func (protector *FullProtection) IsSlashableProposalWithRoot(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) (*core.ProposalSlashStatus, error) {
+	if slot == 0 {
+		return nil, errors.New("proposal slot can not be 0")
+	}
+
+	history, err := protector.store.ListSignedProposals(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list signed proposals")
+	}
+	if len(history) == 0 {
+		return nil, errors.New("proposal history is not found, can't determine if proposal is slashable")
+	}
+
+	for _, record := range history {
+		if record.Slot == slot {
+			if isKnownRoot(signingRoot) && record.SigningRoot == signingRoot {
+				return &core.ProposalSlashStatus{Slot: slot, Status: core.ValidProposal}, nil
+			}
+			return &core.ProposalSlashStatus{Slot: slot, Status: core.DoubleProposal}, nil
+		}
+	}
+
+	if slot <= history[0].Slot {
+		return &core.ProposalSlashStatus{Slot: slot, Status: core.HighestProposalVote}, nil
+	}
+	return &core.ProposalSlashStatus{Slot: slot, Status: core.ValidProposal}, nil
+}
+
+// UpdateHighestAttestation records the given attestation, without signing root
+func (protector *FullProtection) UpdateHighestAttestation(pubKey []byte, attestation *phase0.AttestationData) error {
+	return protector.RecordAttestation(pubKey, attestation, phase0.Root{})
+}
+
+// RecordAttestation records the given signed attestation
+func (protector *FullProtection) RecordAttestation(pubKey []byte, attestation *phase0.AttestationData, signingRoot phase0.Root) error {
+	if attestation == nil || attestation.Source == nil || attestation.Target == nil {
+		return errors.New("attestation data could not be nil")
+	}
+
+	err := protector.store.SaveSignedAttestation(pubKey, &core.SignedAttestation{
+		SourceEpoch: attestation.Source.Epoch,
+		TargetEpoch: attestation.Target.Epoch,
+		SigningRoot: signingRoot,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not save signed attestation")
+	}
+	return nil
+}
+
+// UpdateHighestProposal records the given proposal, without signing root
+func (protector *FullProtection) UpdateHighestProposal(pubKey []byte, slot phase0.Slot) error {
+	return protector.RecordProposal(pubKey, slot, phase0.Root{})
+}
+
+// RecordProposal records the given signed proposal
+func (protector *FullProtection) RecordProposal(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) error {
+	if slot == 0 {
+		return errors.New("proposal slot can not be 0")
+	}
+
+	err := protector.store.SaveSignedProposal(pubKey, &core.SignedProposal{
+		Slot:        slot,
+		SigningRoot: signingRoot,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not save signed proposal")
+	}
+	return nil
+}
+
+// PruneAttestations deletes the attestations with a target epoch lower than the given watermark.
+// The latest attestation is always kept so the history never becomes empty.
+func (protector *FullProtection) PruneAttestations(pubKey []byte, watermark phase0.Epoch) error {
+	history, err := protector.store.ListSignedAttestations(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not list signed attestations")
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	if latest := history[len(history)-1].TargetEpoch; latest < watermark {
+		watermark = latest
+	}
+	return protector.store.PruneSignedAttestations(pubKey, watermark)
+}
+
+// PruneProposals deletes the proposals with a slot lower than the given watermark.
+// The latest proposal is always kept so the history never becomes empty.
+func (protector *FullProtection) PruneProposals(pubKey []byte, watermark phase0.Slot) error {
+	history, err := protector.store.ListSignedProposals(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not list signed proposals")
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	if latest := history[len(history)-1].Slot; latest < watermark {
+		watermark = latest
+	}
+	return protector.store.PruneSignedProposals(pubKey, watermark)
+}
+
+// FetchHighestAttestation returns the highest source and target epochs of the history
+func (protector *FullProtection) FetchHighestAttestation(pubKey []byte) (*phase0.AttestationData, bool, error) {
+	history, err := protector.store.ListSignedAttestations(pubKey)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not list signed attestations")
+	}
+	if len(history) == 0 {
+		return nil, false, nil
+	}
+
+	ret := &phase0.AttestationData{
+		Source: &phase0.Checkpoint{},
+		Target: &phase0.Checkpoint{Epoch: history[len(history)-1].TargetEpoch},
+	}
+	for _, record := range history {
+		if record.SourceEpoch > ret.Source.Epoch {
+			ret.Source.Epoch = record.SourceEpoch
+		}
+	}
+	return ret, true, nil
+}
+
+// FetchHighestProposal returns the highest slot of the history
+func (protector *FullProtection) FetchHighestProposal(pubKey []byte) (phase0.Slot, bool, error) {
+	history, err := protector.store.ListSignedProposals(pubKey)
+	if err != nil {
+		return 0, false, errors.Wrap(err, "could not list signed proposals")
+	}
+	if len(history) == 0 {
+		return 0, false, nil
+	}
+	return history[len(history)-1].Slot, true, nil
+}
+
+func slashableAttestation(attestation *phase0.AttestationData, status core.VoteDetectionType) *core.AttestationSlashStatus {
+	return &core.AttestationSlashStatus{
+		Attestation: attestation,
+		Status:      status,
+	}
+}
+
+func isKnownRoot(root phase0.Root) bool {
+	return root != phase0.Root{}
+}
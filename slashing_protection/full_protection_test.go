@@ -0,0 +1,140 @@
+package slashingprotection
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+var fullProtectionPubKey = _byteArray("95087182937f6982ae99f9b06bd116f463f414513032e33a3d175d9662eddf162101fcf6ca2a9fedaded74b8047c5dcf")
+
+func attestation(source, target phase0.Epoch) *phase0.AttestationData {
+	return &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: source},
+		Target: &phase0.Checkpoint{Epoch: target},
+	}
+}
+
+func setupFullProtection(t *testing.T) *FullProtection {
+	protector := NewFullProtection(store())
+	require.NoError(t, protector.RecordAttestation(fullProtectionPubKey, attestation(2, 3), _byteArray32("a1")))
+	require.NoError(t, protector.RecordAttestation(fullProtectionPubKey, attestation(3, 4), _byteArray32("a2")))
+	require.NoError(t, protector.RecordAttestation(fullProtectionPubKey, attestation(4, 10), _byteArray32("a3")))
+	require.NoError(t, protector.RecordProposal(fullProtectionPubKey, 100, _byteArray32("b1")))
+	require.NoError(t, protector.RecordProposal(fullProtectionPubKey, 110, _byteArray32("b2")))
+	return protector
+}
+
+func TestFullProtectionAttestation(t *testing.T) {
+	protector := setupFullProtection(t)
+
+	tests := []struct {
+		name        string
+		source      phase0.Epoch
+		target      phase0.Epoch
+		signingRoot phase0.Root
+		expected    core.VoteDetectionType
+	}{
+		{name: "new attestation", source: 10, target: 11},
+		{name: "re-sign same signing root", source: 3, target: 4, signingRoot: _byteArray32("a2")},
+		{name: "double vote", source: 3, target: 4, signingRoot: _byteArray32("ff"), expected: core.DoubleVote},
+		{name: "double vote unknown signing root", source: 3, target: 4, expected: core.DoubleVote},
+		{name: "surrounded vote", source: 5, target: 9, expected: core.SurroundedVote},
+		{name: "surrounding vote", source: 3, target: 11, expected: core.SurroundingVote},
+		{name: "gap between records", source: 4, target: 5},
+		{name: "source below history", source: 1, target: 12, expected: core.SurroundingVote},
+		{name: "target below history", source: 2, target: 2, expected: core.HighestAttestationVote},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, err := protector.IsSlashableAttestationWithRoot(fullProtectionPubKey, attestation(test.source, test.target), test.signingRoot)
+			require.NoError(t, err)
+			if len(test.expected) == 0 {
+				require.Nil(t, status)
+				return
+			}
+			require.NotNil(t, status)
+			require.Equal(t, test.expected, status.Status)
+		})
+	}
+
+	t.Run("no history", func(t *testing.T) {
+		_, err := NewFullProtection(store()).IsSlashableAttestation(fullProtectionPubKey, attestation(1, 2))
+		require.EqualError(t, err, "attestation history is not found, can't determine if attestation is slashable")
+	})
+
+	t.Run("highest attestation", func(t *testing.T) {
+		highest, found, err := protector.FetchHighestAttestation(fullProtectionPubKey)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 4, highest.Source.Epoch)
+		require.EqualValues(t, 10, highest.Target.Epoch)
+	})
+}
+
+func TestFullProtectionProposal(t *testing.T) {
+	protector := setupFullProtection(t)
+
+	tests := []struct {
+		name        string
+		slot        phase0.Slot
+		signingRoot phase0.Root
+		expected    core.ProposalDetectionType
+	}{
+		{name: "new proposal", slot: 111, expected: core.ValidProposal},
+		{name: "between records", slot: 105, expected: core.ValidProposal},
+		{name: "re-sign same signing root", slot: 110, signingRoot: _byteArray32("b2"), expected: core.ValidProposal},
+		{name: "double proposal", slot: 110, signingRoot: _byteArray32("ff"), expected: core.DoubleProposal},
+		{name: "double proposal unknown signing root", slot: 100, expected: core.DoubleProposal},
+		{name: "below history", slot: 99, expected: core.HighestProposalVote},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, err := protector.IsSlashableProposalWithRoot(fullProtectionPubKey, test.slot, test.signingRoot)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, status.Status)
+		})
+	}
+
+	t.Run("no history", func(t *testing.T) {
+		_, err := NewFullProtection(store()).IsSlashableProposal(fullProtectionPubKey, 1)
+		require.EqualError(t, err, "proposal history is not found, can't determine if proposal is slashable")
+	})
+}
+
+func TestFullProtectionPruning(t *testing.T) {
+	protector := setupFullProtection(t)
+
+	require.NoError(t, protector.PruneAttestations(fullProtectionPubKey, 4))
+	// the gap between target 4 and 10 is still signable, anything at or below the watermark is not
+	status, err := protector.IsSlashableAttestation(fullProtectionPubKey, attestation(4, 5))
+	require.NoError(t, err)
+	require.Nil(t, status)
+	status, err = protector.IsSlashableAttestation(fullProtectionPubKey, attestation(2, 3))
+	require.NoError(t, err)
+	require.Equal(t, core.HighestAttestationVote, status.Status)
+
+	// pruning above the latest record keeps it
+	require.NoError(t, protector.PruneAttestations(fullProtectionPubKey, 100))
+	highest, found, err := protector.FetchHighestAttestation(fullProtectionPubKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 10, highest.Target.Epoch)
+	status, err = protector.IsSlashableAttestation(fullProtectionPubKey, attestation(4, 5))
+	require.NoError(t, err)
+	require.Equal(t, core.HighestAttestationVote, status.Status)
+
+	require.NoError(t, protector.PruneProposals(fullProtectionPubKey, 1000))
+	slot, found, err := protector.FetchHighestProposal(fullProtectionPubKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 110, slot)
+	proposalStatus, err := protector.IsSlashableProposal(fullProtectionPubKey, 105)
+	require.NoError(t, err)
+	require.Equal(t, core.HighestProposalVote, proposalStatus.Status)
+}
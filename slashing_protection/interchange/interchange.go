@@ -0,0 +1,363 @@
+package interchange
+
+import (
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// FormatVersion is the EIP-3076 interchange format version supported by this package.
+const FormatVersion = "5"
+
+// Interchange represents the EIP-3076 slashing protection interchange format.
+// https://eips.ethereum.org/EIPS/eip-3076
+type Interchange struct {
+	Metadata *Metadata `json:"metadata"`
+	Data     []*Data   `json:"data"`
+}
+
+// Metadata represents the interchange metadata
+type Metadata struct {
+	InterchangeFormatVersion string      `json:"interchange_format_version"`
+	GenesisValidatorsRoot    phase0.Root `json:"genesis_validators_root"`
+}
+
+// Data holds the signing history of a single validator
+type Data struct {
+	PublicKey          phase0.BLSPubKey     `json:"pubkey"`
+	SignedBlocks       []*SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []*SignedAttestation `json:"signed_attestations"`
+}
+
+// SignedBlock represents a signed block record
+type SignedBlock struct {
+	Slot        phase0.Slot  `json:"slot"`
+	SigningRoot *phase0.Root `json:"signing_root,omitempty"`
+}
+
+// SignedAttestation represents a signed attestation record
+type SignedAttestation struct {
+	SourceEpoch phase0.Epoch `json:"source_epoch"`
+	TargetEpoch phase0.Epoch `json:"target_epoch"`
+	SigningRoot *phase0.Root `json:"signing_root,omitempty"`
+}
+
+// validatorRecords holds the highest values and the complete history found for a single validator
+type validatorRecords struct {
+	pubKey      []byte
+	source      phase0.Epoch
+	target      phase0.Epoch
+	hasAtt      bool
+	slot        phase0.Slot
+	hasProposal bool
+
+	attestations []*SignedAttestation
+	blocks       []*SignedBlock
+}
+
+// Parse parses the given EIP-3076 JSON and validates it against the given network.
+// Both the minimal and the complete formats are accepted.
+func Parse(data []byte, network core.Network) (*Interchange, error) {
+	ret := &Interchange{}
+	if err := json.Unmarshal(data, ret); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal interchange")
+	}
+	if err := ret.Validate(network); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Validate checks the interchange metadata against the given network
+func (i *Interchange) Validate(network core.Network) error {
+	if i.Metadata == nil {
+		return errors.New("interchange metadata is missing")
+	}
+	if i.Metadata.InterchangeFormatVersion != FormatVersion {
+		return errors.Errorf("unsupported interchange format version %s", i.Metadata.InterchangeFormatVersion)
+	}
+	if _, err := network.Config(); err != nil {
+		return err
+	}
+	if i.Metadata.GenesisValidatorsRoot != network.GenesisValidatorsRoot() {
+		return errors.Errorf("genesis validators root %s does not match network %s", i.Metadata.GenesisValidatorsRoot, network)
+	}
+	return nil
+}
+
+// Import merges the given EIP-3076 JSON into the slashing store.
+// Stored values are only ever raised, never lowered.
+func Import(data []byte, store core.SlashingStore, network core.Network) error {
+	parsed, err := Parse(data, network)
+	if err != nil {
+		return err
+	}
+	return parsed.ImportTo(store)
+}
+
+// ImportTo merges the interchange into the slashing store.
+// If the store implements core.FullSlashingStore the complete history is imported as well,
+// records already in the store are kept as is.
+// The whole interchange is processed before anything is written, so a malformed entry will not leave a partial import.
+func (i *Interchange) ImportTo(store core.SlashingStore) error {
+	records := make([]*validatorRecords, 0, len(i.Data))
+	seen := make(map[string]*validatorRecords)
+	for _, d := range i.Data {
+		if d == nil {
+			return errors.New("interchange data entry could not be nil")
+		}
+
+		pubKey := hex.EncodeToString(d.PublicKey[:])
+		rec, exists := seen[pubKey]
+		if !exists {
+			rec = &validatorRecords{pubKey: d.PublicKey[:]}
+			seen[pubKey] = rec
+			records = append(records, rec)
+		}
+
+		for _, block := range d.SignedBlocks {
+			if block == nil {
+				return errors.Errorf("signed block could not be nil (pubkey %s)", pubKey)
+			}
+			if !rec.hasProposal || block.Slot > rec.slot {
+				rec.slot = block.Slot
+				rec.hasProposal = true
+			}
+			rec.blocks = append(rec.blocks, block)
+		}
+
+		for _, att := range d.SignedAttestations {
+			if att == nil {
+				return errors.Errorf("signed attestation could not be nil (pubkey %s)", pubKey)
+			}
+			if att.SourceEpoch > att.TargetEpoch {
+				return errors.Errorf("source epoch %d is greater than target epoch %d (pubkey %s)", att.SourceEpoch, att.TargetEpoch, pubKey)
+			}
+			if !rec.hasAtt || att.SourceEpoch > rec.source {
+				rec.source = att.SourceEpoch
+			}
+			if !rec.hasAtt || att.TargetEpoch > rec.target {
+				rec.target = att.TargetEpoch
+			}
+			rec.hasAtt = true
+			rec.attestations = append(rec.attestations, att)
+		}
+	}
+
+	for _, rec := range records {
+		if err := mergeAttestation(store, rec); err != nil {
+			return errors.Wrapf(err, "failed to import attestations (pubkey %x)", rec.pubKey)
+		}
+		if err := mergeProposal(store, rec); err != nil {
+			return errors.Wrapf(err, "failed to import proposals (pubkey %x)", rec.pubKey)
+		}
+		if full, ok := store.(core.FullSlashingStore); ok {
+			if err := importHistory(full, rec); err != nil {
+				return errors.Wrapf(err, "failed to import history (pubkey %x)", rec.pubKey)
+			}
+		}
+	}
+	return nil
+}
+
+func importHistory(store core.FullSlashingStore, rec *validatorRecords) error {
+	existingAtts, err := store.ListSignedAttestations(rec.pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not list signed attestations")
+	}
+	knownTargets := make(map[phase0.Epoch]bool, len(existingAtts))
+	for _, att := range existingAtts {
+		knownTargets[att.TargetEpoch] = true
+	}
+	for _, att := range rec.attestations {
+		if knownTargets[att.TargetEpoch] {
+			continue
+		}
+		knownTargets[att.TargetEpoch] = true
+
+		record := &core.SignedAttestation{SourceEpoch: att.SourceEpoch, TargetEpoch: att.TargetEpoch}
+		if att.SigningRoot != nil {
+			record.SigningRoot = *att.SigningRoot
+		}
+		if err := store.SaveSignedAttestation(rec.pubKey, record); err != nil {
+			return errors.Wrap(err, "could not save signed attestation")
+		}
+	}
+
+	existingBlocks, err := store.ListSignedProposals(rec.pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not list signed proposals")
+	}
+	knownSlots := make(map[phase0.Slot]bool, len(existingBlocks))
+	for _, block := range existingBlocks {
+		knownSlots[block.Slot] = true
+	}
+	for _, block := range rec.blocks {
+		if block.Slot == 0 || knownSlots[block.Slot] {
+			continue
+		}
+		knownSlots[block.Slot] = true
+
+		record := &core.SignedProposal{Slot: block.Slot}
+		if block.SigningRoot != nil {
+			record.SigningRoot = *block.SigningRoot
+		}
+		if err := store.SaveSignedProposal(rec.pubKey, record); err != nil {
+			return errors.Wrap(err, "could not save signed proposal")
+		}
+	}
+	return nil
+}
+
+func mergeAttestation(store core.SlashingStore, rec *validatorRecords) error {
+	if !rec.hasAtt {
+		return nil
+	}
+
+	source, target := rec.source, rec.target
+	highest, found, err := store.RetrieveHighestAttestation(rec.pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve highest attestation")
+	}
+	if found && highest != nil {
+		if highest.Source != nil && highest.Source.Epoch > source {
+			source = highest.Source.Epoch
+		}
+		if highest.Target != nil && highest.Target.Epoch > target {
+			target = highest.Target.Epoch
+		}
+		if highest.Source != nil && highest.Target != nil && highest.Source.Epoch == source && highest.Target.Epoch == target {
+			return nil
+		}
+	}
+
+	return store.SaveHighestAttestation(rec.pubKey, &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: source},
+		Target: &phase0.Checkpoint{Epoch: target},
+	})
+}
+
+func mergeProposal(store core.SlashingStore, rec *validatorRecords) error {
+	// slot 0 is the genesis block which can't be proposed, nothing to protect
+	if !rec.hasProposal || rec.slot == 0 {
+		return nil
+	}
+
+	highest, found, err := store.RetrieveHighestProposal(rec.pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve highest proposal")
+	}
+	if found && highest >= rec.slot {
+		return nil
+	}
+	return store.SaveHighestProposal(rec.pubKey, rec.slot)
+}
+
+// Export builds an EIP-3076 interchange with the highest attestation and proposal of each given public key.
+// If the store implements core.FullSlashingStore the complete history is exported as well.
+// Public keys with no slashing history are included with empty records.
+func Export(store core.SlashingStore, network core.Network, pubKeys [][]byte) (*Interchange, error) {
+	if _, err := network.Config(); err != nil {
+		return nil, err
+	}
+	ret := &Interchange{
+		Metadata: &Metadata{
+			InterchangeFormatVersion: FormatVersion,
+			GenesisValidatorsRoot:    network.GenesisValidatorsRoot(),
+		},
+		Data: make([]*Data, 0, len(pubKeys)),
+	}
+
+	for _, pubKey := range pubKeys {
+		if len(pubKey) != phase0.PublicKeyLength {
+			return nil, errors.Errorf("invalid public key length %d", len(pubKey))
+		}
+
+		d := &Data{
+			SignedBlocks:       make([]*SignedBlock, 0),
+			SignedAttestations: make([]*SignedAttestation, 0),
+		}
+		copy(d.PublicKey[:], pubKey)
+
+		knownTargets := make(map[phase0.Epoch]bool)
+		knownSlots := make(map[phase0.Slot]bool)
+		if full, ok := store.(core.FullSlashingStore); ok {
+			if err := exportHistory(full, d, knownTargets, knownSlots); err != nil {
+				return nil, err
+			}
+		}
+
+		highestAtt, found, err := store.RetrieveHighestAttestation(pubKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not retrieve highest attestation")
+		}
+		if found && highestAtt != nil && highestAtt.Source != nil && highestAtt.Target != nil && !knownTargets[highestAtt.Target.Epoch] {
+			d.SignedAttestations = append(d.SignedAttestations, &SignedAttestation{
+				SourceEpoch: highestAtt.Source.Epoch,
+				TargetEpoch: highestAtt.Target.Epoch,
+			})
+		}
+
+		highestProposal, found, err := store.RetrieveHighestProposal(pubKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not retrieve highest proposal")
+		}
+		if found && highestProposal != 0 && !knownSlots[highestProposal] {
+			d.SignedBlocks = append(d.SignedBlocks, &SignedBlock{
+				Slot: highestProposal,
+			})
+		}
+
+		ret.Data = append(ret.Data, d)
+	}
+	return ret, nil
+}
+
+func exportHistory(store core.FullSlashingStore, d *Data, knownTargets map[phase0.Epoch]bool, knownSlots map[phase0.Slot]bool) error {
+	atts, err := store.ListSignedAttestations(d.PublicKey[:])
+	if err != nil {
+		return errors.Wrap(err, "could not list signed attestations")
+	}
+	for _, att := range atts {
+		knownTargets[att.TargetEpoch] = true
+		d.SignedAttestations = append(d.SignedAttestations, &SignedAttestation{
+			SourceEpoch: att.SourceEpoch,
+			TargetEpoch: att.TargetEpoch,
+			SigningRoot: signingRootOrNil(att.SigningRoot),
+		})
+	}
+
+	blocks, err := store.ListSignedProposals(d.PublicKey[:])
+	if err != nil {
+		return errors.Wrap(err, "could not list signed proposals")
+	}
+	for _, block := range blocks {
+		knownSlots[block.Slot] = true
+		d.SignedBlocks = append(d.SignedBlocks, &SignedBlock{
+			Slot:        block.Slot,
+			SigningRoot: signingRootOrNil(block.SigningRoot),
+		})
+	}
+	return nil
+}
+
+// signingRootOrNil returns nil for unknown (zero) signing roots so they are omitted
+func signingRootOrNil(root phase0.Root) *phase0.Root {
+	if root == (phase0.Root{}) {
+		return nil
+	}
+	return &root
+}
+
+// ExportJSON is the same as Export but returns the marshaled interchange
+func ExportJSON(store core.SlashingStore, network core.Network, pubKeys [][]byte) ([]byte, error) {
+	ret, err := Export(store, network, pubKeys)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(ret)
+}
@@ -0,0 +1,236 @@
+package interchange
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+)
+
+const (
+	pubKey1 = "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	pubKey2 = "b845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+)
+
+func _byteArray(input string) []byte {
+	res, _ := hex.DecodeString(input)
+	return res
+}
+
+func interchangeJSON(gvr phase0.Root, data string) []byte {
+	return []byte(fmt.Sprintf(`{
+		"metadata": {
+			"interchange_format_version": "5",
+			"genesis_validators_root": "%#x"
+		},
+		"data": %s
+	}`, gvr, data))
+}
+
+func TestImport(t *testing.T) {
+	network := core.MainNetwork
+
+	t.Run("complete format keeps the highest values", func(t *testing.T) {
+		store := inmemory.NewInMemStore(network)
+		data := interchangeJSON(network.GenesisValidatorsRoot(), `[
+			{
+				"pubkey": "0x`+pubKey1+`",
+				"signed_blocks": [
+					{"slot": "81952", "signing_root": "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"},
+					{"slot": "81951"}
+				],
+				"signed_attestations": [
+					{"source_epoch": "2290", "target_epoch": "3007", "signing_root": "0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"},
+					{"source_epoch": "2291", "target_epoch": "3006"}
+				]
+			},
+			{
+				"pubkey": "0x`+pubKey2+`",
+				"signed_blocks": [],
+				"signed_attestations": [
+					{"source_epoch": "10", "target_epoch": "11"}
+				]
+			}
+		]`)
+
+		require.NoError(t, Import(data, store, network))
+
+		att, found, err := store.RetrieveHighestAttestation(_byteArray(pubKey1))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 2291, att.Source.Epoch)
+		require.EqualValues(t, 3007, att.Target.Epoch)
+
+		slot, found, err := store.RetrieveHighestProposal(_byteArray(pubKey1))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 81952, slot)
+
+		att, found, err = store.RetrieveHighestAttestation(_byteArray(pubKey2))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 10, att.Source.Epoch)
+		require.EqualValues(t, 11, att.Target.Epoch)
+
+		_, found, err = store.RetrieveHighestProposal(_byteArray(pubKey2))
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+
+	t.Run("never lowers existing values", func(t *testing.T) {
+		store := inmemory.NewInMemStore(network)
+		require.NoError(t, store.SaveHighestAttestation(_byteArray(pubKey1), &phase0.AttestationData{
+			Source: &phase0.Checkpoint{Epoch: 100},
+			Target: &phase0.Checkpoint{Epoch: 50},
+		}))
+		require.NoError(t, store.SaveHighestProposal(_byteArray(pubKey1), 1000))
+
+		data := interchangeJSON(network.GenesisValidatorsRoot(), `[
+			{
+				"pubkey": "0x`+pubKey1+`",
+				"signed_blocks": [{"slot": "10"}],
+				"signed_attestations": [{"source_epoch": "20", "target_epoch": "200"}]
+			}
+		]`)
+		require.NoError(t, Import(data, store, network))
+
+		att, _, err := store.RetrieveHighestAttestation(_byteArray(pubKey1))
+		require.NoError(t, err)
+		require.EqualValues(t, 100, att.Source.Epoch)
+		require.EqualValues(t, 200, att.Target.Epoch)
+
+		slot, _, err := store.RetrieveHighestProposal(_byteArray(pubKey1))
+		require.NoError(t, err)
+		require.EqualValues(t, 1000, slot)
+	})
+
+	t.Run("genesis validators root mismatch", func(t *testing.T) {
+		store := inmemory.NewInMemStore(network)
+		data := interchangeJSON(core.PraterNetwork.GenesisValidatorsRoot(), `[]`)
+		err := Import(data, store, network)
+		require.EqualError(t, err, fmt.Sprintf("genesis validators root %#x does not match network mainnet", core.PraterNetwork.GenesisValidatorsRoot()))
+	})
+
+	t.Run("unsupported format version", func(t *testing.T) {
+		store := inmemory.NewInMemStore(network)
+		data := []byte(`{"metadata": {"interchange_format_version": "4", "genesis_validators_root": "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"}, "data": []}`)
+		require.EqualError(t, Import(data, store, network), "unsupported interchange format version 4")
+	})
+
+	t.Run("source greater than target is rejected without partial import", func(t *testing.T) {
+		store := inmemory.NewInMemStore(network)
+		data := interchangeJSON(network.GenesisValidatorsRoot(), `[
+			{
+				"pubkey": "0x`+pubKey1+`",
+				"signed_blocks": [{"slot": "10"}],
+				"signed_attestations": []
+			},
+			{
+				"pubkey": "0x`+pubKey2+`",
+				"signed_blocks": [],
+				"signed_attestations": [{"source_epoch": "20", "target_epoch": "10"}]
+			}
+		]`)
+		require.EqualError(t, Import(data, store, network), "source epoch 20 is greater than target epoch 10 (pubkey "+pubKey2+")")
+
+		_, found, err := store.RetrieveHighestProposal(_byteArray(pubKey1))
+		require.NoError(t, err)
+		require.False(t, found)
+	})
+}
+
+func TestExport(t *testing.T) {
+	network := core.MainNetwork
+	store := inmemory.NewInMemStore(network)
+	require.NoError(t, store.SaveHighestAttestation(_byteArray(pubKey1), &phase0.AttestationData{
+		Source: &phase0.Checkpoint{Epoch: 5},
+		Target: &phase0.Checkpoint{Epoch: 6},
+	}))
+	require.NoError(t, store.SaveHighestProposal(_byteArray(pubKey1), 77))
+
+	byts, err := ExportJSON(store, network, [][]byte{_byteArray(pubKey1), _byteArray(pubKey2)})
+	require.NoError(t, err)
+
+	expected := interchangeJSON(network.GenesisValidatorsRoot(), `[
+		{
+			"pubkey": "0x`+pubKey1+`",
+			"signed_blocks": [{"slot": "77"}],
+			"signed_attestations": [{"source_epoch": "5", "target_epoch": "6"}]
+		},
+		{
+			"pubkey": "0x`+pubKey2+`",
+			"signed_blocks": [],
+			"signed_attestations": []
+		}
+	]`)
+	require.JSONEq(t, string(expected), string(byts))
+
+	t.Run("round trip", func(t *testing.T) {
+		newStore := inmemory.NewInMemStore(network)
+		require.NoError(t, Import(byts, newStore, network))
+
+		att, found, err := newStore.RetrieveHighestAttestation(_byteArray(pubKey1))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 5, att.Source.Epoch)
+		require.EqualValues(t, 6, att.Target.Epoch)
+
+		slot, found, err := newStore.RetrieveHighestProposal(_byteArray(pubKey1))
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 77, slot)
+	})
+
+	t.Run("invalid public key", func(t *testing.T) {
+		_, err := Export(store, network, [][]byte{{0x01}})
+		require.EqualError(t, err, "invalid public key length 1")
+	})
+
+	t.Run("output is valid interchange", func(t *testing.T) {
+		ret := &Interchange{}
+		require.NoError(t, json.Unmarshal(byts, ret))
+		require.NoError(t, ret.Validate(network))
+	})
+}
+
+func TestCompleteHistory(t *testing.T) {
+	network := core.MainNetwork
+	store := inmemory.NewInMemStore(network)
+	data := interchangeJSON(network.GenesisValidatorsRoot(), `[
+		{
+			"pubkey": "0x`+pubKey1+`",
+			"signed_blocks": [
+				{"slot": "81951"},
+				{"slot": "81952", "signing_root": "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"}
+			],
+			"signed_attestations": [
+				{"source_epoch": "2290", "target_epoch": "3006"},
+				{"source_epoch": "2290", "target_epoch": "3007", "signing_root": "0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"}
+			]
+		}
+	]`)
+	require.NoError(t, Import(data, store, network))
+
+	atts, err := store.ListSignedAttestations(_byteArray(pubKey1))
+	require.NoError(t, err)
+	require.Len(t, atts, 2)
+	require.EqualValues(t, 3006, atts[0].TargetEpoch)
+	require.Equal(t, phase0.Root{}, atts[0].SigningRoot)
+	require.EqualValues(t, 3007, atts[1].TargetEpoch)
+	require.Equal(t, "587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d", hex.EncodeToString(atts[1].SigningRoot[:]))
+
+	blocks, err := store.ListSignedProposals(_byteArray(pubKey1))
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+
+	// the exported history is the imported one
+	byts, err := ExportJSON(store, network, [][]byte{_byteArray(pubKey1)})
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(byts))
+}
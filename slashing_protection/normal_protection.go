@@ -35,7 +35,7 @@ func (protector *NormalProtection) IsSlashableAttestation(pubKey []byte, attesta
 		// Source epoch can't be lower than previously known highest source, it can be equal or higher.
 		// We prevent double voting by rejecting another attestations with the same target epoch
 		// however you are eligible to sign the message with the same target epoch and the signing root,
-		// we are being strict by not storing the signing roots
+		// which is checked by IsSlashableAttestationWithRoot
 		if attestation.Source.Epoch < highest.Source.Epoch || attestation.Target.Epoch <= highest.Target.Epoch {
 			return &core.AttestationSlashStatus{
 				Attestation: attestation,
@@ -75,6 +75,120 @@ func (protector *NormalProtection) IsSlashableProposal(pubKey []byte, slot phase
 	}, nil
 }
 
+// IsSlashableAttestationWithRoot detects slashable events like IsSlashableAttestation,
+// but allows re-signing the highest attestation with the exact same signing root.
+func (protector *NormalProtection) IsSlashableAttestationWithRoot(pubKey []byte, attestation *phase0.AttestationData, signingRoot phase0.Root) (*core.AttestationSlashStatus, error) {
+	status, err := protector.IsSlashableAttestation(pubKey, attestation)
+	if err != nil || status == nil || status.Status != core.HighestAttestationVote {
+		return status, err
+	}
+
+	// the stored root is only trusted when it belongs to the current highest target
+	highest, _, err := protector.store.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve highest attestation")
+	}
+	rootEpoch, root, found, err := protector.store.RetrieveHighestAttestationSigningRoot(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve highest attestation signing root")
+	}
+	if found && isKnownRoot(signingRoot) && root == signingRoot &&
+		rootEpoch == attestation.Target.Epoch && highest.Target.Epoch == attestation.Target.Epoch &&
+		highest.Source.Epoch == attestation.Source.Epoch {
+		return nil, nil
+	}
+	return status, nil
+}
+
+// RecordAttestation updates the highest attestation and stores its signing root
+func (protector *NormalProtection) RecordAttestation(pubKey []byte, attestation *phase0.AttestationData, signingRoot phase0.Root) error {
+	if err := protector.UpdateHighestAttestation(pubKey, attestation); err != nil {
+		return err
+	}
+
+	highest, found, err := protector.store.RetrieveHighestAttestation(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve highest attestation")
+	}
+	if found && highest.Target.Epoch == attestation.Target.Epoch && highest.Source.Epoch == attestation.Source.Epoch {
+		if err := protector.store.SaveHighestAttestationSigningRoot(pubKey, attestation.Target.Epoch, signingRoot); err != nil {
+			return errors.Wrap(err, "could not save highest attestation signing root")
+		}
+	}
+	return nil
+}
+
+// IsSlashableProposalWithRoot detects slashable proposal request like IsSlashableProposal,
+// but allows re-signing the highest proposal with the exact same signing root.
+func (protector *NormalProtection) IsSlashableProposalWithRoot(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) (*core.ProposalSlashStatus, error) {
+	status, err := protector.IsSlashableProposal(pubKey, slot)
+	if err != nil || status.Status == core.ValidProposal {
+		return status, err
+	}
+
+	highest, _, err := protector.store.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve highest proposal")
+	}
+	rootSlot, root, found, err := protector.store.RetrieveHighestProposalSigningRoot(pubKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve highest proposal signing root")
+	}
+	if found && isKnownRoot(signingRoot) && root == signingRoot && rootSlot == slot && highest == slot {
+		return &core.ProposalSlashStatus{Slot: slot, Status: core.ValidProposal}, nil
+	}
+	return status, nil
+}
+
+// RecordProposal updates the highest proposal and stores its signing root
+func (protector *NormalProtection) RecordProposal(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) error {
+	if err := protector.UpdateHighestProposal(pubKey, slot); err != nil {
+		return err
+	}
+
+	highest, found, err := protector.store.RetrieveHighestProposal(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve highest proposal")
+	}
+	if found && highest == slot {
+		if err := protector.store.SaveHighestProposalSigningRoot(pubKey, slot, signingRoot); err != nil {
+			return errors.Wrap(err, "could not save highest proposal signing root")
+		}
+	}
+	return nil
+}
+
+// ProtectAttestations checks and records the attestations of many validators.
+// When the store implements core.SlashingStoreTransactor everything is done in a single store transaction.
+func (protector *NormalProtection) ProtectAttestations(requests []*core.AttestationProtectionRequest) ([]*core.AttestationSlashStatus, []error) {
+	statuses := make([]*core.AttestationSlashStatus, len(requests))
+	errs := make([]error, len(requests))
+	protect := func(store core.SlashingStore) error {
+		txProtector := NewNormalProtection(store)
+		for i, req := range requests {
+			statuses[i], errs[i] = txProtector.IsSlashableAttestationWithRoot(req.PubKey, req.Attestation, req.SigningRoot)
+			if errs[i] == nil && statuses[i] == nil {
+				errs[i] = txProtector.RecordAttestation(req.PubKey, req.Attestation, req.SigningRoot)
+			}
+		}
+		return nil
+	}
+
+	transactor, ok := protector.store.(core.SlashingStoreTransactor)
+	if !ok {
+		_ = protect(protector.store)
+		return statuses, errs
+	}
+	if err := transactor.SlashingTx(protect); err != nil {
+		// nothing was recorded, none of the attestations can be signed
+		for i := range requests {
+			statuses[i] = nil
+			errs[i] = errors.Wrap(err, "could not commit slashing protection")
+		}
+	}
+	return statuses, errs
+}
+
 // UpdateHighestAttestation potentially updates the highest attestation given this latest attestation.
 func (protector *NormalProtection) UpdateHighestAttestation(pubKey []byte, attestation *phase0.AttestationData) error {
 	if attestation == nil {
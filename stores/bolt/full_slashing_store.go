@@ -0,0 +1,163 @@
+package bolt
+
+import (
+	"encoding/binary"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	bbolt "go.etcd.io/bbolt"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// Records are kept in a nested bucket per public key.
+// Keys are big endian encoded so the bucket cursor iterates them in ascending order,
+// values are the little endian source epoch (attestations only) followed by the signing root.
+
+// SaveSignedAttestation saves the given attestation record
+func (store *BoltStore) SaveSignedAttestation(pubKey []byte, attestation *core.SignedAttestation) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+	if attestation == nil {
+		return errors.New("attestation could not be nil")
+	}
+
+	val := make([]byte, 8+len(attestation.SigningRoot))
+	binary.LittleEndian.PutUint64(val, uint64(attestation.SourceEpoch))
+	copy(val[8:], attestation.SigningRoot[:])
+
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.Bucket(signedAttestationsBucket).CreateBucketIfNotExists(pubKey)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(uint64Key(uint64(attestation.TargetEpoch)), val)
+	})
+}
+
+// ListSignedAttestations returns the attestation records sorted by target epoch
+func (store *BoltStore) ListSignedAttestations(pubKey []byte) ([]*core.SignedAttestation, error) {
+	if pubKey == nil {
+		return nil, errors.New("public key could not be nil")
+	}
+
+	ret := make([]*core.SignedAttestation, 0)
+	err := store.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(signedAttestationsBucket).Bucket(pubKey)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			if len(k) != 8 || len(v) != 8+32 {
+				return errors.New("invalid attestation record")
+			}
+			record := &core.SignedAttestation{
+				SourceEpoch: phase0.Epoch(binary.LittleEndian.Uint64(v)),
+				TargetEpoch: phase0.Epoch(binary.BigEndian.Uint64(k)),
+			}
+			copy(record.SigningRoot[:], v[8:])
+			ret = append(ret, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// PruneSignedAttestations deletes the attestation records with a target epoch lower than the given one
+func (store *BoltStore) PruneSignedAttestations(pubKey []byte, targetEpoch phase0.Epoch) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		return pruneBelow(tx.Bucket(signedAttestationsBucket).Bucket(pubKey), uint64(targetEpoch))
+	})
+}
+
+// SaveSignedProposal saves the given proposal record
+func (store *BoltStore) SaveSignedProposal(pubKey []byte, proposal *core.SignedProposal) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+	if proposal == nil {
+		return errors.New("proposal could not be nil")
+	}
+
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.Bucket(signedProposalsBucket).CreateBucketIfNotExists(pubKey)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(uint64Key(uint64(proposal.Slot)), proposal.SigningRoot[:])
+	})
+}
+
+// ListSignedProposals returns the proposal records sorted by slot
+func (store *BoltStore) ListSignedProposals(pubKey []byte) ([]*core.SignedProposal, error) {
+	if pubKey == nil {
+		return nil, errors.New("public key could not be nil")
+	}
+
+	ret := make([]*core.SignedProposal, 0)
+	err := store.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(signedProposalsBucket).Bucket(pubKey)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			if len(k) != 8 || len(v) != 32 {
+				return errors.New("invalid proposal record")
+			}
+			record := &core.SignedProposal{
+				Slot: phase0.Slot(binary.BigEndian.Uint64(k)),
+			}
+			copy(record.SigningRoot[:], v)
+			ret = append(ret, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// PruneSignedProposals deletes the proposal records with a slot lower than the given one
+func (store *BoltStore) PruneSignedProposals(pubKey []byte, slot phase0.Slot) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		return pruneBelow(tx.Bucket(signedProposalsBucket).Bucket(pubKey), uint64(slot))
+	})
+}
+
+func pruneBelow(bucket *bbolt.Bucket, watermark uint64) error {
+	if bucket == nil {
+		return nil
+	}
+
+	// keys are collected first, deleting while iterating a cursor can skip keys
+	var keys [][]byte
+	c := bucket.Cursor()
+	for k, _ := c.First(); k != nil && binary.BigEndian.Uint64(k) < watermark; k, _ = c.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+	for _, k := range keys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uint64Key(val uint64) []byte {
+	ret := make([]byte, 8)
+	binary.BigEndian.PutUint64(ret, val)
+	return ret
+}
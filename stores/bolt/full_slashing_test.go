@@ -0,0 +1,80 @@
+package bolt
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+func TestSignedAttestations(t *testing.T) {
+	store := newStore(t)
+	pubKey := []byte{0x01}
+
+	records, err := store.ListSignedAttestations(pubKey)
+	require.NoError(t, err)
+	require.Len(t, records, 0)
+
+	require.NoError(t, store.SaveSignedAttestation(pubKey, &core.SignedAttestation{SourceEpoch: 5, TargetEpoch: 6, SigningRoot: phase0.Root{0x06}}))
+	require.NoError(t, store.SaveSignedAttestation(pubKey, &core.SignedAttestation{SourceEpoch: 1, TargetEpoch: 2}))
+	require.NoError(t, store.SaveSignedAttestation(pubKey, &core.SignedAttestation{SourceEpoch: 2, TargetEpoch: 3, SigningRoot: phase0.Root{0x03}}))
+
+	records, err = store.ListSignedAttestations(pubKey)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.EqualValues(t, 2, records[0].TargetEpoch)
+	require.EqualValues(t, 3, records[1].TargetEpoch)
+	require.Equal(t, phase0.Root{0x03}, records[1].SigningRoot)
+	require.EqualValues(t, 6, records[2].TargetEpoch)
+
+	require.NoError(t, store.PruneSignedAttestations(pubKey, 3))
+	records, err = store.ListSignedAttestations(pubKey)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.EqualValues(t, 3, records[0].TargetEpoch)
+
+	t.Run("survives reopening", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "keymanager.db")
+		persisted, err := NewBoltStore(path, core.MainNetwork)
+		require.NoError(t, err)
+		require.NoError(t, persisted.SaveSignedAttestation(pubKey, &core.SignedAttestation{SourceEpoch: 2, TargetEpoch: 3, SigningRoot: phase0.Root{0x03}}))
+		require.NoError(t, persisted.Close())
+
+		reopened, err := NewBoltStore(path, core.MainNetwork)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, reopened.Close()) }()
+		records, err := reopened.ListSignedAttestations(pubKey)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, phase0.Root{0x03}, records[0].SigningRoot)
+	})
+
+	require.EqualError(t, store.SaveSignedAttestation(nil, &core.SignedAttestation{}), "public key could not be nil")
+	require.EqualError(t, store.SaveSignedAttestation(pubKey, nil), "attestation could not be nil")
+}
+
+func TestSignedProposals(t *testing.T) {
+	store := newStore(t)
+	pubKey := []byte{0x01}
+
+	require.NoError(t, store.SaveSignedProposal(pubKey, &core.SignedProposal{Slot: 20, SigningRoot: phase0.Root{0x20}}))
+	require.NoError(t, store.SaveSignedProposal(pubKey, &core.SignedProposal{Slot: 10}))
+
+	records, err := store.ListSignedProposals(pubKey)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.EqualValues(t, 10, records[0].Slot)
+	require.EqualValues(t, 20, records[1].Slot)
+	require.Equal(t, phase0.Root{0x20}, records[1].SigningRoot)
+
+	require.NoError(t, store.PruneSignedProposals(pubKey, 11))
+	records, err = store.ListSignedProposals(pubKey)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.EqualValues(t, 20, records[0].Slot)
+
+	require.EqualError(t, store.SaveSignedProposal(pubKey, nil), "proposal could not be nil")
+}
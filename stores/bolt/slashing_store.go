@@ -0,0 +1,216 @@
+package bolt
+
+import (
+	"encoding/binary"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	bbolt "go.etcd.io/bbolt"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// SaveHighestAttestation saves the given highest attestation
+func (store *BoltStore) SaveHighestAttestation(pubKey []byte, attestation *phase0.AttestationData) error {
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		return (&slashingTx{tx: tx}).SaveHighestAttestation(pubKey, attestation)
+	})
+}
+
+// RetrieveHighestAttestation retrieves highest attestation
+func (store *BoltStore) RetrieveHighestAttestation(pubKey []byte) (*phase0.AttestationData, bool, error) {
+	var ret *phase0.AttestationData
+	var found bool
+	err := store.db.View(func(tx *bbolt.Tx) (err error) {
+		ret, found, err = (&slashingTx{tx: tx}).RetrieveHighestAttestation(pubKey)
+		return err
+	})
+	return ret, found, err
+}
+
+// SaveHighestProposal saves the given highest attestation
+func (store *BoltStore) SaveHighestProposal(pubKey []byte, slot phase0.Slot) error {
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		return (&slashingTx{tx: tx}).SaveHighestProposal(pubKey, slot)
+	})
+}
+
+// RetrieveHighestProposal returns highest proposal
+func (store *BoltStore) RetrieveHighestProposal(pubKey []byte) (phase0.Slot, bool, error) {
+	var slot phase0.Slot
+	var found bool
+	err := store.db.View(func(tx *bbolt.Tx) (err error) {
+		slot, found, err = (&slashingTx{tx: tx}).RetrieveHighestProposal(pubKey)
+		return err
+	})
+	return slot, found, err
+}
+
+// SaveHighestAttestationSigningRoot saves the signing root of the highest attestation
+func (store *BoltStore) SaveHighestAttestationSigningRoot(pubKey []byte, targetEpoch phase0.Epoch, signingRoot phase0.Root) error {
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		return (&slashingTx{tx: tx}).SaveHighestAttestationSigningRoot(pubKey, targetEpoch, signingRoot)
+	})
+}
+
+// RetrieveHighestAttestationSigningRoot returns the signing root of the highest attestation and its target epoch
+func (store *BoltStore) RetrieveHighestAttestationSigningRoot(pubKey []byte) (phase0.Epoch, phase0.Root, bool, error) {
+	var epoch phase0.Epoch
+	var root phase0.Root
+	var found bool
+	err := store.db.View(func(tx *bbolt.Tx) (err error) {
+		epoch, root, found, err = (&slashingTx{tx: tx}).RetrieveHighestAttestationSigningRoot(pubKey)
+		return err
+	})
+	return epoch, root, found, err
+}
+
+// SaveHighestProposalSigningRoot saves the signing root of the highest proposal
+func (store *BoltStore) SaveHighestProposalSigningRoot(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) error {
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		return (&slashingTx{tx: tx}).SaveHighestProposalSigningRoot(pubKey, slot, signingRoot)
+	})
+}
+
+// RetrieveHighestProposalSigningRoot returns the signing root of the highest proposal and its slot
+func (store *BoltStore) RetrieveHighestProposalSigningRoot(pubKey []byte) (phase0.Slot, phase0.Root, bool, error) {
+	var slot phase0.Slot
+	var root phase0.Root
+	var found bool
+	err := store.db.View(func(tx *bbolt.Tx) (err error) {
+		slot, root, found, err = (&slashingTx{tx: tx}).RetrieveHighestProposalSigningRoot(pubKey)
+		return err
+	})
+	return slot, root, found, err
+}
+
+// SlashingTx runs fn with a slashing store bound to a single read-write transaction.
+// The transaction is rolled back if fn returns an error.
+func (store *BoltStore) SlashingTx(fn func(store core.SlashingStore) error) error {
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		return fn(&slashingTx{tx: tx})
+	})
+}
+
+// slashingTx implements core.SlashingStore within a bbolt transaction
+type slashingTx struct {
+	tx *bbolt.Tx
+}
+
+// SaveHighestAttestation saves the given highest attestation
+func (s *slashingTx) SaveHighestAttestation(pubKey []byte, attestation *phase0.AttestationData) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+
+	if attestation == nil {
+		return errors.New("attestation data could not be nil")
+	}
+
+	byts, err := attestation.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal attestation data")
+	}
+
+	return s.tx.Bucket(highestAttestationBucket).Put(pubKey, byts)
+}
+
+// RetrieveHighestAttestation retrieves highest attestation
+func (s *slashingTx) RetrieveHighestAttestation(pubKey []byte) (*phase0.AttestationData, bool, error) {
+	if pubKey == nil {
+		return nil, false, errors.New("public key could not be nil")
+	}
+
+	val := s.tx.Bucket(highestAttestationBucket).Get(pubKey)
+	if val == nil {
+		return nil, false, nil
+	}
+	ret := &phase0.AttestationData{}
+	if err := ret.UnmarshalSSZ(val); err != nil {
+		return nil, false, errors.Wrap(err, "failed to unmarshal attestation data")
+	}
+	return ret, true, nil
+}
+
+// SaveHighestProposal saves the given highest attestation
+func (s *slashingTx) SaveHighestProposal(pubKey []byte, slot phase0.Slot) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+	if slot == 0 {
+		return errors.New("invalid proposal slot, slot could not be 0")
+	}
+
+	byts := make([]byte, 8)
+	binary.LittleEndian.PutUint64(byts, uint64(slot))
+
+	return s.tx.Bucket(highestProposalBucket).Put(pubKey, byts)
+}
+
+// RetrieveHighestProposal returns highest proposal
+func (s *slashingTx) RetrieveHighestProposal(pubKey []byte) (phase0.Slot, bool, error) {
+	if pubKey == nil {
+		return 0, false, errors.New("public key could not be nil")
+	}
+
+	val := s.tx.Bucket(highestProposalBucket).Get(pubKey)
+	if val == nil {
+		return 0, false, nil
+	}
+	if len(val) != 8 {
+		return 0, false, errors.Errorf("invalid highest proposal length %d", len(val))
+	}
+	return phase0.Slot(binary.LittleEndian.Uint64(val)), true, nil
+}
+
+// SaveHighestAttestationSigningRoot saves the signing root of the highest attestation
+func (s *slashingTx) SaveHighestAttestationSigningRoot(pubKey []byte, targetEpoch phase0.Epoch, signingRoot phase0.Root) error {
+	return s.saveSigningRoot(highestAttRootBucket, pubKey, uint64(targetEpoch), signingRoot)
+}
+
+// RetrieveHighestAttestationSigningRoot returns the signing root of the highest attestation and its target epoch
+func (s *slashingTx) RetrieveHighestAttestationSigningRoot(pubKey []byte) (phase0.Epoch, phase0.Root, bool, error) {
+	epoch, root, found, err := s.retrieveSigningRoot(highestAttRootBucket, pubKey)
+	return phase0.Epoch(epoch), root, found, err
+}
+
+// SaveHighestProposalSigningRoot saves the signing root of the highest proposal
+func (s *slashingTx) SaveHighestProposalSigningRoot(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) error {
+	return s.saveSigningRoot(highestProposalRootBucket, pubKey, uint64(slot), signingRoot)
+}
+
+// RetrieveHighestProposalSigningRoot returns the signing root of the highest proposal and its slot
+func (s *slashingTx) RetrieveHighestProposalSigningRoot(pubKey []byte) (phase0.Slot, phase0.Root, bool, error) {
+	slot, root, found, err := s.retrieveSigningRoot(highestProposalRootBucket, pubKey)
+	return phase0.Slot(slot), root, found, err
+}
+
+// saveSigningRoot stores the little endian epoch or slot followed by the signing root
+func (s *slashingTx) saveSigningRoot(bucket []byte, pubKey []byte, index uint64, signingRoot phase0.Root) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+
+	byts := make([]byte, 8+len(signingRoot))
+	binary.LittleEndian.PutUint64(byts, index)
+	copy(byts[8:], signingRoot[:])
+
+	return s.tx.Bucket(bucket).Put(pubKey, byts)
+}
+
+func (s *slashingTx) retrieveSigningRoot(bucket []byte, pubKey []byte) (uint64, phase0.Root, bool, error) {
+	if pubKey == nil {
+		return 0, phase0.Root{}, false, errors.New("public key could not be nil")
+	}
+
+	var root phase0.Root
+	val := s.tx.Bucket(bucket).Get(pubKey)
+	if val == nil {
+		return 0, root, false, nil
+	}
+	if len(val) != 8+len(root) {
+		return 0, root, false, errors.Errorf("invalid signing root record length %d", len(val))
+	}
+	copy(root[:], val[8:])
+	return binary.LittleEndian.Uint64(val), root, true, nil
+}
@@ -0,0 +1,345 @@
+package bolt
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	bbolt "go.etcd.io/bbolt"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	encryptor2 "github.com/ssvlabs/eth2-key-manager/encryptor"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
+	"github.com/ssvlabs/eth2-key-manager/wallets/hd"
+	"github.com/ssvlabs/eth2-key-manager/wallets/nd"
+)
+
+// Bucket names
+var (
+	metaBucket                = []byte("meta")
+	walletBucket              = []byte("wallet")
+	accountsBucket            = []byte("accounts")
+	highestAttestationBucket  = []byte("highest_attestation")
+	highestProposalBucket     = []byte("highest_proposal")
+	highestAttRootBucket      = []byte("highest_attestation_root")
+	highestProposalRootBucket = []byte("highest_proposal_root")
+	signedAttestationsBucket  = []byte("signed_attestations")
+	signedProposalsBucket     = []byte("signed_proposals")
+)
+
+// Keys of the meta and wallet buckets
+var (
+	networkKey    = []byte("network")
+	walletKey     = []byte("wallet")
+	walletTypeKey = []byte("type")
+)
+
+// BoltStore implements core.Storage and core.SlashingStore using a bbolt file.
+// Every write is done in its own transaction, bbolt guarantees it is either fully persisted or not at all.
+type BoltStore struct {
+	network core.Network
+	db      *bbolt.DB
+
+	encryptorLock      sync.RWMutex
+	encryptor          encryptor2.Encryptor
+	encryptionPassword []byte
+	keyCache           *core.KeyCache
+}
+
+// NewBoltStore is the constructor of BoltStore.
+// Opens (or creates) the database at the given path.
+func NewBoltStore(path string, network core.Network) (*BoltStore, error) {
+	return NewBoltStoreWithEncryptor(path, network, nil, nil)
+}
+
+// NewBoltStoreWithEncryptor is the constructor of BoltStore.
+// Opens (or creates) the database at the given path, account secrets are encrypted with the given encryptor.
+func NewBoltStoreWithEncryptor(path string, network core.Network, encryptor encryptor2.Encryptor, password []byte) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open bolt db")
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{metaBucket, walletBucket, accountsBucket, highestAttestationBucket, highestProposalBucket, highestAttRootBucket, highestProposalRootBucket, signedAttestationsBucket, signedProposalsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return errors.Wrapf(err, "failed to create bucket %s", bucket)
+			}
+		}
+
+		meta := tx.Bucket(metaBucket)
+		if stored := meta.Get(networkKey); stored != nil {
+			if core.Network(stored) != network {
+				return errors.Errorf("db belongs to network %s, not %s", stored, network)
+			}
+			return nil
+		}
+		return meta.Put(networkKey, []byte(network))
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{
+		network:            network,
+		db:                 db,
+		encryptor:          encryptor,
+		encryptionPassword: password,
+		keyCache:           core.NewKeyCache(),
+	}, nil
+}
+
+// Close closes the underlying database
+func (store *BoltStore) Close() error {
+	return store.db.Close()
+}
+
+// KeyCache returns the cache holding the decrypted keys of the opened accounts
+func (store *BoltStore) KeyCache() *core.KeyCache {
+	return store.keyCache
+}
+
+// Name provides the name of the store.
+func (store *BoltStore) Name() string {
+	return "bolt"
+}
+
+// Network returns the network.
+func (store *BoltStore) Network() core.Network {
+	return store.network
+}
+
+// SaveWallet implements core.Storage interface.
+func (store *BoltStore) SaveWallet(wallet core.Wallet) error {
+	byts, err := json.Marshal(wallet)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal wallet")
+	}
+
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(walletBucket)
+		if err := bucket.Put(walletTypeKey, []byte(wallet.Type())); err != nil {
+			return err
+		}
+		return bucket.Put(walletKey, byts)
+	})
+}
+
+// OpenWallet returns nil,err if no wallet was found
+func (store *BoltStore) OpenWallet() (core.Wallet, error) {
+	var walletType string
+	var byts []byte
+	err := store.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(walletBucket)
+		walletType = string(bucket.Get(walletTypeKey))
+		if val := bucket.Get(walletKey); val != nil {
+			byts = make([]byte, len(val))
+			copy(byts, val)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if byts == nil {
+		return nil, errors.New("wallet not found")
+	}
+
+	var ret core.Wallet
+	switch walletType {
+	case core.HDWallet:
+		ret = &hd.Wallet{}
+	case core.NDWallet:
+		ret = &nd.Wallet{}
+	default:
+		return nil, errors.Errorf("unknown wallet type %s", walletType)
+	}
+	if err := json.Unmarshal(byts, ret); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal wallet")
+	}
+
+	ret.SetContext(store.freshContext())
+	return ret, nil
+}
+
+// ListAccounts returns an empty array for no accounts
+func (store *BoltStore) ListAccounts() ([]core.ValidatorAccount, error) {
+	w, err := store.OpenWallet()
+	if err != nil {
+		return nil, err
+	}
+
+	return w.Accounts(), nil
+}
+
+// SaveAccount saves the given account, its key is encrypted with the store encryptor if one was set
+func (store *BoltStore) SaveAccount(account core.ValidatorAccount) error {
+	store.encryptorLock.RLock()
+	defer store.encryptorLock.RUnlock()
+
+	recordByts, err := marshalAccountRecord(account, store.encryptor, store.encryptionPassword)
+	if err != nil {
+		return err
+	}
+
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(accountsBucket).Put([]byte(account.ID().String()), recordByts)
+	})
+}
+
+// DeleteAccount deletes account by its ID
+func (store *BoltStore) DeleteAccount(accountID uuid.UUID) error {
+	return store.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(accountsBucket)
+		key := []byte(accountID.String())
+		if bucket.Get(key) == nil {
+			return errors.New("account not found")
+		}
+		return bucket.Delete(key)
+	})
+}
+
+// OpenAccount returns nil,nil if no account was found.
+// An encrypted account key is decrypted when it's first used, with the encryptor and password set when opening it.
+func (store *BoltStore) OpenAccount(accountID uuid.UUID) (core.ValidatorAccount, error) {
+	store.encryptorLock.RLock()
+	encryptor, password := store.encryptor, store.encryptionPassword
+	var recordByts []byte
+	err := store.db.View(func(tx *bbolt.Tx) error {
+		if val := tx.Bucket(accountsBucket).Get([]byte(accountID.String())); val != nil {
+			recordByts = make([]byte, len(val))
+			copy(recordByts, val)
+		}
+		return nil
+	})
+	store.encryptorLock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if recordByts == nil {
+		return nil, nil
+	}
+
+	return unmarshalAccountRecord(recordByts, encryptor, password, store.keyCache)
+}
+
+// RotatePassword re-encrypts the key of every account with the given password, which is then used for new accounts.
+// Keys are re-encrypted before taking the encryptor lock, accounts opened before the rotation keep signing.
+func (store *BoltStore) RotatePassword(password []byte) error {
+	encryptor, oldPassword := store.currentEncryptor()
+	if encryptor == nil {
+		return errors.New("no encryptor was set")
+	}
+	if store.keyCache.Locked() {
+		return core.ErrKeyVaultLocked
+	}
+
+	records := make(map[string][]byte)
+	err := store.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(accountsBucket).ForEach(func(k, v []byte) error {
+			records[string(k)] = append([]byte(nil), v...)
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	rotated := make(map[string][]byte, len(records))
+	for id, record := range records {
+		if rotated[id], err = rotateAccountRecord(record, encryptor, oldPassword, password); err != nil {
+			return errors.Wrapf(err, "failed to rotate account %s", id)
+		}
+	}
+
+	store.encryptorLock.Lock()
+	defer store.encryptorLock.Unlock()
+	if !bytes.Equal(store.encryptionPassword, oldPassword) {
+		return errors.New("password was changed during the rotation")
+	}
+
+	err = store.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(accountsBucket)
+		current := make(map[string][]byte)
+		if err := bucket.ForEach(func(k, v []byte) error {
+			current[string(k)] = append([]byte(nil), v...)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for id, record := range current {
+			// accounts saved during the rotation are re-encrypted now
+			if !bytes.Equal(records[id], record) {
+				var err error
+				if rotated[id], err = rotateAccountRecord(record, encryptor, oldPassword, password); err != nil {
+					return errors.Wrapf(err, "failed to rotate account %s", id)
+				}
+			}
+			if err := bucket.Put([]byte(id), rotated[id]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	store.encryptionPassword = password
+	return nil
+}
+
+func rotateAccountRecord(record []byte, encryptor encryptor2.Encryptor, oldPassword []byte, password []byte) ([]byte, error) {
+	// the key is only decrypted to be encrypted again, it's zeroized right after
+	cache := core.NewKeyCache()
+	cache.SetTTL(core.NoKeyCaching)
+	account, err := unmarshalAccountRecord(record, encryptor, oldPassword, cache)
+	if err != nil {
+		return nil, err
+	}
+	return marshalAccountRecord(account, encryptor, password)
+}
+
+// marshalAccountRecord marshals the persisted form of an account, its key is encrypted if an encryptor was configured
+func marshalAccountRecord(account core.ValidatorAccount, encryptor encryptor2.Encryptor, password []byte) ([]byte, error) {
+	recordByts, err := wallets.MarshalAccount(account, encryptor, password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal account")
+	}
+	return recordByts, nil
+}
+
+func unmarshalAccountRecord(recordByts []byte, encryptor encryptor2.Encryptor, password []byte, cache *core.KeyCache) (core.ValidatorAccount, error) {
+	ret, err := wallets.UnmarshalAccount(recordByts, core.NewKeyDecryptor(encryptor, password), cache)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal account")
+	}
+	if err := wallets.SetAccountEncryptor(ret, encryptor, password); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// SetEncryptor is the encryptor setter
+func (store *BoltStore) SetEncryptor(encryptor encryptor2.Encryptor, password []byte) {
+	store.encryptorLock.Lock()
+	defer store.encryptorLock.Unlock()
+	store.encryptor = encryptor
+	store.encryptionPassword = password
+}
+
+func (store *BoltStore) currentEncryptor() (encryptor2.Encryptor, []byte) {
+	store.encryptorLock.RLock()
+	defer store.encryptorLock.RUnlock()
+	return store.encryptor, store.encryptionPassword
+}
+
+func (store *BoltStore) freshContext() *core.WalletContext {
+	return &core.WalletContext{
+		Storage: store,
+	}
+}
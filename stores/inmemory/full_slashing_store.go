@@ -0,0 +1,131 @@
+package inmemory
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// SaveSignedAttestation saves the given attestation record
+func (store *InMemStore) SaveSignedAttestation(pubKey []byte, attestation *core.SignedAttestation) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+	if attestation == nil {
+		return errors.New("attestation could not be nil")
+	}
+
+	store.signedAttestationsLock.Lock()
+	defer store.signedAttestationsLock.Unlock()
+
+	key := hex.EncodeToString(pubKey)
+	if store.signedAttestations[key] == nil {
+		store.signedAttestations[key] = make(map[phase0.Epoch]*core.SignedAttestation)
+	}
+	record := *attestation
+	store.signedAttestations[key][attestation.TargetEpoch] = &record
+	return nil
+}
+
+// ListSignedAttestations returns the attestation records sorted by target epoch
+func (store *InMemStore) ListSignedAttestations(pubKey []byte) ([]*core.SignedAttestation, error) {
+	if pubKey == nil {
+		return nil, errors.New("public key could not be nil")
+	}
+
+	store.signedAttestationsLock.RLock()
+	defer store.signedAttestationsLock.RUnlock()
+
+	records := store.signedAttestations[hex.EncodeToString(pubKey)]
+	ret := make([]*core.SignedAttestation, 0, len(records))
+	for _, record := range records {
+		val := *record
+		ret = append(ret, &val)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].TargetEpoch < ret[j].TargetEpoch
+	})
+	return ret, nil
+}
+
+// PruneSignedAttestations deletes the attestation records with a target epoch lower than the given one
+func (store *InMemStore) PruneSignedAttestations(pubKey []byte, targetEpoch phase0.Epoch) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+
+	store.signedAttestationsLock.Lock()
+	defer store.signedAttestationsLock.Unlock()
+
+	records := store.signedAttestations[hex.EncodeToString(pubKey)]
+	for epoch := range records {
+		if epoch < targetEpoch {
+			delete(records, epoch)
+		}
+	}
+	return nil
+}
+
+// SaveSignedProposal saves the given proposal record
+func (store *InMemStore) SaveSignedProposal(pubKey []byte, proposal *core.SignedProposal) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+	if proposal == nil {
+		return errors.New("proposal could not be nil")
+	}
+
+	store.signedProposalsLock.Lock()
+	defer store.signedProposalsLock.Unlock()
+
+	key := hex.EncodeToString(pubKey)
+	if store.signedProposals[key] == nil {
+		store.signedProposals[key] = make(map[phase0.Slot]*core.SignedProposal)
+	}
+	record := *proposal
+	store.signedProposals[key][proposal.Slot] = &record
+	return nil
+}
+
+// ListSignedProposals returns the proposal records sorted by slot
+func (store *InMemStore) ListSignedProposals(pubKey []byte) ([]*core.SignedProposal, error) {
+	if pubKey == nil {
+		return nil, errors.New("public key could not be nil")
+	}
+
+	store.signedProposalsLock.RLock()
+	defer store.signedProposalsLock.RUnlock()
+
+	records := store.signedProposals[hex.EncodeToString(pubKey)]
+	ret := make([]*core.SignedProposal, 0, len(records))
+	for _, record := range records {
+		val := *record
+		ret = append(ret, &val)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Slot < ret[j].Slot
+	})
+	return ret, nil
+}
+
+// PruneSignedProposals deletes the proposal records with a slot lower than the given one
+func (store *InMemStore) PruneSignedProposals(pubKey []byte, slot phase0.Slot) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+
+	store.signedProposalsLock.Lock()
+	defer store.signedProposalsLock.Unlock()
+
+	records := store.signedProposals[hex.EncodeToString(pubKey)]
+	for s := range records {
+		if s < slot {
+			delete(records, s)
+		}
+	}
+	return nil
+}
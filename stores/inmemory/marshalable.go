@@ -4,9 +4,11 @@ import (
 	"encoding/hex"
 	"encoding/json"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 
 	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
 	hd2 "github.com/ssvlabs/eth2-key-manager/wallets/hd"
 	"github.com/ssvlabs/eth2-key-manager/wallets/nd"
 )
@@ -26,7 +28,18 @@ func (store *InMemStore) MarshalJSON() ([]byte, error) {
 
 	data["walletType"] = store.wallet.Type()
 
-	data["accounts"], err = json.Marshal(store.accounts)
+	// account keys are never part of the account JSON, they are added encrypted (or in plain text without an encryptor)
+	encryptor, password := store.currentEncryptor()
+	accounts := make(map[string]json.RawMessage)
+	store.accountsLock.Lock()
+	for id, account := range store.accounts {
+		if accounts[id], err = wallets.MarshalAccount(account, encryptor, password); err != nil {
+			store.accountsLock.Unlock()
+			return nil, err
+		}
+	}
+	store.accountsLock.Unlock()
+	data["accounts"], err = json.Marshal(accounts)
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +57,30 @@ func (store *InMemStore) MarshalJSON() ([]byte, error) {
 	}
 	data["highestProposal"] = hex.EncodeToString(data["highestProposal"].([]byte))
 
+	data["highestAttRoot"], err = json.Marshal(store.highestAttestationRoot)
+	if err != nil {
+		return nil, err
+	}
+	data["highestAttRoot"] = hex.EncodeToString(data["highestAttRoot"].([]byte))
+
+	data["highestProposalRoot"], err = json.Marshal(store.highestProposalRoot)
+	if err != nil {
+		return nil, err
+	}
+	data["highestProposalRoot"] = hex.EncodeToString(data["highestProposalRoot"].([]byte))
+
+	data["signedAtt"], err = json.Marshal(store.signedAttestations)
+	if err != nil {
+		return nil, err
+	}
+	data["signedAtt"] = hex.EncodeToString(data["signedAtt"].([]byte))
+
+	data["signedProposals"], err = json.Marshal(store.signedProposals)
+	if err != nil {
+		return nil, err
+	}
+	data["signedProposals"] = hex.EncodeToString(data["signedProposals"].([]byte))
+
 	return json.Marshal(data)
 }
 
@@ -104,15 +141,24 @@ func (store *InMemStore) UnmarshalJSON(data []byte) error {
 	}
 
 	// accounts
+	if store.keyCache == nil {
+		store.keyCache = core.NewKeyCache()
+	}
 	if val, exists := v["accounts"]; exists {
 		byts, err := hex.DecodeString(val.(string))
 		if err != nil {
 			return err
 		}
-		err = json.Unmarshal(byts, &store.accounts)
-		if err != nil {
+		accounts := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(byts, &accounts); err != nil {
 			return err
 		}
+		store.accounts = make(map[string]core.ValidatorAccount)
+		for id, data := range accounts {
+			if store.accounts[id], err = wallets.UnmarshalAccount(data, store.decryptKey, store.keyCache); err != nil {
+				return err
+			}
+		}
 	} else {
 		return errors.New("could not find var: accounts")
 	}
@@ -145,5 +191,54 @@ func (store *InMemStore) UnmarshalJSON(data []byte) error {
 		return errors.New("could not find var: highestProposal")
 	}
 
+	// signing roots, signed attestations and proposals are optional, older storages don't have them
+	store.highestAttestationRoot = make(map[string]*signingRootRecord)
+	if val, exists := v["highestAttRoot"]; exists {
+		byts, err := hex.DecodeString(val.(string))
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(byts, &store.highestAttestationRoot)
+		if err != nil {
+			return err
+		}
+	}
+
+	store.highestProposalRoot = make(map[string]*signingRootRecord)
+	if val, exists := v["highestProposalRoot"]; exists {
+		byts, err := hex.DecodeString(val.(string))
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(byts, &store.highestProposalRoot)
+		if err != nil {
+			return err
+		}
+	}
+
+	store.signedAttestations = make(map[string]map[phase0.Epoch]*core.SignedAttestation)
+	if val, exists := v["signedAtt"]; exists {
+		byts, err := hex.DecodeString(val.(string))
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(byts, &store.signedAttestations)
+		if err != nil {
+			return err
+		}
+	}
+
+	store.signedProposals = make(map[string]map[phase0.Slot]*core.SignedProposal)
+	if val, exists := v["signedProposals"]; exists {
+		byts, err := hex.DecodeString(val.(string))
+		if err != nil {
+			return err
+		}
+		err = json.Unmarshal(byts, &store.signedProposals)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
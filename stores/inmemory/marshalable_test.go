@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
 	"github.com/ssvlabs/eth2-key-manager/wallets"
 	"github.com/ssvlabs/eth2-key-manager/wallets/hd"
 	"github.com/ssvlabs/eth2-key-manager/wallets/nd"
@@ -136,3 +137,88 @@ func TestMarshaling(t *testing.T) {
 		require.Equal(t, phase0.Slot(1), prop2)
 	})
 }
+
+func TestMarshalingEncryptedAccounts(t *testing.T) {
+	require.NoError(t, core.InitBLS())
+	enc := keystorev4.New(keystorev4.WithCipher("pbkdf2"))
+	store := NewInMemStoreWithEncryptor(core.MainNetwork, enc, []byte("password"))
+
+	wallet := hd.NewWallet(&core.WalletContext{Storage: store})
+	require.NoError(t, store.SaveWallet(wallet))
+	acc, err := wallet.CreateValidatorAccount(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff"), nil)
+	require.NoError(t, err)
+	expected, err := acc.ValidationKeySign([]byte("data"))
+	require.NoError(t, err)
+
+	byts, err := json.Marshal(store)
+	require.NoError(t, err)
+	accounts := accountsJSON(t, byts)
+	require.NotContains(t, accounts, "privKey")
+	require.Contains(t, accounts, "crypto")
+
+	t.Run("decrypted when first used", func(t *testing.T) {
+		var store2 InMemStore
+		require.NoError(t, json.Unmarshal(byts, &store2))
+		acc2, err := store2.OpenAccount(acc.ID())
+		require.NoError(t, err)
+		require.Equal(t, acc.ValidatorPublicKey(), acc2.ValidatorPublicKey())
+
+		_, err = acc2.ValidationKeySign([]byte("data"))
+		require.EqualError(t, err, "failed to decrypt key: account is encrypted but no encryptor was set")
+
+		store2.SetEncryptor(enc, []byte("password"))
+		sig, err := acc2.ValidationKeySign([]byte("data"))
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+	})
+
+	t.Run("rotate password", func(t *testing.T) {
+		var store2 InMemStore
+		require.NoError(t, json.Unmarshal(byts, &store2))
+		store2.SetEncryptor(enc, []byte("password"))
+		require.NoError(t, store2.RotatePassword([]byte("new password")))
+
+		acc2, err := store2.OpenAccount(acc.ID())
+		require.NoError(t, err)
+		sig, err := acc2.ValidationKeySign([]byte("data"))
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+
+		rotated, err := json.Marshal(&store2)
+		require.NoError(t, err)
+		var store3 InMemStore
+		require.NoError(t, json.Unmarshal(rotated, &store3))
+		store3.SetEncryptor(enc, []byte("password"))
+		acc3, err := store3.OpenAccount(acc.ID())
+		require.NoError(t, err)
+		_, err = acc3.ValidationKeySign([]byte("data"))
+		require.EqualError(t, err, "failed to decrypt key: invalid checksum")
+
+		store3.SetEncryptor(enc, []byte("new password"))
+		_, err = acc3.ValidationKeySign([]byte("data"))
+		require.NoError(t, err)
+	})
+
+	t.Run("marshaled while locked", func(t *testing.T) {
+		store.KeyCache().Lock()
+		defer store.KeyCache().Unlock()
+
+		locked, err := json.Marshal(store)
+		require.NoError(t, err)
+		require.Equal(t, accounts, accountsJSON(t, locked))
+	})
+
+	t.Run("no encryptor", func(t *testing.T) {
+		store := NewInMemStore(core.MainNetwork)
+		require.EqualError(t, store.RotatePassword([]byte("password")), "no encryptor was set")
+	})
+}
+
+// accountsJSON returns the decoded accounts of a marshaled store
+func accountsJSON(t *testing.T, byts []byte) string {
+	var v map[string]string
+	require.NoError(t, json.Unmarshal(byts, &v))
+	accounts, err := hex.DecodeString(v["accounts"])
+	require.NoError(t, err)
+	return string(accounts)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
 )
 
 // SaveHighestAttestation saves the given highest attestation
@@ -61,3 +63,71 @@ func (store *InMemStore) RetrieveHighestProposal(pubKey []byte) (phase0.Slot, bo
 	store.highestProposalLock.RUnlock()
 	return phase0.Slot(val), found, nil
 }
+
+// signingRootRecord is a signing root with the target epoch or slot it was signed for
+type signingRootRecord struct {
+	Index uint64      `json:"index"`
+	Root  phase0.Root `json:"root"`
+}
+
+// SaveHighestAttestationSigningRoot saves the signing root of the highest attestation
+func (store *InMemStore) SaveHighestAttestationSigningRoot(pubKey []byte, targetEpoch phase0.Epoch, signingRoot phase0.Root) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+
+	store.highestAttestationLock.Lock()
+	store.highestAttestationRoot[hex.EncodeToString(pubKey)] = &signingRootRecord{Index: uint64(targetEpoch), Root: signingRoot}
+	store.highestAttestationLock.Unlock()
+	return nil
+}
+
+// RetrieveHighestAttestationSigningRoot returns the signing root of the highest attestation and its target epoch
+func (store *InMemStore) RetrieveHighestAttestationSigningRoot(pubKey []byte) (phase0.Epoch, phase0.Root, bool, error) {
+	if pubKey == nil {
+		return 0, phase0.Root{}, false, errors.New("public key could not be nil")
+	}
+
+	store.highestAttestationLock.RLock()
+	val, found := store.highestAttestationRoot[hex.EncodeToString(pubKey)]
+	store.highestAttestationLock.RUnlock()
+	if !found {
+		return 0, phase0.Root{}, false, nil
+	}
+	return phase0.Epoch(val.Index), val.Root, true, nil
+}
+
+// SaveHighestProposalSigningRoot saves the signing root of the highest proposal
+func (store *InMemStore) SaveHighestProposalSigningRoot(pubKey []byte, slot phase0.Slot, signingRoot phase0.Root) error {
+	if pubKey == nil {
+		return errors.New("public key could not be nil")
+	}
+
+	store.highestProposalLock.Lock()
+	store.highestProposalRoot[hex.EncodeToString(pubKey)] = &signingRootRecord{Index: uint64(slot), Root: signingRoot}
+	store.highestProposalLock.Unlock()
+	return nil
+}
+
+// RetrieveHighestProposalSigningRoot returns the signing root of the highest proposal and its slot
+func (store *InMemStore) RetrieveHighestProposalSigningRoot(pubKey []byte) (phase0.Slot, phase0.Root, bool, error) {
+	if pubKey == nil {
+		return 0, phase0.Root{}, false, errors.New("public key could not be nil")
+	}
+
+	store.highestProposalLock.RLock()
+	val, found := store.highestProposalRoot[hex.EncodeToString(pubKey)]
+	store.highestProposalLock.RUnlock()
+	if !found {
+		return 0, phase0.Root{}, false, nil
+	}
+	return phase0.Slot(val.Index), val.Root, true, nil
+}
+
+// SlashingTx runs fn with the store, serialized against other slashing transactions.
+// Nothing is persisted by the in-memory store so there is nothing to roll back.
+func (store *InMemStore) SlashingTx(fn func(store core.SlashingStore) error) error {
+	store.slashingTxLock.Lock()
+	defer store.slashingTxLock.Unlock()
+	return fn(store)
+}
@@ -32,10 +32,12 @@ func (a *mockAccount) ValidatorPublicKey() []byte {
 	}
 	return sk.GetPublicKey().Serialize()
 }
-func (a *mockAccount) WithdrawalPublicKey() []byte                     { return nil }
-func (a *mockAccount) ValidationKeySign(data []byte) ([]byte, error)   { return nil, nil }
-func (a *mockAccount) GetDepositData() (map[string]interface{}, error) { return nil, nil }
-func (a *mockAccount) SetContext(ctx *core.WalletContext)              {}
+func (a *mockAccount) WithdrawalPublicKey() []byte                   { return nil }
+func (a *mockAccount) ValidationKeySign(data []byte) ([]byte, error) { return nil, nil }
+func (a *mockAccount) GetDepositData(...core.DepositDataOption) (map[string]interface{}, error) {
+	return nil, nil
+}
+func (a *mockAccount) SetContext(ctx *core.WalletContext) {}
 
 func getSlashingStorage() core.SlashingStore {
 	return NewInMemStore(core.MainNetwork)
@@ -216,3 +218,37 @@ func TestSavingHighestAttestation(t *testing.T) {
 		})
 	}
 }
+
+func TestSavingHighestSigningRoots(t *testing.T) {
+	storage := getSlashingStorage()
+	account := &mockAccount{
+		id:            uuid.New(),
+		validationKey: _bigInt("5467048590701165350380985526996487573957450279098876378395441669247373404218"),
+	}
+	pubKey := account.ValidatorPublicKey()
+	root := phase0.Root{1, 2, 3}
+
+	_, _, found, err := storage.RetrieveHighestAttestationSigningRoot(pubKey)
+	require.NoError(t, err)
+	require.False(t, found)
+	_, _, found, err = storage.RetrieveHighestProposalSigningRoot(pubKey)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, storage.SaveHighestAttestationSigningRoot(pubKey, 10, root))
+	epoch, retrieved, found, err := storage.RetrieveHighestAttestationSigningRoot(pubKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 10, epoch)
+	require.Equal(t, root, retrieved)
+
+	require.NoError(t, storage.SaveHighestProposalSigningRoot(pubKey, 100, root))
+	slot, retrieved, found, err := storage.RetrieveHighestProposalSigningRoot(pubKey)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.EqualValues(t, 100, slot)
+	require.Equal(t, root, retrieved)
+
+	require.EqualError(t, storage.SaveHighestAttestationSigningRoot(nil, 10, root), "public key could not be nil")
+	require.EqualError(t, storage.SaveHighestProposalSigningRoot(nil, 100, root), "public key could not be nil")
+}
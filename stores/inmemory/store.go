@@ -1,6 +1,7 @@
 package inmemory
 
 import (
+	"bytes"
 	"sync"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -20,16 +21,28 @@ type InMemStore struct {
 	wallet     core.Wallet
 
 	accountsLock sync.Mutex
-	accounts     map[string]*wallets.HDAccount
+	accounts     map[string]core.ValidatorAccount
 
 	highestAttestationLock sync.RWMutex
 	highestAttestation     map[string]*phase0.AttestationData
+	highestAttestationRoot map[string]*signingRootRecord
 
 	highestProposalLock sync.RWMutex
 	highestProposal     map[string]uint64
+	highestProposalRoot map[string]*signingRootRecord
 
+	slashingTxLock sync.Mutex
+
+	signedAttestationsLock sync.RWMutex
+	signedAttestations     map[string]map[phase0.Epoch]*core.SignedAttestation
+
+	signedProposalsLock sync.RWMutex
+	signedProposals     map[string]map[phase0.Slot]*core.SignedProposal
+
+	encryptorLock      sync.RWMutex
 	encryptor          encryptor2.Encryptor
 	encryptionPassword []byte
+	keyCache           *core.KeyCache
 }
 
 // NewInMemStore is the constructor of InMemStore.
@@ -40,12 +53,17 @@ func NewInMemStore(network core.Network) *InMemStore {
 // NewInMemStoreWithEncryptor is the constructor of InMemStore.
 func NewInMemStoreWithEncryptor(network core.Network, encryptor encryptor2.Encryptor, password []byte) *InMemStore {
 	return &InMemStore{
-		network:            network,
-		accounts:           make(map[string]*wallets.HDAccount),
-		highestAttestation: make(map[string]*phase0.AttestationData),
-		highestProposal:    make(map[string]uint64),
-		encryptor:          encryptor,
-		encryptionPassword: password,
+		network:                network,
+		accounts:               make(map[string]core.ValidatorAccount),
+		highestAttestation:     make(map[string]*phase0.AttestationData),
+		highestAttestationRoot: make(map[string]*signingRootRecord),
+		highestProposal:        make(map[string]uint64),
+		highestProposalRoot:    make(map[string]*signingRootRecord),
+		signedAttestations:     make(map[string]map[phase0.Epoch]*core.SignedAttestation),
+		signedProposals:        make(map[string]map[phase0.Slot]*core.SignedProposal),
+		encryptor:              encryptor,
+		encryptionPassword:     password,
+		keyCache:               core.NewKeyCache(),
 	}
 }
 
@@ -86,10 +104,18 @@ func (store *InMemStore) ListAccounts() ([]core.ValidatorAccount, error) {
 	return w.Accounts(), nil
 }
 
-// SaveAccount saves the given account
+// SaveAccount saves the given account.
+// With an encryptor set, the store keeps a copy of the account holding only its encrypted key.
 func (store *InMemStore) SaveAccount(account core.ValidatorAccount) error {
+	if encryptor, password := store.currentEncryptor(); encryptor != nil {
+		var err error
+		if account, err = store.encryptAccount(account, encryptor, password); err != nil {
+			return errors.Wrap(err, "failed to encrypt account")
+		}
+	}
+
 	store.accountsLock.Lock()
-	store.accounts[account.ID().String()] = account.(*wallets.HDAccount)
+	store.accounts[account.ID().String()] = account
 	store.accountsLock.Unlock()
 	return nil
 }
@@ -115,12 +141,113 @@ func (store *InMemStore) OpenAccount(accountID uuid.UUID) (core.ValidatorAccount
 	return val, nil
 }
 
-// SetEncryptor is the encryptor setter
+// SetEncryptor is the encryptor setter, account keys are encrypted with it when marshalling the store
 func (store *InMemStore) SetEncryptor(encryptor encryptor2.Encryptor, password []byte) {
+	store.encryptorLock.Lock()
+	defer store.encryptorLock.Unlock()
 	store.encryptor = encryptor
 	store.encryptionPassword = password
 }
 
+// RotatePassword re-encrypts the key of every account with the given password, which is then used when marshalling the store.
+// Keys are re-encrypted before taking the locks, accounts saved meanwhile are re-encrypted in a following pass.
+func (store *InMemStore) RotatePassword(password []byte) error {
+	encryptor, oldPassword := store.currentEncryptor()
+	if encryptor == nil {
+		return errors.New("no encryptor was set")
+	}
+	if store.keyCache.Locked() {
+		return core.ErrKeyVaultLocked
+	}
+
+	rotated := make(map[core.ValidatorAccount]core.ValidatorAccount)
+	for {
+		store.accountsLock.Lock()
+		accounts := make(map[string]core.ValidatorAccount, len(store.accounts))
+		for id, account := range store.accounts {
+			accounts[id] = account
+		}
+		store.accountsLock.Unlock()
+
+		for id, account := range accounts {
+			if _, done := rotated[account]; done {
+				continue
+			}
+			ret, err := store.encryptAccount(account, encryptor, password)
+			if err != nil {
+				return errors.Wrapf(err, "failed to rotate account %s", id)
+			}
+			rotated[account] = ret
+		}
+
+		if done, err := store.swapRotatedAccounts(accounts, rotated, oldPassword, password); done || err != nil {
+			return err
+		}
+	}
+}
+
+// swapRotatedAccounts replaces the accounts by their rotated copy and sets the new password.
+// Returns false if accounts were saved since they were listed.
+func (store *InMemStore) swapRotatedAccounts(accounts map[string]core.ValidatorAccount, rotated map[core.ValidatorAccount]core.ValidatorAccount, oldPassword []byte, password []byte) (bool, error) {
+	store.accountsLock.Lock()
+	defer store.accountsLock.Unlock()
+	store.encryptorLock.Lock()
+	defer store.encryptorLock.Unlock()
+
+	if !bytes.Equal(store.encryptionPassword, oldPassword) {
+		return false, errors.New("password was changed during the rotation")
+	}
+	if len(store.accounts) != len(accounts) {
+		return false, nil
+	}
+	for id, account := range store.accounts {
+		if accounts[id] != account {
+			return false, nil
+		}
+	}
+
+	ret := make(map[string]core.ValidatorAccount, len(accounts))
+	for id, account := range accounts {
+		ret[id] = rotated[account]
+	}
+	store.accounts = ret
+	store.encryptionPassword = password
+	return true, nil
+}
+
+// KeyCache returns the cache holding the decrypted keys of the accounts
+func (store *InMemStore) KeyCache() *core.KeyCache {
+	return store.keyCache
+}
+
+// encryptAccount returns a copy of the account holding only its key encrypted with the given password
+func (store *InMemStore) encryptAccount(account core.ValidatorAccount, encryptor encryptor2.Encryptor, password []byte) (core.ValidatorAccount, error) {
+	byts, err := wallets.MarshalAccount(account, encryptor, password)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := wallets.UnmarshalAccount(byts, store.decryptKey, store.keyCache)
+	if err != nil {
+		return nil, err
+	}
+	if err := wallets.SetAccountEncryptor(ret, encryptor, password); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (store *InMemStore) currentEncryptor() (encryptor2.Encryptor, []byte) {
+	store.encryptorLock.RLock()
+	defer store.encryptorLock.RUnlock()
+	return store.encryptor, store.encryptionPassword
+}
+
+// decryptKey decrypts account keys with the encryptor set when they are first used
+func (store *InMemStore) decryptKey(crypto map[string]interface{}) ([]byte, error) {
+	encryptor, password := store.currentEncryptor()
+	return core.NewKeyDecryptor(encryptor, password)(crypto)
+}
+
 func (store *InMemStore) freshContext() *core.WalletContext {
 	return &core.WalletContext{
 		Storage: store,
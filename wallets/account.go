@@ -6,10 +6,13 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
 
 	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
 	eth1deposit "github.com/ssvlabs/eth2-key-manager/eth1_deposit"
 )
 
@@ -128,6 +131,20 @@ func NewValidatorAccount(
 	}
 }
 
+// NewValidatorAccountWithID is the constructor of HDAccount keeping an existing account id, like the UUID of an imported keystore
+func NewValidatorAccountWithID(
+	id uuid.UUID,
+	name string,
+	validationKey *core.HDKey,
+	withdrawalPubKey []byte,
+	basePath string,
+	context *core.WalletContext,
+) *HDAccount {
+	ret := NewValidatorAccount(name, validationKey, withdrawalPubKey, basePath, context)
+	ret.id = id
+	return ret
+}
+
 // ID provides the ID for the account.
 func (account *HDAccount) ID() uuid.UUID {
 	return account.id
@@ -158,14 +175,34 @@ func (account *HDAccount) ValidationKeySign(data []byte) ([]byte, error) {
 	return account.validationKey.Sign(data)
 }
 
-// GetDepositData returns deposit data
-func (account *HDAccount) GetDepositData() (map[string]interface{}, error) {
-	depositData, root, err := eth1deposit.DepositData(
-		account.validationKey,
-		account.withdrawalPubKey,
-		account.GetContext().Storage.Network(),
-		eth1deposit.MaxEffectiveBalanceInGwei,
+// ExportKeystore encrypts the validation key in an EIP-2335 keystore, with the account id as the keystore uuid.
+// The plaintext secret is zeroized once encrypted.
+func (account *HDAccount) ExportKeystore(encryptor *keystorev4.Encryptor, password string) (*keystorev4.Keystore, error) {
+	sk, err := account.validationKey.SecretKey()
+	if err != nil {
+		return nil, err
+	}
+	secret := sk.Serialize()
+	*sk = bls.SecretKey{}
+	defer func() {
+		for i := range secret {
+			secret[i] = 0
+		}
+	}()
+	return encryptor.EncryptKeystore(
+		secret,
+		account.ValidatorPublicKey(),
+		account.validationKey.Path(),
+		account.id,
+		account.name,
+		password,
 	)
+}
+
+// GetDepositData returns deposit data.
+// Without options it deposits 32 ETH with BLS withdrawal credentials of the account withdrawal key.
+func (account *HDAccount) GetDepositData(opts ...core.DepositDataOption) (map[string]interface{}, error) {
+	depositData, root, err := account.DepositData(opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -179,6 +216,29 @@ func (account *HDAccount) GetDepositData() (map[string]interface{}, error) {
 	}, nil
 }
 
+// DepositData returns the signed deposit data and its root, see GetDepositData for the options
+func (account *HDAccount) DepositData(opts ...core.DepositDataOption) (*phase0.DepositData, [32]byte, error) {
+	options := core.NewDepositDataOptions(opts...)
+
+	withdrawalCredentials := eth1deposit.BLSWithdrawalCredentials(account.withdrawalPubKey)
+	if options.WithdrawalAddress != nil {
+		withdrawalCredentials = eth1deposit.ExecutionWithdrawalCredentials(*options.WithdrawalAddress, options.Compounding)
+	} else if options.Compounding {
+		return nil, [32]byte{}, errors.New("compounding withdrawal credentials require a withdrawal address")
+	}
+	amount := options.Amount
+	if amount == 0 {
+		amount = eth1deposit.MaxEffectiveBalanceInGwei
+	}
+
+	return eth1deposit.DepositDataWithCredentials(
+		account.validationKey,
+		withdrawalCredentials,
+		account.GetContext().Storage.Network(),
+		amount,
+	)
+}
+
 // SetContext is the context setter
 func (account *HDAccount) SetContext(ctx *core.WalletContext) {
 	account.contextMtx.Lock()
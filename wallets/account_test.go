@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor/keystorev4"
 	"github.com/ssvlabs/eth2-key-manager/stores/dummy"
 )
 
@@ -82,3 +83,38 @@ func TestAccountMarshaling(t *testing.T) {
 		})
 	}
 }
+
+func TestExportKeystore(t *testing.T) {
+	require.NoError(t, core.InitBLS())
+	masterKey, err := core.MasterKeyFromSeed(_byteArray("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1fff"), core.MainNetwork)
+	require.NoError(t, err)
+	validationKey, err := masterKey.Derive("/3/0/0")
+	require.NoError(t, err)
+	account := NewValidatorAccount("account-3", validationKey, nil, "/3", nil)
+
+	for _, cipher := range []string{"scrypt", "pbkdf2"} {
+		t.Run(cipher, func(t *testing.T) {
+			ks, err := account.ExportKeystore(keystorev4.New(keystorev4.WithCipher(cipher)), "password")
+			require.NoError(t, err)
+
+			byts, err := json.Marshal(ks)
+			require.NoError(t, err)
+			var v map[string]interface{}
+			require.NoError(t, json.Unmarshal(byts, &v))
+			require.Equal(t, hex.EncodeToString(account.ValidatorPublicKey()), v["pubkey"])
+			require.Equal(t, "m/12381/3600/3/0/0", v["path"])
+			require.Equal(t, account.ID().String(), v["uuid"])
+			require.Equal(t, "account-3", v["description"])
+			require.EqualValues(t, 4, v["version"])
+			require.Equal(t, cipher, v["crypto"].(map[string]interface{})["kdf"].(map[string]interface{})["function"])
+
+			parsed, err := keystorev4.ParseKeystore(byts)
+			require.NoError(t, err)
+			secret, err := parsed.Decrypt("password")
+			require.NoError(t, err)
+			sk, err := validationKey.SecretKey()
+			require.NoError(t, err)
+			require.Equal(t, sk.Serialize(), secret)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package wallets
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/encryptor"
+)
+
+// MarshalAccount marshals an account for storage, the account key is encrypted with the given encryptor.
+// The key is written in plain text if no encryptor is given, json.Marshal of an account never includes it.
+func MarshalAccount(account core.ValidatorAccount, encryptor encryptor.Encryptor, password []byte) ([]byte, error) {
+	field, key, err := accountKey(account)
+	if err != nil {
+		return nil, err
+	}
+
+	byts, err := json.Marshal(account)
+	if err != nil {
+		return nil, err
+	}
+	var v map[string]json.RawMessage
+	if err := json.Unmarshal(byts, &v); err != nil {
+		return nil, err
+	}
+	if v[field], err = key.MarshalSecretJSON(encryptor, password); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalAccount unmarshals a stored account, either a HDAccount or a ShareAccount.
+// An encrypted account key is decrypted with decrypt when it's used and kept by the given cache, if any.
+func UnmarshalAccount(data []byte, decrypt core.KeyDecryptor, cache *core.KeyCache) (core.ValidatorAccount, error) {
+	var v map[string]json.RawMessage
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	if _, isShare := v["shareKey"]; isShare {
+		ret := &ShareAccount{}
+		if err := json.Unmarshal(data, ret); err != nil {
+			return nil, err
+		}
+		ret.shareKey.SetDecryptor(decrypt, cache)
+		return ret, nil
+	}
+
+	ret := &HDAccount{}
+	if err := json.Unmarshal(data, ret); err != nil {
+		return nil, err
+	}
+	ret.validationKey.SetDecryptor(decrypt, cache)
+	return ret, nil
+}
+
+// CheckAccountDecryptor checks the given decryptor decrypts the account key, without keeping the decrypted key.
+// Returns false if the account key isn't encrypted.
+func CheckAccountDecryptor(account core.ValidatorAccount, decrypt core.KeyDecryptor) (bool, error) {
+	_, key, err := accountKey(account)
+	if err != nil {
+		return false, err
+	}
+	return key.CheckDecryptor(decrypt)
+}
+
+// SetAccountEncryptor records the encryptor and password which encrypted the account key,
+// MarshalAccount then reuses the encrypted key rather than decrypting it when given the same ones.
+func SetAccountEncryptor(account core.ValidatorAccount, encryptor encryptor.Encryptor, password []byte) error {
+	_, key, err := accountKey(account)
+	if err != nil {
+		return err
+	}
+	key.SetEncryptor(encryptor, password)
+	return nil
+}
+
+// accountKey returns the key of the account, with its marshalled field name
+func accountKey(account core.ValidatorAccount) (string, *core.HDKey, error) {
+	switch account := account.(type) {
+	case *HDAccount:
+		return "validationKey", account.validationKey, nil
+	case *ShareAccount:
+		return "shareKey", account.shareKey, nil
+	default:
+		return "", nil, errors.Errorf("unknown account type %T", account)
+	}
+}
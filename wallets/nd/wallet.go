@@ -4,6 +4,8 @@ import (
 	"encoding/hex"
 	"sort"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
@@ -23,6 +25,11 @@ type Wallet struct {
 	walletType  core.WalletType
 	indexMapper map[string]uuid.UUID
 	context     *core.WalletContext
+
+	// lock guards indexMapper and context, which signers read while accounts are added or deleted
+	lock sync.RWMutex
+	// writeLock serializes the account additions and deletions, from the storage to the saved wallet
+	writeLock sync.Mutex
 }
 
 // NewWallet is the constructor of Wallet
@@ -47,11 +54,17 @@ func (wallet *Wallet) Type() core.WalletType {
 
 // GetNextAccountIndex provides next index to create account at.
 func (wallet *Wallet) GetNextAccountIndex() int {
-	if len(wallet.indexMapper) == 0 {
+	wallet.lock.RLock()
+	empty := len(wallet.indexMapper) == 0
+	wallet.lock.RUnlock()
+	if empty {
 		return 0
 	}
 	accounts := wallet.Accounts()
-	index, _ := strconv.ParseInt(accounts[0].BasePath()[1:], 0, 64)
+	if len(accounts) == 0 {
+		return 0
+	}
+	index, _ := strconv.ParseInt(strings.TrimPrefix(accounts[0].BasePath(), "/"), 0, 64)
 	return int(index) + 1
 }
 
@@ -67,16 +80,20 @@ func (wallet *Wallet) CreateValidatorAccountFromPrivateKey(_ []byte, _ *int) (co
 
 // AddValidatorAccount adds the given account
 func (wallet *Wallet) AddValidatorAccount(account core.ValidatorAccount) error {
-	validatorPublicKey := hex.EncodeToString(account.ValidatorPublicKey())
-	wallet.indexMapper[validatorPublicKey] = account.ID()
+	wallet.writeLock.Lock()
+	defer wallet.writeLock.Unlock()
+
+	storage := wallet.walletContext().Storage
 
 	// Store account
-	if err := wallet.context.Storage.SaveAccount(account); err != nil {
+	if err := storage.SaveAccount(account); err != nil {
 		return err
 	}
+	validatorPublicKey := hex.EncodeToString(account.ValidatorPublicKey())
+	wallet.setIndex(validatorPublicKey, account.ID())
 
 	// Store wallet
-	err := wallet.context.Storage.SaveWallet(wallet)
+	err := storage.SaveWallet(wallet)
 	if err != nil {
 		return err
 	}
@@ -86,17 +103,23 @@ func (wallet *Wallet) AddValidatorAccount(account core.ValidatorAccount) error {
 
 // DeleteAccountByPublicKey deletes account by public key
 func (wallet *Wallet) DeleteAccountByPublicKey(pubKey string) error {
+	wallet.writeLock.Lock()
+	defer wallet.writeLock.Unlock()
+
 	account, err := wallet.AccountByPublicKey(pubKey)
 	if err != nil {
 		return errors.Wrap(err, "failed to get account by public key")
 	}
 
-	if err := wallet.context.Storage.DeleteAccount(account.ID()); err != nil {
+	// the account is unmapped first, so signers don't find an account missing from the storage
+	wallet.deleteIndex(pubKey)
+	storage := wallet.walletContext().Storage
+	if err := storage.DeleteAccount(account.ID()); err != nil {
+		wallet.setIndex(pubKey, account.ID())
 		return errors.Wrap(err, "failed to delete account from store")
 	}
-	delete(wallet.indexMapper, pubKey)
 
-	if err := wallet.context.Storage.SaveWallet(wallet); err != nil {
+	if err := storage.SaveWallet(wallet); err != nil {
 		return errors.Wrap(err, "failed to save wallet")
 	}
 	return nil
@@ -104,9 +127,15 @@ func (wallet *Wallet) DeleteAccountByPublicKey(pubKey string) error {
 
 // Accounts provides all accounts in the wallet.
 func (wallet *Wallet) Accounts() []core.ValidatorAccount {
-	accounts := make([]core.ValidatorAccount, 0)
-	for pubKey := range wallet.indexMapper {
-		id := wallet.indexMapper[pubKey]
+	wallet.lock.RLock()
+	ids := make([]uuid.UUID, 0, len(wallet.indexMapper))
+	for _, id := range wallet.indexMapper {
+		ids = append(ids, id)
+	}
+	wallet.lock.RUnlock()
+
+	accounts := make([]core.ValidatorAccount, 0, len(ids))
+	for _, id := range ids {
 		account, err := wallet.AccountByID(id)
 		if err != nil {
 			continue
@@ -114,8 +143,8 @@ func (wallet *Wallet) Accounts() []core.ValidatorAccount {
 		accounts = append(accounts, account)
 	}
 	sort.Slice(accounts, func(i, j int) bool {
-		a, _ := strconv.ParseInt(accounts[i].BasePath()[1:], 0, 64)
-		b, _ := strconv.ParseInt(accounts[j].BasePath()[1:], 0, 64)
+		a, _ := strconv.ParseInt(strings.TrimPrefix(accounts[i].BasePath(), "/"), 0, 64)
+		b, _ := strconv.ParseInt(strings.TrimPrefix(accounts[j].BasePath(), "/"), 0, 64)
 		return a > b
 	})
 	return accounts
@@ -124,7 +153,8 @@ func (wallet *Wallet) Accounts() []core.ValidatorAccount {
 // AccountByID provides a nd account from the wallet given its ID.
 // This will error if the account is not found.
 func (wallet *Wallet) AccountByID(id uuid.UUID) (core.ValidatorAccount, error) {
-	ret, err := wallet.context.Storage.OpenAccount(id)
+	context := wallet.walletContext()
+	ret, err := context.Storage.OpenAccount(id)
 	if err != nil {
 		return nil, err
 	}
@@ -132,21 +162,46 @@ func (wallet *Wallet) AccountByID(id uuid.UUID) (core.ValidatorAccount, error) {
 		return nil, ErrAccountNotFound
 	}
 
-	ret.SetContext(wallet.context)
+	ret.SetContext(context)
 	return ret, nil
 }
 
 // SetContext is the context setter
 func (wallet *Wallet) SetContext(ctx *core.WalletContext) {
+	wallet.lock.Lock()
 	wallet.context = ctx
+	wallet.lock.Unlock()
 }
 
 // AccountByPublicKey provides a nd account from the wallet given its public key.
 // This will error if the account is not found.
 func (wallet *Wallet) AccountByPublicKey(pubKey string) (core.ValidatorAccount, error) {
+	wallet.lock.RLock()
 	id, exists := wallet.indexMapper[pubKey]
+	wallet.lock.RUnlock()
 	if !exists {
 		return nil, ErrAccountNotFound
 	}
 	return wallet.AccountByID(id)
 }
+
+// walletContext returns the wallet context
+func (wallet *Wallet) walletContext() *core.WalletContext {
+	wallet.lock.RLock()
+	defer wallet.lock.RUnlock()
+	return wallet.context
+}
+
+// setIndex maps the given validator public key to the account ID
+func (wallet *Wallet) setIndex(pubKey string, id uuid.UUID) {
+	wallet.lock.Lock()
+	wallet.indexMapper[pubKey] = id
+	wallet.lock.Unlock()
+}
+
+// deleteIndex unmaps the given validator public key
+func (wallet *Wallet) deleteIndex(pubKey string) {
+	wallet.lock.Lock()
+	delete(wallet.indexMapper, pubKey)
+	wallet.lock.Unlock()
+}
@@ -13,7 +13,13 @@ func (wallet *Wallet) MarshalJSON() ([]byte, error) {
 
 	data["id"] = wallet.id
 	data["type"] = wallet.walletType
-	data["indexMapper"] = wallet.indexMapper
+	wallet.lock.RLock()
+	indexMapper := make(map[string]uuid.UUID, len(wallet.indexMapper))
+	for k, v := range wallet.indexMapper {
+		indexMapper[k] = v
+	}
+	wallet.lock.RUnlock()
+	data["indexMapper"] = indexMapper
 
 	return json.Marshal(data)
 }
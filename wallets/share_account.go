@@ -0,0 +1,192 @@
+package wallets
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// ShareAccount represents a threshold share of a validator key, to be added to a ND wallet.
+// It signs with the share key, partial signatures are combined with threshold.RecoverSignature.
+type ShareAccount struct {
+	name               string
+	basePath           string
+	id                 uuid.UUID
+	shareKey           *core.HDKey
+	shareIndex         uint64
+	threshold          uint64
+	verificationVector [][]byte
+	contextMtx         sync.RWMutex
+	context            *core.WalletContext
+}
+
+// NewShareAccount is the constructor of ShareAccount, the first element of the verification vector is the group public key.
+// The share public key must match the verification vector evaluated at the share index.
+func NewShareAccount(
+	name string,
+	shareKey *core.HDKey,
+	shareIndex uint64,
+	threshold uint64,
+	verificationVector [][]byte,
+	basePath string,
+	context *core.WalletContext,
+) (*ShareAccount, error) {
+	expected, err := core.SharePublicKey(verificationVector, shareIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(shareKey.PublicKey().Serialize(), expected) {
+		return nil, errors.Errorf("share %d doesn't match the verification vector", shareIndex)
+	}
+
+	return &ShareAccount{
+		name:               name,
+		id:                 uuid.New(),
+		shareKey:           shareKey,
+		shareIndex:         shareIndex,
+		threshold:          threshold,
+		verificationVector: verificationVector,
+		basePath:           basePath,
+		context:            context,
+	}, nil
+}
+
+// MarshalJSON is the custom JSON marshaler
+func (account *ShareAccount) MarshalJSON() ([]byte, error) {
+	data := make(map[string]interface{})
+
+	data["id"] = account.id
+	data["name"] = account.name
+	data["shareKey"] = account.shareKey
+	data["shareIndex"] = account.shareIndex
+	data["threshold"] = account.threshold
+	vv := make([]string, len(account.verificationVector))
+	for i, pubKey := range account.verificationVector {
+		vv[i] = hex.EncodeToString(pubKey)
+	}
+	data["verificationVector"] = vv
+	data["baseAccountPath"] = account.basePath
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON is the custom JSON unmarshaler
+func (account *ShareAccount) UnmarshalJSON(data []byte) error {
+	var v struct {
+		ID                 uuid.UUID   `json:"id"`
+		Name               string      `json:"name"`
+		ShareKey           *core.HDKey `json:"shareKey"`
+		ShareIndex         uint64      `json:"shareIndex"`
+		Threshold          uint64      `json:"threshold"`
+		VerificationVector []string    `json:"verificationVector"`
+		BasePath           string      `json:"baseAccountPath"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.ID == uuid.Nil {
+		return errors.New("could not find var: id")
+	}
+	if v.ShareKey == nil {
+		return errors.New("could not find var: shareKey")
+	}
+	if v.ShareIndex == 0 {
+		return errors.New("could not find var: shareIndex")
+	}
+	if len(v.VerificationVector) == 0 {
+		return errors.New("could not find var: verificationVector")
+	}
+
+	account.verificationVector = make([][]byte, len(v.VerificationVector))
+	for i, pubKey := range v.VerificationVector {
+		byts, err := hex.DecodeString(pubKey)
+		if err != nil {
+			return err
+		}
+		account.verificationVector[i] = byts
+	}
+	account.id = v.ID
+	account.name = v.Name
+	account.shareKey = v.ShareKey
+	account.shareIndex = v.ShareIndex
+	account.threshold = v.Threshold
+	account.basePath = v.BasePath
+	return nil
+}
+
+// ID provides the ID for the account.
+func (account *ShareAccount) ID() uuid.UUID {
+	return account.id
+}
+
+// Name provides the name for the account.
+func (account *ShareAccount) Name() string {
+	return account.name
+}
+
+// BasePath provides the basePth of the account.
+func (account *ShareAccount) BasePath() string {
+	return account.basePath
+}
+
+// ValidatorPublicKey provides the public key of the share.
+func (account *ShareAccount) ValidatorPublicKey() []byte {
+	return account.shareKey.PublicKey().Serialize()
+}
+
+// WithdrawalPublicKey returns nil, shares have no withdrawal key.
+func (account *ShareAccount) WithdrawalPublicKey() []byte {
+	return nil
+}
+
+// ValidationKeySign signs data with the share key, producing a partial signature.
+func (account *ShareAccount) ValidationKeySign(data []byte) ([]byte, error) {
+	return account.shareKey.Sign(data)
+}
+
+// GetDepositData returns an error, deposits must be signed by the full validator key
+func (account *ShareAccount) GetDepositData(...core.DepositDataOption) (map[string]interface{}, error) {
+	return nil, errors.New("share accounts can't create deposit data")
+}
+
+// ShareIndex provides the index of the share, starting at 1
+func (account *ShareAccount) ShareIndex() uint64 {
+	return account.shareIndex
+}
+
+// Threshold provides the number of shares needed to recover a signature
+func (account *ShareAccount) Threshold() uint64 {
+	return account.threshold
+}
+
+// GroupPublicKey provides the public key of the validator
+func (account *ShareAccount) GroupPublicKey() []byte {
+	if len(account.verificationVector) == 0 {
+		return nil
+	}
+	return account.verificationVector[0]
+}
+
+// VerificationVector provides the public keys of the sharing polynomial coefficients
+func (account *ShareAccount) VerificationVector() [][]byte {
+	return account.verificationVector
+}
+
+// SetContext is the context setter
+func (account *ShareAccount) SetContext(ctx *core.WalletContext) {
+	account.contextMtx.Lock()
+	defer account.contextMtx.Unlock()
+	account.context = ctx
+}
+
+// GetContext is the context getter
+func (account *ShareAccount) GetContext() *core.WalletContext {
+	account.contextMtx.RLock()
+	defer account.contextMtx.RUnlock()
+	return account.context
+}
@@ -0,0 +1,163 @@
+package threshold
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+
+	"github.com/ssvlabs/eth2-key-manager/core"
+)
+
+// Share is a single share of a split validator key, its index is the x coordinate of the share (starting at 1)
+type Share struct {
+	Index uint64
+	Key   *core.HDKey
+}
+
+// PublicKey returns the serialized public key of the share
+func (share *Share) PublicKey() []byte {
+	return share.Key.PublicKey().Serialize()
+}
+
+// VerificationVector holds the serialized public keys of the coefficients of the sharing polynomial,
+// its first element is the group (validator) public key
+type VerificationVector [][]byte
+
+// GroupPublicKey returns the group public key
+func (vv VerificationVector) GroupPublicKey() []byte {
+	if len(vv) == 0 {
+		return nil
+	}
+	return vv[0]
+}
+
+// SharePublicKey evaluates the verification vector at the given share index
+func (vv VerificationVector) SharePublicKey(index uint64) ([]byte, error) {
+	return core.SharePublicKey(vv, index)
+}
+
+// Split splits the given key into count shares, any threshold of which can recover a signature of the key.
+// Shares are indexed from 1 to count, the plaintext secrets of the sharing polynomial are zeroized once split.
+func Split(key *core.HDKey, threshold uint64, count uint64) ([]*Share, VerificationVector, error) {
+	if threshold == 0 || threshold > count {
+		return nil, nil, errors.Errorf("invalid threshold %d of %d shares", threshold, count)
+	}
+
+	sk, err := key.SecretKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	msk := sk.GetMasterSecretKey(int(threshold))
+	*sk = bls.SecretKey{}
+	defer func() {
+		for i := range msk {
+			msk[i] = bls.SecretKey{}
+		}
+	}()
+	vv := make(VerificationVector, threshold)
+	for i, pub := range bls.GetMasterPublicKey(msk) {
+		vv[i] = pub.Serialize()
+	}
+
+	shares := make([]*Share, count)
+	for i := range shares {
+		index := uint64(i + 1)
+		id, err := shareID(index)
+		if err != nil {
+			return nil, nil, err
+		}
+		sk := &bls.SecretKey{}
+		if err := sk.Set(msk, id); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to compute share %d", index)
+		}
+		secret := sk.Serialize()
+		*sk = bls.SecretKey{}
+		shareKey, err := core.NewHDKeyFromPrivateKey(secret, key.Path())
+		for j := range secret {
+			secret[j] = 0
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		shares[i] = &Share{Index: index, Key: shareKey}
+	}
+	return shares, vv, nil
+}
+
+// VerifyShare checks the share public key matches the verification vector
+func VerifyShare(index uint64, sharePublicKey []byte, vv VerificationVector) error {
+	expected, err := vv.SharePublicKey(index)
+	if err != nil {
+		return err
+	}
+	pub := &bls.PublicKey{}
+	if err := pub.Deserialize(sharePublicKey); err != nil {
+		return errors.Wrap(err, "invalid share public key")
+	}
+	if !bytes.Equal(pub.Serialize(), expected) {
+		return errors.Errorf("share %d doesn't match the verification vector", index)
+	}
+	return nil
+}
+
+// RecoverPublicKey rebuilds the group public key from at least threshold share public keys, by share index
+func RecoverPublicKey(sharePublicKeys map[uint64][]byte) ([]byte, error) {
+	indexes, ids, err := shareIDs(sharePublicKeys)
+	if err != nil {
+		return nil, err
+	}
+	pubs := make([]bls.PublicKey, len(indexes))
+	for i, index := range indexes {
+		if err := pubs[i].Deserialize(sharePublicKeys[index]); err != nil {
+			return nil, errors.Wrapf(err, "invalid public key of share %d", index)
+		}
+	}
+
+	ret := &bls.PublicKey{}
+	if err := ret.Recover(pubs, ids); err != nil {
+		return nil, errors.Wrap(err, "failed to recover public key")
+	}
+	return ret.Serialize(), nil
+}
+
+// RecoverSignature recovers the signature of the group key from at least threshold partial signatures, by share index.
+// Use core.RecoverSignature to verify the partial and recovered signatures as well.
+func RecoverSignature(partialSignatures map[uint64][]byte) ([]byte, error) {
+	return core.InterpolateSignature(partialSignatures)
+}
+
+// shareIDs returns the sorted share indexes of the given map and their ids
+func shareIDs(byIndex map[uint64][]byte) ([]uint64, []bls.ID, error) {
+	if len(byIndex) == 0 {
+		return nil, nil, errors.New("no shares were given")
+	}
+	indexes := make([]uint64, 0, len(byIndex))
+	for index := range byIndex {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	ids := make([]bls.ID, len(indexes))
+	for i, index := range indexes {
+		id, err := shareID(index)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids[i] = *id
+	}
+	return indexes, ids, nil
+}
+
+func shareID(index uint64) (*bls.ID, error) {
+	if index == 0 {
+		return nil, errors.New("share index must be positive")
+	}
+	id := &bls.ID{}
+	if err := id.SetDecString(strconv.FormatUint(index, 10)); err != nil {
+		return nil, errors.Wrapf(err, "invalid share index %d", index)
+	}
+	return id, nil
+}
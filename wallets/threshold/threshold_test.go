@@ -0,0 +1,170 @@
+package threshold
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	eth2keymanager "github.com/ssvlabs/eth2-key-manager"
+	"github.com/ssvlabs/eth2-key-manager/core"
+	"github.com/ssvlabs/eth2-key-manager/signer"
+	prot "github.com/ssvlabs/eth2-key-manager/slashing_protection"
+	"github.com/ssvlabs/eth2-key-manager/stores/inmemory"
+	"github.com/ssvlabs/eth2-key-manager/wallets"
+)
+
+func _byteArray(input string) []byte {
+	res, _ := hex.DecodeString(input)
+	return res
+}
+
+func testKey(t *testing.T) *core.HDKey {
+	require.NoError(t, core.InitBLS())
+	key, err := core.NewHDKeyFromPrivateKey(_byteArray("2c083f2c8fc923fa2bd32a70ab72b4b46247e8c1f347adc9fd2ac29e6b5b51ad"), "")
+	require.NoError(t, err)
+	return key
+}
+
+func TestSplit(t *testing.T) {
+	key := testKey(t)
+	shares, vv, err := Split(key, 3, 4)
+	require.NoError(t, err)
+	require.Len(t, shares, 4)
+	require.Len(t, vv, 3)
+	require.Equal(t, key.PublicKey().Serialize(), vv.GroupPublicKey())
+
+	for i, share := range shares {
+		require.EqualValues(t, i+1, share.Index)
+		require.NoError(t, VerifyShare(share.Index, share.PublicKey(), vv))
+	}
+	require.EqualError(t, VerifyShare(1, shares[1].PublicKey(), vv), "share 1 doesn't match the verification vector")
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		_, _, err := Split(key, 5, 4)
+		require.EqualError(t, err, "invalid threshold 5 of 4 shares")
+		_, _, err = Split(key, 0, 4)
+		require.EqualError(t, err, "invalid threshold 0 of 4 shares")
+	})
+}
+
+func TestRecover(t *testing.T) {
+	key := testKey(t)
+	shares, _, err := Split(key, 3, 4)
+	require.NoError(t, err)
+
+	msg := _byteArray("7920f65abe2efb506d0ec763e227ab58978b6e2dda41d4bc2ceb785b4084b0fa")
+	expected, err := key.Sign(msg)
+	require.NoError(t, err)
+
+	subsets := [][]int{{0, 1, 2}, {1, 2, 3}, {0, 2, 3}, {0, 1, 2, 3}}
+	for _, subset := range subsets {
+		t.Run(fmt.Sprint(subset), func(t *testing.T) {
+			pubKeys := make(map[uint64][]byte)
+			sigs := make(map[uint64][]byte)
+			for _, i := range subset {
+				pubKeys[shares[i].Index] = shares[i].PublicKey()
+				sigs[shares[i].Index], err = shares[i].Key.Sign(msg)
+				require.NoError(t, err)
+			}
+
+			pubKey, err := RecoverPublicKey(pubKeys)
+			require.NoError(t, err)
+			require.Equal(t, key.PublicKey().Serialize(), pubKey)
+
+			sig, err := RecoverSignature(sigs)
+			require.NoError(t, err)
+			require.Equal(t, expected, sig)
+		})
+	}
+
+	t.Run("below threshold", func(t *testing.T) {
+		sigs := make(map[uint64][]byte)
+		for _, share := range shares[:2] {
+			sigs[share.Index], err = share.Key.Sign(msg)
+			require.NoError(t, err)
+		}
+		sig, err := RecoverSignature(sigs)
+		require.NoError(t, err)
+		require.NotEqual(t, expected, sig)
+	})
+
+	t.Run("invalid index", func(t *testing.T) {
+		_, err := RecoverSignature(map[uint64][]byte{0: expected})
+		require.EqualError(t, err, "share index must be positive")
+	})
+}
+
+func TestShareAccountSigning(t *testing.T) {
+	key := testKey(t)
+	shares, vv, err := Split(key, 3, 4)
+	require.NoError(t, err)
+	domain := phase0.Domain{}
+	copy(domain[:], _byteArray("0000000081509579e35e84020ad8751eca180b44df470332d3ad17fc6fd52459"))
+
+	sigs := make(map[uint64][]byte)
+	for _, share := range shares[1:] {
+		store := inmemory.NewInMemStore(core.MainNetwork)
+		options := &eth2keymanager.KeyVaultOptions{}
+		options.SetStorage(store).SetWalletType(core.NDWallet)
+		vault, err := eth2keymanager.NewKeyVault(options)
+		require.NoError(t, err)
+		wallet, err := vault.Wallet()
+		require.NoError(t, err)
+
+		account, err := wallets.NewShareAccount("share", share.Key, share.Index, 3, vv, "", vault.Context)
+		require.NoError(t, err)
+		require.NoError(t, wallet.AddValidatorAccount(account))
+
+		// the account is loaded back from the marshaled store
+		byts, err := json.Marshal(store)
+		require.NoError(t, err)
+		loaded := &inmemory.InMemStore{}
+		require.NoError(t, json.Unmarshal(byts, loaded))
+		wallet, err = loaded.OpenWallet()
+		require.NoError(t, err)
+		opened, err := wallet.AccountByPublicKey(hex.EncodeToString(share.PublicKey()))
+		require.NoError(t, err)
+		shareAccount, ok := opened.(*wallets.ShareAccount)
+		require.True(t, ok)
+		require.Equal(t, share.Index, shareAccount.ShareIndex())
+		require.EqualValues(t, 3, shareAccount.Threshold())
+		require.Equal(t, key.PublicKey().Serialize(), shareAccount.GroupPublicKey())
+		require.NoError(t, VerifyShare(shareAccount.ShareIndex(), shareAccount.ValidatorPublicKey(), shareAccount.VerificationVector()))
+
+		simpleSigner := signer.NewSimpleSigner(wallet, &prot.NoProtection{}, core.MainNetwork)
+		sigs[share.Index], _, err = simpleSigner.SignEpoch(1, domain, share.PublicKey())
+		require.NoError(t, err)
+	}
+
+	root, err := signer.ComputeETHSigningRoot(signer.SSZUint64(1), domain)
+	require.NoError(t, err)
+	expected, err := key.Sign(root[:])
+	require.NoError(t, err)
+
+	sig, err := RecoverSignature(sigs)
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+}
+
+func TestNewShareAccount(t *testing.T) {
+	key := testKey(t)
+	shares, vv, err := Split(key, 3, 4)
+	require.NoError(t, err)
+
+	account, err := wallets.NewShareAccount("share", shares[1].Key, shares[1].Index, 3, vv, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey().Serialize(), account.GroupPublicKey())
+
+	_, err = wallets.NewShareAccount("share", shares[1].Key, shares[0].Index, 3, vv, "", nil)
+	require.EqualError(t, err, "share 1 doesn't match the verification vector")
+	_, err = wallets.NewShareAccount("share", shares[1].Key, 0, 3, vv, "", nil)
+	require.EqualError(t, err, "share index must be positive")
+	_, err = wallets.NewShareAccount("share", shares[1].Key, shares[1].Index, 3, nil, "", nil)
+	require.EqualError(t, err, "verification vector is empty")
+	_, err = wallets.NewShareAccount("share", shares[1].Key, shares[1].Index, 3, [][]byte{{1, 2, 3}}, "", nil)
+	require.EqualError(t, err, "invalid verification vector public key 0: err blsPublicKeyDeserialize 010203")
+}